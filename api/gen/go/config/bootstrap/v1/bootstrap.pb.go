@@ -36,7 +36,10 @@ type Bootstrap struct {
 	// paths provides an optional mapping from a component name to its configuration path.
 	// The keys of this map should correspond to the predefined Component* constants
 	// in the Go bootstrap package (e.g., "logger", "registries").
-	Paths         map[string]string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Paths map[string]string `protobuf:"bytes,3,rep,name=paths,proto3" json:"paths,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// version declares the schema version of the configuration files loaded from the sources.
+	// Files written for an older version are migrated to the current one before they are decoded.
+	Version       string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -92,15 +95,23 @@ func (x *Bootstrap) GetPaths() map[string]string {
 	return nil
 }
 
+func (x *Bootstrap) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
 var File_config_bootstrap_v1_bootstrap_proto protoreflect.FileDescriptor
 
 const file_config_bootstrap_v1_bootstrap_proto_rawDesc = "" +
 	"\n" +
-	"#config/bootstrap/v1/bootstrap.proto\x12\x1fruntime.api.config.bootstrap.v1\x1a\x1dconfig/source/v1/source.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x17config/app/v1/app.proto\"\xc5\x03\n" +
+	"#config/bootstrap/v1/bootstrap.proto\x12\x1fruntime.api.config.bootstrap.v1\x1a\x1dconfig/source/v1/source.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x17config/app/v1/app.proto\"\xbe\x04\n" +
 	"\tBootstrap\x12n\n" +
 	"\x03app\x18\x01 \x01(\v2\x1e.runtime.api.config.app.v1.AppB<\xbaG9\x92\x026Application-specific information defined in bootstrap.R\x03app\x12w\n" +
 	"\asources\x18\x02 \x03(\v2*.runtime.api.config.source.v1.SourceConfigB1\xbaG.\x92\x02+List of configuration sources to be loaded.R\asources\x12\x94\x01\n" +
-	"\x05paths\x18\x03 \x03(\v25.runtime.api.config.bootstrap.v1.Bootstrap.PathsEntryBG\xbaGD\x92\x02AOptional mapping from a component name to its configuration path.R\x05paths\x1a8\n" +
+	"\x05paths\x18\x03 \x03(\v25.runtime.api.config.bootstrap.v1.Bootstrap.PathsEntryBG\xbaGD\x92\x02AOptional mapping from a component name to its configuration path.R\x05paths\x12w\n" +
+	"\aversion\x18\x04 \x01(\tB]\xbaGZ\x92\x02WSchema version of the configuration files, used to migrate them to the current version.R\aversion\x1a8\n" +
 	"\n" +
 	"PathsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
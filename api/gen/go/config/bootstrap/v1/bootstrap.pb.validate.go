@@ -122,6 +122,8 @@ func (m *Bootstrap) validate(all bool) error {
 
 	// no validation rules for Paths
 
+	// no validation rules for Version
+
 	if len(errors) > 0 {
 		return BootstrapMultiError(errors)
 	}
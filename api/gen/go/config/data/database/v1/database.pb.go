@@ -176,12 +176,12 @@ var File_config_data_database_v1_database_proto protoreflect.FileDescriptor
 
 const file_config_data_database_v1_database_proto_rawDesc = "" +
 	"\n" +
-	"&config/data/database/v1/database.proto\x12#runtime.api.config.data.database.v1\x1a'config/data/database/v1/migration.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xbc\t\n" +
+	"&config/data/database/v1/database.proto\x12#runtime.api.config.data.database.v1\x1a'config/data/database/v1/migration.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xbf\t\n" +
 	"\x0eDatabaseConfig\x12O\n" +
 	"\x04name\x18\x01 \x01(\tB;\xbaG8\x92\x025Unique name for this database configuration instance.R\x04name\x129\n" +
 	"\x05debug\x18\x02 \x01(\bB#\xbaG \x92\x02\x1dwhether to enable debug mode R\x05debug\x12\x91\x01\n" +
-	"\adialect\x18\x03 \x01(\tBw\xbaGt\x92\x02qDatabase dialect name. Built-in: 'mysql', 'postgresql', 'sqlite', etc. Custom dialects use their registered name.R\adialect\x124\n" +
-	"\x06source\x18\x04 \x01(\tB\x1c\xbaG\x19\x92\x02\x16data source dsn stringR\x06source\x12b\n" +
+	"\adialect\x18\x03 \x01(\tBw\xbaGt\x92\x02qDatabase dialect name. Built-in: 'mysql', 'postgresql', 'sqlite', etc. Custom dialects use their registered name.R\adialect\x127\n" +
+	"\x06source\x18\x04 \x01(\tB\x1f\xbaG\x19\x92\x02\x16data source dsn string\x80\x01\x01R\x06source\x12b\n" +
 	"\tmigration\x18\n" +
 	" \x01(\v2..runtime.api.config.data.database.v1.MigrationB\x14\xbaG\x11\x92\x02\x0edata migrationR\tmigration\x12>\n" +
 	"\fenable_trace\x18\f \x01(\bB\x1a\xbaG\x17\x92\x02\x14link tracking switchR\fenable_trace\x12I\n" +
@@ -10,6 +10,7 @@ import (
 	_ "github.com/google/gnostic/openapiv3"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -181,10 +182,24 @@ type Logger struct {
 	CallerSkip uint32 `protobuf:"varint,9,opt,name=caller_skip,proto3" json:"caller_skip,omitempty"`
 	// Logger time format
 	TimeFormat string `protobuf:"bytes,10,opt,name=time_format,proto3" json:"time_format,omitempty"`
+	// Logger levels of modules, keyed by module name
+	ModuleLevels map[string]string `protobuf:"bytes,11,rep,name=module_levels,proto3" json:"module_levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	// Logger outputs, each entry is written to all of them
+	Outputs []*Logger_Output `protobuf:"bytes,12,rep,name=outputs,proto3" json:"outputs,omitempty"`
 	// Logger file output logger
 	File *Logger_File `protobuf:"bytes,100,opt,name=file,proto3" json:"file,omitempty"`
 	// Logger dev logger logger
-	DevLogger     *Logger_DevLogger `protobuf:"bytes,101,opt,name=dev_logger,proto3" json:"dev_logger,omitempty"` //DevLogger
+	DevLogger *Logger_DevLogger `protobuf:"bytes,101,opt,name=dev_logger,proto3" json:"dev_logger,omitempty"` //DevLogger
+	// Logger redaction of sensitive values
+	Redaction *Logger_Redaction `protobuf:"bytes,102,opt,name=redaction,proto3" json:"redaction,omitempty"`
+	// Logger sampling of repeated entries
+	Sampling *Logger_Sampling `protobuf:"bytes,103,opt,name=sampling,proto3" json:"sampling,omitempty"`
+	// Logger hooks
+	Hooks []*Logger_Hook `protobuf:"bytes,104,rep,name=hooks,proto3" json:"hooks,omitempty"`
+	// Logger context fields
+	Context *Logger_Context `protobuf:"bytes,105,opt,name=context,proto3" json:"context,omitempty"`
+	// Logger in-memory buffer
+	Buffer        *Logger_Buffer `protobuf:"bytes,106,opt,name=buffer,proto3" json:"buffer,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -289,6 +304,20 @@ func (x *Logger) GetTimeFormat() string {
 	return ""
 }
 
+func (x *Logger) GetModuleLevels() map[string]string {
+	if x != nil {
+		return x.ModuleLevels
+	}
+	return nil
+}
+
+func (x *Logger) GetOutputs() []*Logger_Output {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
 func (x *Logger) GetFile() *Logger_File {
 	if x != nil {
 		return x.File
@@ -303,6 +332,105 @@ func (x *Logger) GetDevLogger() *Logger_DevLogger {
 	return nil
 }
 
+func (x *Logger) GetRedaction() *Logger_Redaction {
+	if x != nil {
+		return x.Redaction
+	}
+	return nil
+}
+
+func (x *Logger) GetSampling() *Logger_Sampling {
+	if x != nil {
+		return x.Sampling
+	}
+	return nil
+}
+
+func (x *Logger) GetHooks() []*Logger_Hook {
+	if x != nil {
+		return x.Hooks
+	}
+	return nil
+}
+
+func (x *Logger) GetContext() *Logger_Context {
+	if x != nil {
+		return x.Context
+	}
+	return nil
+}
+
+func (x *Logger) GetBuffer() *Logger_Buffer {
+	if x != nil {
+		return x.Buffer
+	}
+	return nil
+}
+
+// Loggers is a collection of named Logger configurations.
+type Loggers struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Active logger name, overrides default.
+	Active *string `protobuf:"bytes,1,opt,name=active,proto3,oneof" json:"active,omitempty"`
+	// Default logger configuration.
+	Default *Logger `protobuf:"bytes,2,opt,name=default,proto3,oneof" json:"default,omitempty"`
+	// List of named Logger configurations.
+	Configs       []*Logger `protobuf:"bytes,3,rep,name=configs,proto3" json:"configs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Loggers) Reset() {
+	*x = Loggers{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Loggers) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Loggers) ProtoMessage() {}
+
+func (x *Loggers) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Loggers.ProtoReflect.Descriptor instead.
+func (*Loggers) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Loggers) GetActive() string {
+	if x != nil && x.Active != nil {
+		return *x.Active
+	}
+	return ""
+}
+
+func (x *Loggers) GetDefault() *Logger {
+	if x != nil {
+		return x.Default
+	}
+	return nil
+}
+
+func (x *Loggers) GetConfigs() []*Logger {
+	if x != nil {
+		return x.Configs
+	}
+	return nil
+}
+
 // Logger file
 type Logger_File struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -313,13 +441,16 @@ type Logger_File struct {
 	MaxSize       int32                  `protobuf:"varint,5,opt,name=max_size,proto3" json:"max_size,omitempty"`
 	MaxAge        int32                  `protobuf:"varint,6,opt,name=max_age,proto3" json:"max_age,omitempty"`
 	MaxBackups    int32                  `protobuf:"varint,7,opt,name=max_backups,proto3" json:"max_backups,omitempty"`
+	Rotation      string                 `protobuf:"bytes,8,opt,name=rotation,proto3" json:"rotation,omitempty"`
+	Pattern       string                 `protobuf:"bytes,9,opt,name=pattern,proto3" json:"pattern,omitempty"`
+	MaxTotalSize  int64                  `protobuf:"varint,10,opt,name=max_total_size,proto3" json:"max_total_size,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Logger_File) Reset() {
 	*x = Logger_File{}
-	mi := &file_config_logger_v1_logger_proto_msgTypes[3]
+	mi := &file_config_logger_v1_logger_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -331,7 +462,7 @@ func (x *Logger_File) String() string {
 func (*Logger_File) ProtoMessage() {}
 
 func (x *Logger_File) ProtoReflect() protoreflect.Message {
-	mi := &file_config_logger_v1_logger_proto_msgTypes[3]
+	mi := &file_config_logger_v1_logger_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,6 +527,27 @@ func (x *Logger_File) GetMaxBackups() int32 {
 	return 0
 }
 
+func (x *Logger_File) GetRotation() string {
+	if x != nil {
+		return x.Rotation
+	}
+	return ""
+}
+
+func (x *Logger_File) GetPattern() string {
+	if x != nil {
+		return x.Pattern
+	}
+	return ""
+}
+
+func (x *Logger_File) GetMaxTotalSize() int64 {
+	if x != nil {
+		return x.MaxTotalSize
+	}
+	return 0
+}
+
 // Dev logger
 type Logger_DevLogger struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -415,7 +567,7 @@ type Logger_DevLogger struct {
 
 func (x *Logger_DevLogger) Reset() {
 	*x = Logger_DevLogger{}
-	mi := &file_config_logger_v1_logger_proto_msgTypes[4]
+	mi := &file_config_logger_v1_logger_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -427,7 +579,7 @@ func (x *Logger_DevLogger) String() string {
 func (*Logger_DevLogger) ProtoMessage() {}
 
 func (x *Logger_DevLogger) ProtoReflect() protoreflect.Message {
-	mi := &file_config_logger_v1_logger_proto_msgTypes[4]
+	mi := &file_config_logger_v1_logger_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -513,11 +665,539 @@ func (x *Logger_DevLogger) GetFormatter() bool {
 	return false
 }
 
+// Logger output
+type Logger_Output struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Format        string                 `protobuf:"bytes,1,opt,name=format,proto3" json:"format,omitempty"`
+	Level         string                 `protobuf:"bytes,2,opt,name=level,proto3" json:"level,omitempty"`
+	Stdout        bool                   `protobuf:"varint,3,opt,name=stdout,proto3" json:"stdout,omitempty"`
+	File          *Logger_File           `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Output) Reset() {
+	*x = Logger_Output{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Output) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Output) ProtoMessage() {}
+
+func (x *Logger_Output) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Output.ProtoReflect.Descriptor instead.
+func (*Logger_Output) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 2}
+}
+
+func (x *Logger_Output) GetFormat() string {
+	if x != nil {
+		return x.Format
+	}
+	return ""
+}
+
+func (x *Logger_Output) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Logger_Output) GetStdout() bool {
+	if x != nil {
+		return x.Stdout
+	}
+	return false
+}
+
+func (x *Logger_Output) GetFile() *Logger_File {
+	if x != nil {
+		return x.File
+	}
+	return nil
+}
+
+// Logger redaction of sensitive values
+type Logger_Redaction struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	Disabled        bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	DisableDefaults bool                   `protobuf:"varint,2,opt,name=disable_defaults,proto3" json:"disable_defaults,omitempty"`
+	Keys            []string               `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	Values          []string               `protobuf:"bytes,4,rep,name=values,proto3" json:"values,omitempty"`
+	Mask            string                 `protobuf:"bytes,5,opt,name=mask,proto3" json:"mask,omitempty"`
+	Cards           bool                   `protobuf:"varint,6,opt,name=cards,proto3" json:"cards,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *Logger_Redaction) Reset() {
+	*x = Logger_Redaction{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Redaction) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Redaction) ProtoMessage() {}
+
+func (x *Logger_Redaction) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Redaction.ProtoReflect.Descriptor instead.
+func (*Logger_Redaction) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 3}
+}
+
+func (x *Logger_Redaction) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Logger_Redaction) GetDisableDefaults() bool {
+	if x != nil {
+		return x.DisableDefaults
+	}
+	return false
+}
+
+func (x *Logger_Redaction) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+func (x *Logger_Redaction) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *Logger_Redaction) GetMask() string {
+	if x != nil {
+		return x.Mask
+	}
+	return ""
+}
+
+func (x *Logger_Redaction) GetCards() bool {
+	if x != nil {
+		return x.Cards
+	}
+	return false
+}
+
+// Logger sampling of repeated entries
+type Logger_Sampling struct {
+	state         protoimpl.MessageState           `protogen:"open.v1"`
+	Interval      *durationpb.Duration             `protobuf:"bytes,1,opt,name=interval,proto3" json:"interval,omitempty"`
+	Initial       uint32                           `protobuf:"varint,2,opt,name=initial,proto3" json:"initial,omitempty"`
+	Thereafter    uint32                           `protobuf:"varint,3,opt,name=thereafter,proto3" json:"thereafter,omitempty"`
+	Levels        map[string]*Logger_Sampling_Rule `protobuf:"bytes,4,rep,name=levels,proto3" json:"levels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Sampling) Reset() {
+	*x = Logger_Sampling{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Sampling) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Sampling) ProtoMessage() {}
+
+func (x *Logger_Sampling) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Sampling.ProtoReflect.Descriptor instead.
+func (*Logger_Sampling) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 4}
+}
+
+func (x *Logger_Sampling) GetInterval() *durationpb.Duration {
+	if x != nil {
+		return x.Interval
+	}
+	return nil
+}
+
+func (x *Logger_Sampling) GetInitial() uint32 {
+	if x != nil {
+		return x.Initial
+	}
+	return 0
+}
+
+func (x *Logger_Sampling) GetThereafter() uint32 {
+	if x != nil {
+		return x.Thereafter
+	}
+	return 0
+}
+
+func (x *Logger_Sampling) GetLevels() map[string]*Logger_Sampling_Rule {
+	if x != nil {
+		return x.Levels
+	}
+	return nil
+}
+
+// Logger hook shipping the entries of a level to sinks
+type Logger_Hook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Level         string                 `protobuf:"bytes,1,opt,name=level,proto3" json:"level,omitempty"`
+	BufferSize    uint32                 `protobuf:"varint,2,opt,name=buffer_size,proto3" json:"buffer_size,omitempty"`
+	Webhook       *Logger_Hook_Webhook   `protobuf:"bytes,3,opt,name=webhook,proto3" json:"webhook,omitempty"`
+	File          string                 `protobuf:"bytes,4,opt,name=file,proto3" json:"file,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Hook) Reset() {
+	*x = Logger_Hook{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Hook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Hook) ProtoMessage() {}
+
+func (x *Logger_Hook) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Hook.ProtoReflect.Descriptor instead.
+func (*Logger_Hook) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 5}
+}
+
+func (x *Logger_Hook) GetLevel() string {
+	if x != nil {
+		return x.Level
+	}
+	return ""
+}
+
+func (x *Logger_Hook) GetBufferSize() uint32 {
+	if x != nil {
+		return x.BufferSize
+	}
+	return 0
+}
+
+func (x *Logger_Hook) GetWebhook() *Logger_Hook_Webhook {
+	if x != nil {
+		return x.Webhook
+	}
+	return nil
+}
+
+func (x *Logger_Hook) GetFile() string {
+	if x != nil {
+		return x.File
+	}
+	return ""
+}
+
+// Logger in-memory buffer of the recent entries
+type Logger_Buffer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Enabled       bool                   `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	MaxRecords    uint32                 `protobuf:"varint,2,opt,name=max_records,proto3" json:"max_records,omitempty"`
+	MaxBytes      uint64                 `protobuf:"varint,3,opt,name=max_bytes,proto3" json:"max_bytes,omitempty"`
+	Path          string                 `protobuf:"bytes,4,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Buffer) Reset() {
+	*x = Logger_Buffer{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Buffer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Buffer) ProtoMessage() {}
+
+func (x *Logger_Buffer) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Buffer.ProtoReflect.Descriptor instead.
+func (*Logger_Buffer) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 6}
+}
+
+func (x *Logger_Buffer) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *Logger_Buffer) GetMaxRecords() uint32 {
+	if x != nil {
+		return x.MaxRecords
+	}
+	return 0
+}
+
+func (x *Logger_Buffer) GetMaxBytes() uint64 {
+	if x != nil {
+		return x.MaxBytes
+	}
+	return 0
+}
+
+func (x *Logger_Buffer) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+// Logger fields extracted from the contexts of the entries
+type Logger_Context struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Disabled      bool                   `protobuf:"varint,1,opt,name=disabled,proto3" json:"disabled,omitempty"`
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Context) Reset() {
+	*x = Logger_Context{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Context) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Context) ProtoMessage() {}
+
+func (x *Logger_Context) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Context.ProtoReflect.Descriptor instead.
+func (*Logger_Context) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 7}
+}
+
+func (x *Logger_Context) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+func (x *Logger_Context) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+// Sampling rule
+type Logger_Sampling_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Initial       uint32                 `protobuf:"varint,1,opt,name=initial,proto3" json:"initial,omitempty"`
+	Thereafter    uint32                 `protobuf:"varint,2,opt,name=thereafter,proto3" json:"thereafter,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Sampling_Rule) Reset() {
+	*x = Logger_Sampling_Rule{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Sampling_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Sampling_Rule) ProtoMessage() {}
+
+func (x *Logger_Sampling_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Sampling_Rule.ProtoReflect.Descriptor instead.
+func (*Logger_Sampling_Rule) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 4, 0}
+}
+
+func (x *Logger_Sampling_Rule) GetInitial() uint32 {
+	if x != nil {
+		return x.Initial
+	}
+	return 0
+}
+
+func (x *Logger_Sampling_Rule) GetThereafter() uint32 {
+	if x != nil {
+		return x.Thereafter
+	}
+	return 0
+}
+
+// Hook webhook sink
+type Logger_Hook_Webhook struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Url           string                 `protobuf:"bytes,1,opt,name=url,proto3" json:"url,omitempty"`
+	Headers       map[string]string      `protobuf:"bytes,2,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	Timeout       *durationpb.Duration   `protobuf:"bytes,3,opt,name=timeout,proto3" json:"timeout,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Logger_Hook_Webhook) Reset() {
+	*x = Logger_Hook_Webhook{}
+	mi := &file_config_logger_v1_logger_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Logger_Hook_Webhook) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Logger_Hook_Webhook) ProtoMessage() {}
+
+func (x *Logger_Hook_Webhook) ProtoReflect() protoreflect.Message {
+	mi := &file_config_logger_v1_logger_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Logger_Hook_Webhook.ProtoReflect.Descriptor instead.
+func (*Logger_Hook_Webhook) Descriptor() ([]byte, []int) {
+	return file_config_logger_v1_logger_proto_rawDescGZIP(), []int{1, 5, 0}
+}
+
+func (x *Logger_Hook_Webhook) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+func (x *Logger_Hook_Webhook) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
+func (x *Logger_Hook_Webhook) GetTimeout() *durationpb.Duration {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 var File_config_logger_v1_logger_proto protoreflect.FileDescriptor
 
 const file_config_logger_v1_logger_proto_rawDesc = "" +
 	"\n" +
-	"\x1dconfig/logger/v1/logger.proto\x12\x1cruntime.api.config.logger.v1\x1a$gnostic/openapi/v3/annotations.proto\"\xd5\x03\n" +
+	"\x1dconfig/logger/v1/logger.proto\x12\x1cruntime.api.config.logger.v1\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\"\xd5\x03\n" +
 	"\x11LoggerHookMessage\x129\n" +
 	"\x05level\x18\x01 \x01(\tB#\xbaG \x92\x02\x1dThe log level of the message.R\x05level\x128\n" +
 	"\amessage\x18\x02 \x01(\tB\x1e\xbaG\x1b\x92\x02\x18The log message content.R\amessage\x12C\n" +
@@ -528,7 +1208,7 @@ const file_config_logger_v1_logger_proto_rawDesc = "" +
 	"\x06fields\x18\x05 \x03(\v2;.runtime.api.config.logger.v1.LoggerHookMessage.FieldsEntryB8\xbaG5\x92\x022Additional fields associated with the log message.R\x06fields\x1a9\n" +
 	"\vFieldsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xf9\x13\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x81=\n" +
 	"\x06Logger\x12@\n" +
 	"\bdisabled\x18\x01 \x01(\bB$\xbaG!\x92\x02\x1eWhether to disable the logger.R\bdisabled\x12L\n" +
 	"\adevelop\x18\x02 \x01(\bB2\xbaG/\x92\x02,Whether to enable development logger output.R\adevelop\x12Q\n" +
@@ -540,11 +1220,18 @@ const file_config_logger_v1_logger_proto_rawDesc = "" +
 	"\x0edisable_caller\x18\b \x01(\bBC\xbaG@\x92\x02=Whether to disable logging the caller's file and line number.R\x0edisable_caller\x12g\n" +
 	"\vcaller_skip\x18\t \x01(\rBE\xbaGB\x92\x02?The number of stack frames to skip when determining the caller.R\vcaller_skip\x12V\n" +
 	"\vtime_format\x18\n" +
-	" \x01(\tB4\xbaG1\x92\x02.The format for log timestamps (e.g., RFC3339).R\vtime_format\x12n\n" +
+	" \x01(\tB4\xbaG1\x92\x02.The format for log timestamps (e.g., RFC3339).R\vtime_format\x12\xc0\x01\n" +
+	"\rmodule_levels\x18\v \x03(\v26.runtime.api.config.logger.v1.Logger.ModuleLevelsEntryBb\xbaG_\x92\x02\\Log levels of individual modules (e.g., middleware.jwt: debug), overriding the logger level.R\rmodule_levels\x12\x9c\x01\n" +
+	"\aoutputs\x18\f \x03(\v2+.runtime.api.config.logger.v1.Logger.OutputBU\xbaGR\x92\x02OOutputs the entries are written to, replacing stdout, file and format when set.R\aoutputs\x12n\n" +
 	"\x04file\x18d \x01(\v2).runtime.api.config.logger.v1.Logger.FileB/\xbaG,\x92\x02)File output configuration for the logger.R\x04file\x12w\n" +
 	"\n" +
 	"dev_logger\x18e \x01(\v2..runtime.api.config.logger.v1.Logger.DevLoggerB'\xbaG$\x92\x02!Development logger configuration.R\n" +
-	"dev_logger\x1a\xb8\x04\n" +
+	"dev_logger\x12\x95\x01\n" +
+	"\tredaction\x18f \x01(\v2..runtime.api.config.logger.v1.Logger.RedactionBG\xbaGD\x92\x02ARedaction of sensitive values such as passwords, tokens and DSNs.R\tredaction\x12\xa0\x01\n" +
+	"\bsampling\x18g \x01(\v2-.runtime.api.config.logger.v1.Logger.SamplingBU\xbaGR\x92\x02OSampling of identical entries repeated within an interval, disabled when unset.R\bsampling\x12\x87\x01\n" +
+	"\x05hooks\x18h \x03(\v2).runtime.api.config.logger.v1.Logger.HookBF\xbaGC\x92\x02@Hooks shipping the entries of a level, such as errors, to sinks.R\x05hooks\x12\xa6\x01\n" +
+	"\acontext\x18i \x01(\v2,.runtime.api.config.logger.v1.Logger.ContextB^\xbaG[\x92\x02XFields extracted from the contexts of the entries, such as request, user and tenant IDs.R\acontext\x12\xaf\x01\n" +
+	"\x06buffer\x18j \x01(\v2+.runtime.api.config.logger.v1.Logger.BufferBj\xbaGg\x92\x02dIn-memory buffer of the recent entries, queried through the HTTP servers enabling enable_log_buffer.R\x06buffer\x1a\xf9\a\n" +
 	"\x04File\x123\n" +
 	"\x04path\x18\x01 \x01(\tB\x1f\xbaG\x1c\x92\x02\x19The path to the log file.R\x04path\x12Q\n" +
 	"\n" +
@@ -556,7 +1243,11 @@ const file_config_logger_v1_logger_proto_rawDesc = "" +
 	"local_time\x12`\n" +
 	"\bmax_size\x18\x05 \x01(\x05BD\xbaGA\x92\x02>The maximum size in megabytes of the log file before rotation.R\bmax_size\x12S\n" +
 	"\amax_age\x18\x06 \x01(\x05B9\xbaG6\x92\x023The maximum number of days to retain old log files.R\amax_age\x12V\n" +
-	"\vmax_backups\x18\a \x01(\x05B4\xbaG1\x92\x02.The maximum number of old log files to retain.R\vmax_backups\x1a\xfa\x06\n" +
+	"\vmax_backups\x18\a \x01(\x05B4\xbaG1\x92\x02.The maximum number of old log files to retain.R\vmax_backups\x12p\n" +
+	"\brotation\x18\b \x01(\tBT\xbaGQ\x92\x02NTime-based rotation period: hourly or daily, takes precedence over lumberjack.R\brotation\x12\xbb\x01\n" +
+	"\apattern\x18\t \x01(\tB\xa0\x01\xbaG\x9c\x01\x92\x02\x98\x01Filename pattern of the time-rotated files with the %Y, %m, %d, %H and %M verbs (e.g., app-%Y-%m-%d.log), defaults to the path with the period appended.R\apattern\x12\x8e\x01\n" +
+	"\x0emax_total_size\x18\n" +
+	" \x01(\x03Bf\xbaGc\x92\x02`The maximum total size in megabytes of the time-rotated files, the oldest are removed beyond it.R\x0emax_total_size\x1a\xfa\x06\n" +
 	"\tDevLogger\x12P\n" +
 	"\tmax_slice\x18\x01 \x01(\rB2\xbaG/\x92\x02,Maximum slice length for development logger.R\tmax_slice\x12V\n" +
 	"\tsort_keys\x18\x02 \x01(\bB8\xbaG5\x92\x022Whether to sort keys in development logger output.R\tsort_keys\x12a\n" +
@@ -572,7 +1263,64 @@ const file_config_logger_v1_logger_proto_rawDesc = "" +
 	"\verror_color\x18\b \x01(\rB7\xbaG4\x92\x021Color code for error level in development logger.R\verror_color\x12O\n" +
 	"\tmax_trace\x18\t \x01(\rB1\xbaG.\x92\x02+Maximum trace depth for development logger.R\tmax_trace\x12]\n" +
 	"\tformatter\x18\n" +
-	" \x01(\bB?\xbaG<\x92\x029Whether to use a custom formatter for development logger.R\tformatter*\xa1\x01\n" +
+	" \x01(\bB?\xbaG<\x92\x029Whether to use a custom formatter for development logger.R\tformatter\x1a\x91\x03\n" +
+	"\x06Output\x12t\n" +
+	"\x06format\x18\x01 \x01(\tB\\\xbaGY\x92\x02VThe format of the output (e.g., json, text, tint, dev), defaults to the logger format.R\x06format\x12e\n" +
+	"\x05level\x18\x02 \x01(\tBO\xbaGL\x92\x02IThe minimum level written to the output, in addition to the logger level.R\x05level\x12B\n" +
+	"\x06stdout\x18\x03 \x01(\bB*\xbaG'\x92\x02$Whether to write to standard output.R\x06stdout\x12f\n" +
+	"\x04file\x18\x04 \x01(\v2).runtime.api.config.logger.v1.Logger.FileB'\xbaG$\x92\x02!File configuration of the output.R\x04file\x1a\x87\x06\n" +
+	"\tRedaction\x12W\n" +
+	"\bdisabled\x18\x01 \x01(\bB;\xbaG8\x92\x025Whether to disable the redaction of sensitive values.R\bdisabled\x12r\n" +
+	"\x10disable_defaults\x18\x02 \x01(\bBF\xbaGC\x92\x02@Whether to drop the built-in key patterns and value expressions.R\x10disable_defaults\x12\xbc\x01\n" +
+	"\x04keys\x18\x03 \x03(\tB\xa7\x01\xbaG\xa3\x01\x92\x02\x9f\x01Key patterns whose values are masked, matched case-insensitively against the last segments of the keys (e.g., token matches access_token but not token_source).R\x04keys\x12\\\n" +
+	"\x06values\x18\x04 \x03(\tBD\xbaGA\x92\x02>Regular expressions of the values masked wherever they appear.R\x06values\x12P\n" +
+	"\x04mask\x18\x05 \x01(\tB<\xbaG9\x92\x026The replacement of the masked values, defaults to ***.R\x04mask\x12\xbd\x01\n" +
+	"\x05cards\x18\x06 \x01(\bB\xa6\x01\xbaG\xa2\x01\x92\x02\x9e\x01Whether to mask the card numbers passing the Luhn check in the string values, off by default as about one in ten other long numbers, such as IDs, pass it too.R\x05cards\x1a\xce\a\n" +
+	"\bSampling\x12w\n" +
+	"\binterval\x18\x01 \x01(\v2\x19.google.protobuf.DurationB@\xbaG=\x92\x02:The interval the entries are counted over, defaults to 1s.R\binterval\x12d\n" +
+	"\ainitial\x18\x02 \x01(\rBJ\xbaGG\x92\x02DThe number of identical entries logged per interval before sampling.R\ainitial\x12\xaa\x01\n" +
+	"\n" +
+	"thereafter\x18\x03 \x01(\rB\x89\x01\xbaG\x85\x01\x92\x02\x81\x01Log every Mth identical entry after the initial ones, 0 drops them all. An initial and thereafter of 0 do not sample the entries.R\n" +
+	"thereafter\x12\xaa\x01\n" +
+	"\x06levels\x18\x04 \x03(\v29.runtime.api.config.logger.v1.Logger.Sampling.LevelsEntryBW\xbaGT\x92\x02QSampling rules of levels (e.g., debug, error), overriding initial and thereafter.R\x06levels\x1a\x99\x02\n" +
+	"\x04Rule\x12d\n" +
+	"\ainitial\x18\x01 \x01(\rBJ\xbaGG\x92\x02DThe number of identical entries logged per interval before sampling.R\ainitial\x12\xaa\x01\n" +
+	"\n" +
+	"thereafter\x18\x02 \x01(\rB\x89\x01\xbaG\x85\x01\x92\x02\x81\x01Log every Mth identical entry after the initial ones, 0 drops them all. An initial and thereafter of 0 do not sample the entries.R\n" +
+	"thereafter\x1am\n" +
+	"\vLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12H\n" +
+	"\x05value\x18\x02 \x01(\v22.runtime.api.config.logger.v1.Logger.Sampling.RuleR\x05value:\x028\x01\x1a\x9b\x06\n" +
+	"\x04Hook\x12X\n" +
+	"\x05level\x18\x01 \x01(\tBB\xbaG?\x92\x02<The minimum level of the entries shipped, defaults to error.R\x05level\x12s\n" +
+	"\vbuffer_size\x18\x02 \x01(\rBQ\xbaGN\x92\x02KThe number of messages buffered before dropping new ones, defaults to 1024.R\vbuffer_size\x12v\n" +
+	"\awebhook\x18\x03 \x01(\v21.runtime.api.config.logger.v1.Logger.Hook.WebhookB)\xbaG&\x92\x02#Webhook the messages are posted to.R\awebhook\x12T\n" +
+	"\x04file\x18\x04 \x01(\tB@\xbaG=\x92\x02:Path of a file the messages are appended to as JSON lines.R\x04file\x1a\xf5\x02\n" +
+	"\aWebhook\x12H\n" +
+	"\x03url\x18\x01 \x01(\tB6\xbaG3\x92\x020The URL the hook messages are posted to as JSON.R\x03url\x12~\n" +
+	"\aheaders\x18\x02 \x03(\v2>.runtime.api.config.logger.v1.Logger.Hook.Webhook.HeadersEntryB$\xbaG!\x92\x02\x1eHeaders added to the requests.R\aheaders\x12d\n" +
+	"\atimeout\x18\x03 \x01(\v2\x19.google.protobuf.DurationB/\xbaG,\x92\x02)The timeout of a request, defaults to 5s.R\atimeout\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\x1a\xa0\x03\n" +
+	"\x06Buffer\x12M\n" +
+	"\aenabled\x18\x01 \x01(\bB3\xbaG0\x92\x02-Whether to keep the recent entries in memory.R\aenabled\x12]\n" +
+	"\vmax_records\x18\x02 \x01(\rB;\xbaG8\x92\x025The maximum number of entries kept, defaults to 5000.R\vmax_records\x12d\n" +
+	"\tmax_bytes\x18\x03 \x01(\x04BF\xbaGC\x92\x02@The maximum size in bytes of the entries kept, defaults to 16MB.R\tmax_bytes\x12\x81\x01\n" +
+	"\x04path\x18\x04 \x01(\tBm\xbaGj\x92\x02gThe path of the query endpoint on the HTTP servers enabling enable_log_buffer, defaults to /debug/logs.R\x04path\x1a\xd1\x01\n" +
+	"\aContext\x12H\n" +
+	"\bdisabled\x18\x01 \x01(\bB,\xbaG)\x92\x02&Whether to disable the context fields.R\bdisabled\x12|\n" +
+	"\x04keys\x18\x02 \x03(\tBh\xbaGe\x92\x02bKeys of the registered extractors to apply (e.g., request_id, user_id, tenant_id), all when empty.R\x04keys\x1a?\n" +
+	"\x11ModuleLevelsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xde\x02\n" +
+	"\aLoggers\x12H\n" +
+	"\x06active\x18\x01 \x01(\tB+\xbaG(\x92\x02%Active logger name, overrides defaultH\x00R\x06active\x88\x01\x01\x12g\n" +
+	"\adefault\x18\x02 \x01(\v2$.runtime.api.config.logger.v1.LoggerB\"\xbaG\x1f\x92\x02\x1cDefault logger configurationH\x01R\adefault\x88\x01\x01\x12\x88\x01\n" +
+	"\aconfigs\x18\x03 \x03(\v2$.runtime.api.config.logger.v1.LoggerBH\xbaGE\x92\x02BList of named Logger configurations, such as app, access and auditR\aconfigsB\t\n" +
+	"\a_activeB\n" +
+	"\n" +
+	"\b_default*\xa1\x01\n" +
 	"\vLoggerLevel\x12\x1c\n" +
 	"\x18LOGGER_LEVEL_UNSPECIFIED\x10\x00\x12\x16\n" +
 	"\x12LOGGER_LEVEL_DEBUG\x10\x01\x12\x15\n" +
@@ -595,24 +1343,53 @@ func file_config_logger_v1_logger_proto_rawDescGZIP() []byte {
 }
 
 var file_config_logger_v1_logger_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_config_logger_v1_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 5)
+var file_config_logger_v1_logger_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
 var file_config_logger_v1_logger_proto_goTypes = []any{
-	(LoggerLevel)(0),          // 0: runtime.api.config.logger.v1.LoggerLevel
-	(*LoggerHookMessage)(nil), // 1: runtime.api.config.logger.v1.LoggerHookMessage
-	(*Logger)(nil),            // 2: runtime.api.config.logger.v1.Logger
-	nil,                       // 3: runtime.api.config.logger.v1.LoggerHookMessage.FieldsEntry
-	(*Logger_File)(nil),       // 4: runtime.api.config.logger.v1.Logger.File
-	(*Logger_DevLogger)(nil),  // 5: runtime.api.config.logger.v1.Logger.DevLogger
+	(LoggerLevel)(0),             // 0: runtime.api.config.logger.v1.LoggerLevel
+	(*LoggerHookMessage)(nil),    // 1: runtime.api.config.logger.v1.LoggerHookMessage
+	(*Logger)(nil),               // 2: runtime.api.config.logger.v1.Logger
+	(*Loggers)(nil),              // 3: runtime.api.config.logger.v1.Loggers
+	nil,                          // 4: runtime.api.config.logger.v1.LoggerHookMessage.FieldsEntry
+	(*Logger_File)(nil),          // 5: runtime.api.config.logger.v1.Logger.File
+	(*Logger_DevLogger)(nil),     // 6: runtime.api.config.logger.v1.Logger.DevLogger
+	(*Logger_Output)(nil),        // 7: runtime.api.config.logger.v1.Logger.Output
+	(*Logger_Redaction)(nil),     // 8: runtime.api.config.logger.v1.Logger.Redaction
+	(*Logger_Sampling)(nil),      // 9: runtime.api.config.logger.v1.Logger.Sampling
+	(*Logger_Hook)(nil),          // 10: runtime.api.config.logger.v1.Logger.Hook
+	(*Logger_Buffer)(nil),        // 11: runtime.api.config.logger.v1.Logger.Buffer
+	(*Logger_Context)(nil),       // 12: runtime.api.config.logger.v1.Logger.Context
+	nil,                          // 13: runtime.api.config.logger.v1.Logger.ModuleLevelsEntry
+	(*Logger_Sampling_Rule)(nil), // 14: runtime.api.config.logger.v1.Logger.Sampling.Rule
+	nil,                          // 15: runtime.api.config.logger.v1.Logger.Sampling.LevelsEntry
+	(*Logger_Hook_Webhook)(nil),  // 16: runtime.api.config.logger.v1.Logger.Hook.Webhook
+	nil,                          // 17: runtime.api.config.logger.v1.Logger.Hook.Webhook.HeadersEntry
+	(*durationpb.Duration)(nil),  // 18: google.protobuf.Duration
 }
 var file_config_logger_v1_logger_proto_depIdxs = []int32{
-	3, // 0: runtime.api.config.logger.v1.LoggerHookMessage.fields:type_name -> runtime.api.config.logger.v1.LoggerHookMessage.FieldsEntry
-	4, // 1: runtime.api.config.logger.v1.Logger.file:type_name -> runtime.api.config.logger.v1.Logger.File
-	5, // 2: runtime.api.config.logger.v1.Logger.dev_logger:type_name -> runtime.api.config.logger.v1.Logger.DevLogger
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	4,  // 0: runtime.api.config.logger.v1.LoggerHookMessage.fields:type_name -> runtime.api.config.logger.v1.LoggerHookMessage.FieldsEntry
+	13, // 1: runtime.api.config.logger.v1.Logger.module_levels:type_name -> runtime.api.config.logger.v1.Logger.ModuleLevelsEntry
+	7,  // 2: runtime.api.config.logger.v1.Logger.outputs:type_name -> runtime.api.config.logger.v1.Logger.Output
+	5,  // 3: runtime.api.config.logger.v1.Logger.file:type_name -> runtime.api.config.logger.v1.Logger.File
+	6,  // 4: runtime.api.config.logger.v1.Logger.dev_logger:type_name -> runtime.api.config.logger.v1.Logger.DevLogger
+	8,  // 5: runtime.api.config.logger.v1.Logger.redaction:type_name -> runtime.api.config.logger.v1.Logger.Redaction
+	9,  // 6: runtime.api.config.logger.v1.Logger.sampling:type_name -> runtime.api.config.logger.v1.Logger.Sampling
+	10, // 7: runtime.api.config.logger.v1.Logger.hooks:type_name -> runtime.api.config.logger.v1.Logger.Hook
+	12, // 8: runtime.api.config.logger.v1.Logger.context:type_name -> runtime.api.config.logger.v1.Logger.Context
+	11, // 9: runtime.api.config.logger.v1.Logger.buffer:type_name -> runtime.api.config.logger.v1.Logger.Buffer
+	2,  // 10: runtime.api.config.logger.v1.Loggers.default:type_name -> runtime.api.config.logger.v1.Logger
+	2,  // 11: runtime.api.config.logger.v1.Loggers.configs:type_name -> runtime.api.config.logger.v1.Logger
+	5,  // 12: runtime.api.config.logger.v1.Logger.Output.file:type_name -> runtime.api.config.logger.v1.Logger.File
+	18, // 13: runtime.api.config.logger.v1.Logger.Sampling.interval:type_name -> google.protobuf.Duration
+	15, // 14: runtime.api.config.logger.v1.Logger.Sampling.levels:type_name -> runtime.api.config.logger.v1.Logger.Sampling.LevelsEntry
+	16, // 15: runtime.api.config.logger.v1.Logger.Hook.webhook:type_name -> runtime.api.config.logger.v1.Logger.Hook.Webhook
+	14, // 16: runtime.api.config.logger.v1.Logger.Sampling.LevelsEntry.value:type_name -> runtime.api.config.logger.v1.Logger.Sampling.Rule
+	17, // 17: runtime.api.config.logger.v1.Logger.Hook.Webhook.headers:type_name -> runtime.api.config.logger.v1.Logger.Hook.Webhook.HeadersEntry
+	18, // 18: runtime.api.config.logger.v1.Logger.Hook.Webhook.timeout:type_name -> google.protobuf.Duration
+	19, // [19:19] is the sub-list for method output_type
+	19, // [19:19] is the sub-list for method input_type
+	19, // [19:19] is the sub-list for extension type_name
+	19, // [19:19] is the sub-list for extension extendee
+	0,  // [0:19] is the sub-list for field type_name
 }
 
 func init() { file_config_logger_v1_logger_proto_init() }
@@ -620,13 +1397,14 @@ func file_config_logger_v1_logger_proto_init() {
 	if File_config_logger_v1_logger_proto != nil {
 		return
 	}
+	file_config_logger_v1_logger_proto_msgTypes[2].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_logger_v1_logger_proto_rawDesc), len(file_config_logger_v1_logger_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   5,
+			NumMessages:   17,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
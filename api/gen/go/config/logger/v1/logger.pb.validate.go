@@ -188,6 +188,42 @@ func (m *Logger) validate(all bool) error {
 
 	// no validation rules for TimeFormat
 
+	// no validation rules for ModuleLevels
+
+	for idx, item := range m.GetOutputs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LoggerValidationError{
+						field:  fmt.Sprintf("Outputs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LoggerValidationError{
+						field:  fmt.Sprintf("Outputs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LoggerValidationError{
+					field:  fmt.Sprintf("Outputs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if all {
 		switch v := interface{}(m.GetFile()).(type) {
 		case interface{ ValidateAll() error }:
@@ -246,6 +282,156 @@ func (m *Logger) validate(all bool) error {
 		}
 	}
 
+	if all {
+		switch v := interface{}(m.GetRedaction()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Redaction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Redaction",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetRedaction()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LoggerValidationError{
+				field:  "Redaction",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetSampling()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Sampling",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Sampling",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSampling()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LoggerValidationError{
+				field:  "Sampling",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	for idx, item := range m.GetHooks() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LoggerValidationError{
+						field:  fmt.Sprintf("Hooks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LoggerValidationError{
+						field:  fmt.Sprintf("Hooks[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LoggerValidationError{
+					field:  fmt.Sprintf("Hooks[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if all {
+		switch v := interface{}(m.GetContext()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Context",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Context",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetContext()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LoggerValidationError{
+				field:  "Context",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if all {
+		switch v := interface{}(m.GetBuffer()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Buffer",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, LoggerValidationError{
+					field:  "Buffer",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetBuffer()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return LoggerValidationError{
+				field:  "Buffer",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
 	if len(errors) > 0 {
 		return LoggerMultiError(errors)
 	}
@@ -323,6 +509,175 @@ var _ interface {
 	ErrorName() string
 } = LoggerValidationError{}
 
+// Validate checks the field values on Loggers with the rules defined in the
+// proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Loggers) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Loggers with the rules defined in the
+// proto definition for this message. If any rules are violated, the result is
+// a list of violation errors wrapped in LoggersMultiError, or nil if none found.
+func (m *Loggers) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Loggers) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	for idx, item := range m.GetConfigs() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LoggersValidationError{
+						field:  fmt.Sprintf("Configs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LoggersValidationError{
+						field:  fmt.Sprintf("Configs[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LoggersValidationError{
+					field:  fmt.Sprintf("Configs[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if m.Active != nil {
+		// no validation rules for Active
+	}
+
+	if m.Default != nil {
+
+		if all {
+			switch v := interface{}(m.GetDefault()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, LoggersValidationError{
+						field:  "Default",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, LoggersValidationError{
+						field:  "Default",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetDefault()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return LoggersValidationError{
+					field:  "Default",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
+	if len(errors) > 0 {
+		return LoggersMultiError(errors)
+	}
+
+	return nil
+}
+
+// LoggersMultiError is an error wrapping multiple validation errors returned
+// by Loggers.ValidateAll() if the designated constraints aren't met.
+type LoggersMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m LoggersMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m LoggersMultiError) AllErrors() []error { return m }
+
+// LoggersValidationError is the validation error returned by Loggers.Validate
+// if the designated constraints aren't met.
+type LoggersValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e LoggersValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e LoggersValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e LoggersValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e LoggersValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e LoggersValidationError) ErrorName() string { return "LoggersValidationError" }
+
+// Error satisfies the builtin error interface
+func (e LoggersValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLoggers.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = LoggersValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = LoggersValidationError{}
+
 // Validate checks the field values on Logger_File with the rules defined in
 // the proto definition for this message. If any rules are violated, the first
 // error encountered is returned, or nil if there are no violations.
@@ -359,6 +714,12 @@ func (m *Logger_File) validate(all bool) error {
 
 	// no validation rules for MaxBackups
 
+	// no validation rules for Rotation
+
+	// no validation rules for Pattern
+
+	// no validation rules for MaxTotalSize
+
 	if len(errors) > 0 {
 		return Logger_FileMultiError(errors)
 	}
@@ -555,3 +916,1010 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = Logger_DevLoggerValidationError{}
+
+// Validate checks the field values on Logger_Output with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Logger_Output) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Output with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Logger_OutputMultiError, or
+// nil if none found.
+func (m *Logger_Output) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Output) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Format
+
+	// no validation rules for Level
+
+	// no validation rules for Stdout
+
+	if all {
+		switch v := interface{}(m.GetFile()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Logger_OutputValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Logger_OutputValidationError{
+					field:  "File",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetFile()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Logger_OutputValidationError{
+				field:  "File",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Logger_OutputMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_OutputMultiError is an error wrapping multiple validation errors
+// returned by Logger_Output.ValidateAll() if the designated constraints
+// aren't met.
+type Logger_OutputMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_OutputMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_OutputMultiError) AllErrors() []error { return m }
+
+// Logger_OutputValidationError is the validation error returned by
+// Logger_Output.Validate if the designated constraints aren't met.
+type Logger_OutputValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_OutputValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_OutputValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_OutputValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_OutputValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_OutputValidationError) ErrorName() string { return "Logger_OutputValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_OutputValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Output.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_OutputValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_OutputValidationError{}
+
+// Validate checks the field values on Logger_Redaction with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Logger_Redaction) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Redaction with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Logger_RedactionMultiError, or nil if none found.
+func (m *Logger_Redaction) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Redaction) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Disabled
+
+	// no validation rules for DisableDefaults
+
+	// no validation rules for Mask
+
+	// no validation rules for Cards
+
+	if len(errors) > 0 {
+		return Logger_RedactionMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_RedactionMultiError is an error wrapping multiple validation errors
+// returned by Logger_Redaction.ValidateAll() if the designated constraints
+// aren't met.
+type Logger_RedactionMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_RedactionMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_RedactionMultiError) AllErrors() []error { return m }
+
+// Logger_RedactionValidationError is the validation error returned by
+// Logger_Redaction.Validate if the designated constraints aren't met.
+type Logger_RedactionValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_RedactionValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_RedactionValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_RedactionValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_RedactionValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_RedactionValidationError) ErrorName() string { return "Logger_RedactionValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_RedactionValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Redaction.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_RedactionValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_RedactionValidationError{}
+
+// Validate checks the field values on Logger_Sampling with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *Logger_Sampling) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Sampling with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Logger_SamplingMultiError, or nil if none found.
+func (m *Logger_Sampling) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Sampling) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	if all {
+		switch v := interface{}(m.GetInterval()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Logger_SamplingValidationError{
+					field:  "Interval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Logger_SamplingValidationError{
+					field:  "Interval",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetInterval()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Logger_SamplingValidationError{
+				field:  "Interval",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Initial
+
+	// no validation rules for Thereafter
+
+	{
+		sorted_keys := make([]string, len(m.GetLevels()))
+		i := 0
+		for key := range m.GetLevels() {
+			sorted_keys[i] = key
+			i++
+		}
+		sort.Slice(sorted_keys, func(i, j int) bool { return sorted_keys[i] < sorted_keys[j] })
+		for _, key := range sorted_keys {
+			val := m.GetLevels()[key]
+			_ = val
+
+			// no validation rules for Levels[key]
+
+			if all {
+				switch v := interface{}(val).(type) {
+				case interface{ ValidateAll() error }:
+					if err := v.ValidateAll(); err != nil {
+						errors = append(errors, Logger_SamplingValidationError{
+							field:  fmt.Sprintf("Levels[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				case interface{ Validate() error }:
+					if err := v.Validate(); err != nil {
+						errors = append(errors, Logger_SamplingValidationError{
+							field:  fmt.Sprintf("Levels[%v]", key),
+							reason: "embedded message failed validation",
+							cause:  err,
+						})
+					}
+				}
+			} else if v, ok := interface{}(val).(interface{ Validate() error }); ok {
+				if err := v.Validate(); err != nil {
+					return Logger_SamplingValidationError{
+						field:  fmt.Sprintf("Levels[%v]", key),
+						reason: "embedded message failed validation",
+						cause:  err,
+					}
+				}
+			}
+
+		}
+	}
+
+	if len(errors) > 0 {
+		return Logger_SamplingMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_SamplingMultiError is an error wrapping multiple validation errors
+// returned by Logger_Sampling.ValidateAll() if the designated constraints
+// aren't met.
+type Logger_SamplingMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_SamplingMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_SamplingMultiError) AllErrors() []error { return m }
+
+// Logger_SamplingValidationError is the validation error returned by
+// Logger_Sampling.Validate if the designated constraints aren't met.
+type Logger_SamplingValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_SamplingValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_SamplingValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_SamplingValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_SamplingValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_SamplingValidationError) ErrorName() string { return "Logger_SamplingValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_SamplingValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Sampling.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_SamplingValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_SamplingValidationError{}
+
+// Validate checks the field values on Logger_Hook with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Logger_Hook) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Hook with the rules defined in
+// the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Logger_HookMultiError, or
+// nil if none found.
+func (m *Logger_Hook) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Hook) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Level
+
+	// no validation rules for BufferSize
+
+	if all {
+		switch v := interface{}(m.GetWebhook()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Logger_HookValidationError{
+					field:  "Webhook",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Logger_HookValidationError{
+					field:  "Webhook",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetWebhook()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Logger_HookValidationError{
+				field:  "Webhook",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for File
+
+	if len(errors) > 0 {
+		return Logger_HookMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_HookMultiError is an error wrapping multiple validation errors
+// returned by Logger_Hook.ValidateAll() if the designated constraints aren't met.
+type Logger_HookMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_HookMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_HookMultiError) AllErrors() []error { return m }
+
+// Logger_HookValidationError is the validation error returned by
+// Logger_Hook.Validate if the designated constraints aren't met.
+type Logger_HookValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_HookValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_HookValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_HookValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_HookValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_HookValidationError) ErrorName() string { return "Logger_HookValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_HookValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Hook.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_HookValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_HookValidationError{}
+
+// Validate checks the field values on Logger_Buffer with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Logger_Buffer) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Buffer with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Logger_BufferMultiError, or
+// nil if none found.
+func (m *Logger_Buffer) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Buffer) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Enabled
+
+	// no validation rules for MaxRecords
+
+	// no validation rules for MaxBytes
+
+	// no validation rules for Path
+
+	if len(errors) > 0 {
+		return Logger_BufferMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_BufferMultiError is an error wrapping multiple validation errors
+// returned by Logger_Buffer.ValidateAll() if the designated constraints
+// aren't met.
+type Logger_BufferMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_BufferMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_BufferMultiError) AllErrors() []error { return m }
+
+// Logger_BufferValidationError is the validation error returned by
+// Logger_Buffer.Validate if the designated constraints aren't met.
+type Logger_BufferValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_BufferValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_BufferValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_BufferValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_BufferValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_BufferValidationError) ErrorName() string { return "Logger_BufferValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_BufferValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Buffer.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_BufferValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_BufferValidationError{}
+
+// Validate checks the field values on Logger_Context with the rules defined in
+// the proto definition for this message. If any rules are violated, the first
+// error encountered is returned, or nil if there are no violations.
+func (m *Logger_Context) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Context with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// result is a list of violation errors wrapped in Logger_ContextMultiError,
+// or nil if none found.
+func (m *Logger_Context) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Context) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Disabled
+
+	if len(errors) > 0 {
+		return Logger_ContextMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_ContextMultiError is an error wrapping multiple validation errors
+// returned by Logger_Context.ValidateAll() if the designated constraints
+// aren't met.
+type Logger_ContextMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_ContextMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_ContextMultiError) AllErrors() []error { return m }
+
+// Logger_ContextValidationError is the validation error returned by
+// Logger_Context.Validate if the designated constraints aren't met.
+type Logger_ContextValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_ContextValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_ContextValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_ContextValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_ContextValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_ContextValidationError) ErrorName() string { return "Logger_ContextValidationError" }
+
+// Error satisfies the builtin error interface
+func (e Logger_ContextValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Context.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_ContextValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_ContextValidationError{}
+
+// Validate checks the field values on Logger_Sampling_Rule with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *Logger_Sampling_Rule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Sampling_Rule with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Logger_Sampling_RuleMultiError, or nil if none found.
+func (m *Logger_Sampling_Rule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Sampling_Rule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Initial
+
+	// no validation rules for Thereafter
+
+	if len(errors) > 0 {
+		return Logger_Sampling_RuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_Sampling_RuleMultiError is an error wrapping multiple validation
+// errors returned by Logger_Sampling_Rule.ValidateAll() if the designated
+// constraints aren't met.
+type Logger_Sampling_RuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_Sampling_RuleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_Sampling_RuleMultiError) AllErrors() []error { return m }
+
+// Logger_Sampling_RuleValidationError is the validation error returned by
+// Logger_Sampling_Rule.Validate if the designated constraints aren't met.
+type Logger_Sampling_RuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_Sampling_RuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_Sampling_RuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_Sampling_RuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_Sampling_RuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_Sampling_RuleValidationError) ErrorName() string {
+	return "Logger_Sampling_RuleValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Logger_Sampling_RuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Sampling_Rule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_Sampling_RuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_Sampling_RuleValidationError{}
+
+// Validate checks the field values on Logger_Hook_Webhook with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the first error encountered is returned, or nil if there are no violations.
+func (m *Logger_Hook_Webhook) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on Logger_Hook_Webhook with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// Logger_Hook_WebhookMultiError, or nil if none found.
+func (m *Logger_Hook_Webhook) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *Logger_Hook_Webhook) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Url
+
+	// no validation rules for Headers
+
+	if all {
+		switch v := interface{}(m.GetTimeout()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, Logger_Hook_WebhookValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, Logger_Hook_WebhookValidationError{
+					field:  "Timeout",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetTimeout()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return Logger_Hook_WebhookValidationError{
+				field:  "Timeout",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	if len(errors) > 0 {
+		return Logger_Hook_WebhookMultiError(errors)
+	}
+
+	return nil
+}
+
+// Logger_Hook_WebhookMultiError is an error wrapping multiple validation
+// errors returned by Logger_Hook_Webhook.ValidateAll() if the designated
+// constraints aren't met.
+type Logger_Hook_WebhookMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m Logger_Hook_WebhookMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m Logger_Hook_WebhookMultiError) AllErrors() []error { return m }
+
+// Logger_Hook_WebhookValidationError is the validation error returned by
+// Logger_Hook_Webhook.Validate if the designated constraints aren't met.
+type Logger_Hook_WebhookValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e Logger_Hook_WebhookValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e Logger_Hook_WebhookValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e Logger_Hook_WebhookValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e Logger_Hook_WebhookValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e Logger_Hook_WebhookValidationError) ErrorName() string {
+	return "Logger_Hook_WebhookValidationError"
+}
+
+// Error satisfies the builtin error interface
+func (e Logger_Hook_WebhookValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sLogger_Hook_Webhook.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = Logger_Hook_WebhookValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = Logger_Hook_WebhookValidationError{}
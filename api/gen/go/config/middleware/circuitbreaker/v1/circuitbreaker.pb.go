@@ -192,6 +192,7 @@ func (x *Header) GetValue() []string {
 	return nil
 }
 
+// ResponseData is the static response of the requests rejected by an open breaker.
 type ResponseData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	StatusCode    int32                  `protobuf:"varint,1,opt,name=status_code,json=statusCode,proto3" json:"status_code,omitempty"`
@@ -252,6 +253,7 @@ func (x *ResponseData) GetBody() []byte {
 	return nil
 }
 
+// BackupService is the HTTP endpoint the requests rejected by an open breaker are sent to.
 type BackupService struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Endpoint      *Endpoint              `protobuf:"bytes,1,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
@@ -296,6 +298,7 @@ func (x *BackupService) GetEndpoint() *Endpoint {
 	return nil
 }
 
+// SuccessRatio configures the SRE breaker of each operation.
 type SuccessRatio struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Success       float64                `protobuf:"fixed64,1,opt,name=success,proto3" json:"success,omitempty"`
@@ -369,11 +372,15 @@ type CircuitBreaker struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Only one of success_ratio or ratio should be set.
 	SuccessRatio *SuccessRatio `protobuf:"bytes,1,opt,name=success_ratio,proto3,oneof" json:"success_ratio,omitempty"`
-	Ratio        *int64        `protobuf:"varint,2,opt,name=ratio,proto3,oneof" json:"ratio,omitempty"`
+	// The percentage of successful requests below which requests are dropped, with the
+	// defaults of success_ratio otherwise.
+	Ratio *int64 `protobuf:"varint,2,opt,name=ratio,proto3,oneof" json:"ratio,omitempty"`
 	// Only one of response_data or backup_service should be set.
-	ResponseData    *ResponseData  `protobuf:"bytes,3,opt,name=response_data,proto3,oneof" json:"response_data,omitempty"`
-	BackupService   *BackupService `protobuf:"bytes,4,opt,name=backup_service,proto3,oneof" json:"backup_service,omitempty"`
-	AssertCondtions []*Condition   `protobuf:"bytes,5,rep,name=assert_condtions,proto3" json:"assert_condtions,omitempty"`
+	ResponseData  *ResponseData  `protobuf:"bytes,3,opt,name=response_data,proto3,oneof" json:"response_data,omitempty"`
+	BackupService *BackupService `protobuf:"bytes,4,opt,name=backup_service,proto3,oneof" json:"backup_service,omitempty"`
+	// The conditions counting a request as failed, any of them matching. Server errors
+	// (5xx) are failures when none is set.
+	AssertCondtions []*Condition `protobuf:"bytes,5,rep,name=assert_condtions,proto3" json:"assert_condtions,omitempty"`
 	unknownFields   protoimpl.UnknownFields
 	sizeCache       protoimpl.SizeCache
 }
@@ -516,19 +523,19 @@ const file_config_middleware_circuitbreaker_v1_circuitbreaker_proto_rawDesc = ""
 	"_by_header\"0\n" +
 	"\x06Header\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x03(\tR\x05value\"\x94\x01\n" +
-	"\fResponseData\x12\x1f\n" +
-	"\vstatus_code\x18\x01 \x01(\x05R\n" +
-	"statusCode\x12O\n" +
-	"\x06header\x18\x02 \x03(\v27.runtime.api.config.middleware.circuitbreaker.v1.HeaderR\x06header\x12\x12\n" +
-	"\x04body\x18\x03 \x01(\fR\x04body\"f\n" +
+	"\x05value\x18\x02 \x03(\tR\x05value\"\xf7\x02\n" +
+	"\fResponseData\x12\x8e\x01\n" +
+	"\vstatus_code\x18\x01 \x01(\x05Bm\xbaGj\x92\x02gThe status code of the response, 503 by default. Error codes fail the request with the body as message.R\n" +
+	"statusCode\x12s\n" +
+	"\x06header\x18\x02 \x03(\v27.runtime.api.config.middleware.circuitbreaker.v1.HeaderB\"\xbaG\x1f\x92\x02\x1cThe headers of the response.R\x06header\x12a\n" +
+	"\x04body\x18\x03 \x01(\fBM\xbaGJ\x92\x02GThe JSON body of the response, decoded into the reply of the operation.R\x04body\"f\n" +
 	"\rBackupService\x12U\n" +
-	"\bendpoint\x18\x01 \x01(\v29.runtime.api.config.middleware.circuitbreaker.v1.EndpointR\bendpoint\"r\n" +
-	"\fSuccessRatio\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\x01R\asuccess\x12\x18\n" +
-	"\arequest\x18\x02 \x01(\x05R\arequest\x12\x16\n" +
-	"\x06bucket\x18\x03 \x01(\x05R\x06bucket\x12\x16\n" +
-	"\x06window\x18\x04 \x01(\x03R\x06window\"\x95\x04\n" +
+	"\bendpoint\x18\x01 \x01(\v29.runtime.api.config.middleware.circuitbreaker.v1.EndpointR\bendpoint\"\xb0\x03\n" +
+	"\fSuccessRatio\x12r\n" +
+	"\asuccess\x18\x01 \x01(\x01BX\xbaGU\x92\x02RThe ratio of successful requests below which requests are dropped, 0.6 by default.R\asuccess\x12q\n" +
+	"\arequest\x18\x02 \x01(\x05BW\xbaGT\x92\x02QThe number of requests in the window before the breaker may open, 100 by default.R\arequest\x12Q\n" +
+	"\x06bucket\x18\x03 \x01(\x05B9\xbaG6\x92\x023The number of buckets of the window, 10 by default.R\x06bucket\x12f\n" +
+	"\x06window\x18\x04 \x01(\x03BN\xbaGK\x92\x02HThe duration of the statistical window in milliseconds, 3000 by default.R\x06window\"\x95\x04\n" +
 	"\x0eCircuitBreaker\x12h\n" +
 	"\rsuccess_ratio\x18\x01 \x01(\v2=.runtime.api.config.middleware.circuitbreaker.v1.SuccessRatioH\x00R\rsuccess_ratio\x88\x01\x01\x12\x19\n" +
 	"\x05ratio\x18\x02 \x01(\x03H\x01R\x05ratio\x88\x01\x01\x12h\n" +
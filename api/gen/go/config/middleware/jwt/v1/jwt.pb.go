@@ -99,9 +99,14 @@ type AuthConfig struct {
 	// Optional: Defines how to extract the token from the request.
 	// Defaults to "header:Authorization" with "Bearer " prefix.
 	// Example: "cookie:access_token"
-	TokenSource   *string `protobuf:"bytes,8,opt,name=token_source,proto3,oneof" json:"token_source,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	TokenSource         *string `protobuf:"bytes,8,opt,name=token_source,proto3,oneof" json:"token_source,omitempty"`
+	KeyId               string  `protobuf:"bytes,9,opt,name=key_id,proto3" json:"key_id,omitempty"`
+	SecondaryKeyId      string  `protobuf:"bytes,10,opt,name=secondary_key_id,proto3" json:"secondary_key_id,omitempty"`
+	JwksUrl             string  `protobuf:"bytes,11,opt,name=jwks_url,proto3" json:"jwks_url,omitempty"`
+	JwksRefreshInterval int64   `protobuf:"varint,12,opt,name=jwks_refresh_interval,proto3" json:"jwks_refresh_interval,omitempty"`
+	ClockSkew           int64   `protobuf:"varint,13,opt,name=clock_skew,proto3" json:"clock_skew,omitempty"`
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
 }
 
 func (x *AuthConfig) Reset() {
@@ -190,6 +195,41 @@ func (x *AuthConfig) GetTokenSource() string {
 	return ""
 }
 
+func (x *AuthConfig) GetKeyId() string {
+	if x != nil {
+		return x.KeyId
+	}
+	return ""
+}
+
+func (x *AuthConfig) GetSecondaryKeyId() string {
+	if x != nil {
+		return x.SecondaryKeyId
+	}
+	return ""
+}
+
+func (x *AuthConfig) GetJwksUrl() string {
+	if x != nil {
+		return x.JwksUrl
+	}
+	return ""
+}
+
+func (x *AuthConfig) GetJwksRefreshInterval() int64 {
+	if x != nil {
+		return x.JwksRefreshInterval
+	}
+	return 0
+}
+
+func (x *AuthConfig) GetClockSkew() int64 {
+	if x != nil {
+		return x.ClockSkew
+	}
+	return 0
+}
+
 var File_config_middleware_jwt_v1_jwt_proto protoreflect.FileDescriptor
 
 const file_config_middleware_jwt_v1_jwt_proto_rawDesc = "" +
@@ -200,18 +240,27 @@ const file_config_middleware_jwt_v1_jwt_proto_rawDesc = "" +
 	"claim_type\x18\x01 \x01(\tB6\xbaG3\x92\x020The type of the claim used to extract the token.R\n" +
 	"claim_type\x12;\n" +
 	"\ftoken_header\x18\x02 \x01(\v2\x17.google.protobuf.StructR\ftoken_header\x12{\n" +
-	"\x06config\x18d \x01(\v20.runtime.api.config.middleware.jwt.v1.AuthConfigB1\xbaG.\x92\x02+The configuration used to create the token.R\x06config\"\xa2\a\n" +
+	"\x06config\x18d \x01(\v20.runtime.api.config.middleware.jwt.v1.AuthConfigB1\xbaG.\x92\x02+The configuration used to create the token.R\x06config\"\xfa\r\n" +
 	"\n" +
 	"AuthConfig\x12\x80\x01\n" +
-	"\x0esigning_method\x18\x01 \x01(\tBX\xfaB\x14r\x12\x10\x01\x18\x80\b2\v^[A-Z0-9]+$\xbaG>\x92\x02;The signing method used for the token (e.g., HS256, RS256).R\x0esigning_method\x12]\n" +
-	"\vsigning_key\x18\x02 \x01(\tB;\xfaB\ar\x05\x10\x01\x18\x80\b\xbaG.\x92\x02+The signing key used for signing the token.R\vsigning_key\x12q\n" +
-	"\x15secondary_signing_key\x18\x03 \x01(\tB;\xbaG8\x92\x025The secondary signing key used for signing the token.R\x15secondary_signing_key\x12\x96\x01\n" +
+	"\x0esigning_method\x18\x01 \x01(\tBX\xfaB\x14r\x12\x10\x01\x18\x80\b2\v^[A-Z0-9]+$\xbaG>\x92\x02;The signing method used for the token (e.g., HS256, RS256).R\x0esigning_method\x12\xc0\x01\n" +
+	"\vsigning_key\x18\x02 \x01(\tB\x9d\x01\xfaB\n" +
+	"r\b\x10\x01\x18\x80\b\xd0\x01\x01\xbaG\x89\x01\x92\x02\x85\x01The signing key used for signing the token. For RS and ES methods, the PEM public key verifying the tokens. Optional with a jwks_url.\x80\x01\x01R\vsigning_key\x12\xc0\x01\n" +
+	"\x15secondary_signing_key\x18\x03 \x01(\tB\x89\x01\xbaG\x82\x01\x92\x02\x7fThe secondary key verifying the tokens, such as the previous signing key while the tokens signed before its rotation are valid.\x80\x01\x01R\x15secondary_signing_key\x12\x96\x01\n" +
 	"\x15access_token_lifetime\x18\x04 \x01(\x03B`\xfaB\t\"\a\x18\x80\xe7\x84\x0f(\x01\xbaGQ\x92\x02NThe lifetime of the access token in seconds. A common value is 7200 (2 hours).R\x15access_token_lifetime\x12w\n" +
 	"\x16refresh_token_lifetime\x18\x05 \x01(\x03B?\xfaB\t\"\a\x18\x80\xe7\x84\x0f(\x01\xbaG0\x92\x02-The lifetime of the refresh token in seconds.R\x16refresh_token_lifetime\x126\n" +
 	"\x06issuer\x18\x06 \x01(\tB\x1e\xbaG\x1b\x92\x02\x18The issuer of the token.R\x06issuer\x12\\\n" +
 	"\baudience\x18\a \x03(\tB@\xfaB\n" +
 	"\x92\x01\a\b\x01\x10\x80\b\x18\x01\xbaG0\x92\x02-The audience for which the token is intended.R\baudience\x12\x85\x01\n" +
-	"\ftoken_source\x18\b \x01(\tB\\\xbaGY\x92\x02VDefines how to extract the token from the request. Defaults to 'header:Authorization'.H\x00R\ftoken_source\x88\x01\x01B\x0f\n" +
+	"\ftoken_source\x18\b \x01(\tB\\\xbaGY\x92\x02VDefines how to extract the token from the request. Defaults to 'header:Authorization'.H\x00R\ftoken_source\x88\x01\x01\x12\x9f\x01\n" +
+	"\x06key_id\x18\t \x01(\tB\x86\x01\xbaG\x82\x01\x92\x02\x7fThe kid of the signing key, set in the header of the generated tokens. The tokens of another kid are not verified with the key.R\x06key_id\x12W\n" +
+	"\x10secondary_key_id\x18\n" +
+	" \x01(\tB+\xbaG(\x92\x02%The kid of the secondary signing key.R\x10secondary_key_id\x12\x7f\n" +
+	"\bjwks_url\x18\v \x01(\tBc\xfaB\br\x06\xd0\x01\x01\x88\x01\x01\xbaGU\x92\x02RThe URL of a JSON Web Key Set whose keys verify the tokens, selected by their kid.R\bjwks_url\x12\xa2\x01\n" +
+	"\x15jwks_refresh_interval\x18\f \x01(\x03Bl\xfaB\x04\"\x02(\x00\xbaGb\x92\x02_The age in seconds after which the JWKS is refreshed when a token is verified. Defaults to 300.R\x15jwks_refresh_interval\x12\x80\x01\n" +
+	"\n" +
+	"clock_skew\x18\r \x01(\x03B`\xfaB\a\"\x05\x18\x90\x1c(\x00\xbaGS\x92\x02PThe tolerated clock skew in seconds when validating the exp, nbf and iat claims.R\n" +
+	"clock_skewB\x0f\n" +
 	"\r_token_sourceB\xb3\x02\n" +
 	"(com.runtime.api.config.middleware.jwt.v1B\bJwtProtoP\x01ZFgithub.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1;jwtv1\xa2\x02\x05RACMJ\xaa\x02$Runtime.Api.Config.Middleware.Jwt.V1\xca\x02$Runtime\\Api\\Config\\Middleware\\Jwt\\V1\xe2\x020Runtime\\Api\\Config\\Middleware\\Jwt\\V1\\GPBMetadata\xea\x02)Runtime::Api::Config::Middleware::Jwt::V1b\x06proto3"
 
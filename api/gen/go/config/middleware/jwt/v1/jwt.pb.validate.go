@@ -237,15 +237,19 @@ func (m *AuthConfig) validate(all bool) error {
 		errors = append(errors, err)
 	}
 
-	if l := utf8.RuneCountInString(m.GetSigningKey()); l < 1 || l > 1024 {
-		err := AuthConfigValidationError{
-			field:  "SigningKey",
-			reason: "value length must be between 1 and 1024 runes, inclusive",
-		}
-		if !all {
-			return err
+	if m.GetSigningKey() != "" {
+
+		if l := utf8.RuneCountInString(m.GetSigningKey()); l < 1 || l > 1024 {
+			err := AuthConfigValidationError{
+				field:  "SigningKey",
+				reason: "value length must be between 1 and 1024 runes, inclusive",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
 		}
-		errors = append(errors, err)
+
 	}
 
 	// no validation rules for SecondarySigningKey
@@ -306,6 +310,57 @@ func (m *AuthConfig) validate(all bool) error {
 		// no validation rules for Audience[idx]
 	}
 
+	// no validation rules for KeyId
+
+	// no validation rules for SecondaryKeyId
+
+	if m.GetJwksUrl() != "" {
+
+		if uri, err := url.Parse(m.GetJwksUrl()); err != nil {
+			err = AuthConfigValidationError{
+				field:  "JwksUrl",
+				reason: "value must be a valid URI",
+				cause:  err,
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		} else if !uri.IsAbs() {
+			err := AuthConfigValidationError{
+				field:  "JwksUrl",
+				reason: "value must be absolute",
+			}
+			if !all {
+				return err
+			}
+			errors = append(errors, err)
+		}
+
+	}
+
+	if m.GetJwksRefreshInterval() < 0 {
+		err := AuthConfigValidationError{
+			field:  "JwksRefreshInterval",
+			reason: "value must be greater than or equal to 0",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
+	if val := m.GetClockSkew(); val < 0 || val > 3600 {
+		err := AuthConfigValidationError{
+			field:  "ClockSkew",
+			reason: "value must be inside range [0, 3600]",
+		}
+		if !all {
+			return err
+		}
+		errors = append(errors, err)
+	}
+
 	if m.TokenSource != nil {
 		// no validation rules for TokenSource
 	}
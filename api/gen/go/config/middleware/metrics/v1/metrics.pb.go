@@ -21,7 +21,8 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-// Type of indicator (e.g. counter, timer, histogram, etc.)
+// Type of indicator (e.g. counter, timer, histogram, etc.), the summaries are recorded
+// as histograms
 type UserMetric_MetricType int32
 
 const (
@@ -77,15 +78,17 @@ func (UserMetric_MetricType) EnumDescriptor() ([]byte, []int) {
 	return file_config_middleware_metrics_v1_metrics_proto_rawDescGZIP(), []int{0, 0}
 }
 
+// UserMetric declares a metric recorded by the application, exported along with those
+// of the middleware.
 type UserMetric struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// Timestamp: indicates the time of indicator data
 	Timestamp int64 `protobuf:"varint,1,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
 	// Indicator name
 	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	// Indicator value
+	// Indicator value, the initial value of the counters and gauges
 	Value float64 `protobuf:"fixed64,3,opt,name=value,proto3" json:"value,omitempty"`
-	// Indicator label for classification or filtering
+	// Indicator label for classification or filtering, added to every recorded value
 	Labels map[string]string `protobuf:"bytes,4,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	// Indicator unit
 	Unit string                `protobuf:"bytes,5,opt,name=unit,proto3" json:"unit,omitempty"`
@@ -196,7 +199,8 @@ func (x *UserMetric) GetMetadata() map[string]string {
 // Metrics
 type Metrics struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// Add a list of supported metrics for enabling or disabling specific metrics
+	// Add a list of supported metrics for enabling or disabling specific metrics:
+	// "requests", "errors" and "duration", all of them when empty
 	SupportedMetrics []string `protobuf:"bytes,5,rep,name=supported_metrics,proto3" json:"supported_metrics,omitempty"`
 	// Repeated field for user-defined metrics
 	UserMetrics   []*UserMetric `protobuf:"bytes,6,rep,name=user_metrics,proto3" json:"user_metrics,omitempty"`
@@ -8,6 +8,7 @@ package ratelimitv1
 
 import (
 	_ "github.com/google/gnostic/openapiv3"
+	v1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
 	structpb "google.golang.org/protobuf/types/known/structpb"
@@ -40,9 +41,15 @@ type RateLimiter struct {
 	// The number of seconds until the current rate limit window completely resets
 	XRatelimitReset int32 `protobuf:"varint,6,opt,name=x_ratelimit_reset,proto3" json:"x_ratelimit_reset,omitempty"`
 	// When rate limited, the number of seconds to wait before another request will be accepted
-	RetryAfter int32               `protobuf:"varint,7,opt,name=retry_after,proto3" json:"retry_after,omitempty"`
-	Memory     *RateLimiter_Memory `protobuf:"bytes,101,opt,name=memory,proto3" json:"memory,omitempty"`
-	Redis      *RateLimiter_Redis  `protobuf:"bytes,102,opt,name=redis,proto3" json:"redis,omitempty"`
+	RetryAfter int32 `protobuf:"varint,7,opt,name=retry_after,proto3" json:"retry_after,omitempty"`
+	// The limiting algorithm of the memory and redis limiters, "token_bucket" or "sliding_window"
+	Algorithm string `protobuf:"bytes,8,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	// Keyed limits of the memory and redis limiters, applied in addition to x_ratelimit_limit
+	Rules []*RateLimiter_Rule `protobuf:"bytes,9,rep,name=rules,proto3" json:"rules,omitempty"`
+	// Proxies whose forwarded headers are trusted by the rules keyed by ip
+	TrustedProxies []string            `protobuf:"bytes,10,rep,name=trusted_proxies,proto3" json:"trusted_proxies,omitempty"`
+	Memory         *RateLimiter_Memory `protobuf:"bytes,101,opt,name=memory,proto3" json:"memory,omitempty"`
+	Redis          *RateLimiter_Redis  `protobuf:"bytes,102,opt,name=redis,proto3" json:"redis,omitempty"`
 	// Optional custom configuration for rate limiter types not explicitly defined.
 	Settings      *structpb.Struct `protobuf:"bytes,100,opt,name=settings,proto3,oneof" json:"settings,omitempty"`
 	unknownFields protoimpl.UnknownFields
@@ -121,6 +128,27 @@ func (x *RateLimiter) GetRetryAfter() int32 {
 	return 0
 }
 
+func (x *RateLimiter) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
+func (x *RateLimiter) GetRules() []*RateLimiter_Rule {
+	if x != nil {
+		return x.Rules
+	}
+	return nil
+}
+
+func (x *RateLimiter) GetTrustedProxies() []string {
+	if x != nil {
+		return x.TrustedProxies
+	}
+	return nil
+}
+
 func (x *RateLimiter) GetMemory() *RateLimiter_Memory {
 	if x != nil {
 		return x.Memory
@@ -262,18 +290,107 @@ func (x *RateLimiter_Memory) GetCleanupInterval() int64 {
 	return 0
 }
 
+// A limit applied per key to the operations matched by a selector
+type RateLimiter_Rule struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Selector      *v1.Selector           `protobuf:"bytes,2,opt,name=selector,proto3" json:"selector,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Limit         int32                  `protobuf:"varint,4,opt,name=limit,proto3" json:"limit,omitempty"`
+	Period        int32                  `protobuf:"varint,5,opt,name=period,proto3" json:"period,omitempty"`
+	Algorithm     string                 `protobuf:"bytes,6,opt,name=algorithm,proto3" json:"algorithm,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RateLimiter_Rule) Reset() {
+	*x = RateLimiter_Rule{}
+	mi := &file_config_middleware_ratelimit_v1_ratelimiter_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RateLimiter_Rule) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RateLimiter_Rule) ProtoMessage() {}
+
+func (x *RateLimiter_Rule) ProtoReflect() protoreflect.Message {
+	mi := &file_config_middleware_ratelimit_v1_ratelimiter_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RateLimiter_Rule.ProtoReflect.Descriptor instead.
+func (*RateLimiter_Rule) Descriptor() ([]byte, []int) {
+	return file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDescGZIP(), []int{0, 2}
+}
+
+func (x *RateLimiter_Rule) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RateLimiter_Rule) GetSelector() *v1.Selector {
+	if x != nil {
+		return x.Selector
+	}
+	return nil
+}
+
+func (x *RateLimiter_Rule) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *RateLimiter_Rule) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *RateLimiter_Rule) GetPeriod() int32 {
+	if x != nil {
+		return x.Period
+	}
+	return 0
+}
+
+func (x *RateLimiter_Rule) GetAlgorithm() string {
+	if x != nil {
+		return x.Algorithm
+	}
+	return ""
+}
+
 var File_config_middleware_ratelimit_v1_ratelimiter_proto protoreflect.FileDescriptor
 
 const file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDesc = "" +
 	"\n" +
-	"0config/middleware/ratelimit/v1/ratelimiter.proto\x12*runtime.api.config.middleware.ratelimit.v1\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xb0\x06\n" +
+	"0config/middleware/ratelimit/v1/ratelimiter.proto\x12*runtime.api.config.middleware.ratelimit.v1\x1a,config/middleware/selector/v1/selector.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\x99\x10\n" +
 	"\vRateLimiter\x12x\n" +
 	"\x04name\x18\x01 \x01(\tBd\xbaGa\x92\x02^Rate limiter name. Built-in: 'bbr', 'memory', 'redis'. Custom types use their registered name.R\x04name\x12\x16\n" +
 	"\x06period\x18\x02 \x01(\x05R\x06period\x12,\n" +
 	"\x11x_ratelimit_limit\x18\x04 \x01(\x05R\x11x_ratelimit_limit\x124\n" +
 	"\x15x_ratelimit_remaining\x18\x05 \x01(\x05R\x15x_ratelimit_remaining\x12,\n" +
 	"\x11x_ratelimit_reset\x18\x06 \x01(\x05R\x11x_ratelimit_reset\x12 \n" +
-	"\vretry_after\x18\a \x01(\x05R\vretry_after\x12V\n" +
+	"\vretry_after\x18\a \x01(\x05R\vretry_after\x12}\n" +
+	"\talgorithm\x18\b \x01(\tB_\xbaG\\\x92\x02YAlgorithm of the memory and redis limiters: 'token_bucket' (default) or 'sliding_window'.R\talgorithm\x12\xbf\x01\n" +
+	"\x05rules\x18\t \x03(\v2<.runtime.api.config.middleware.ratelimit.v1.RateLimiter.RuleBk\xbaGh\x92\x02eLimits per key of the operations matched by their selector, applied in addition to x_ratelimit_limit.R\x05rules\x12\xc2\x01\n" +
+	"\x0ftrusted_proxies\x18\n" +
+	" \x03(\tB\x97\x01\xbaG\x93\x01\x92\x02\x8f\x01IPs or CIDRs of the proxies whose X-Forwarded-For and X-Real-IP headers give the client IP of the 'ip' key. The headers are ignored when unset.R\x0ftrusted_proxies\x12V\n" +
 	"\x06memory\x18e \x01(\v2>.runtime.api.config.middleware.ratelimit.v1.RateLimiter.MemoryR\x06memory\x12S\n" +
 	"\x05redis\x18f \x01(\v2=.runtime.api.config.middleware.ratelimit.v1.RateLimiter.RedisR\x05redis\x12f\n" +
 	"\bsettings\x18d \x01(\v2\x17.google.protobuf.StructB,\xbaG)\x92\x02&Non-standard or user-defined settings.H\x00R\bsettings\x88\x01\x01\x1ac\n" +
@@ -286,7 +403,14 @@ const file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDesc = "" +
 	"\n" +
 	"expiration\x18\x01 \x01(\x03R\n" +
 	"expiration\x12*\n" +
-	"\x10cleanup_interval\x18\x02 \x01(\x03R\x10cleanup_intervalB\v\n" +
+	"\x10cleanup_interval\x18\x02 \x01(\x03R\x10cleanup_interval\x1a\xe0\x05\n" +
+	"\x04Rule\x12G\n" +
+	"\x04name\x18\x01 \x01(\tB3\xbaG0\x92\x02-Rule name, prefixes the keys of its counters.R\x04name\x12\xae\x01\n" +
+	"\bselector\x18\x02 \x01(\v23.runtime.api.config.middleware.selector.v1.SelectorB]\xbaGZ\x92\x02WOperations the rule applies to, by paths, prefixes or regex. All operations when unset.R\bselector\x12\xbf\x01\n" +
+	"\x03key\x18\x03 \x01(\tB\xac\x01\xbaG\xa8\x01\x92\x02\xa4\x01Key the requests are counted by: 'ip' (see trusted_proxies), 'subject' (JWT subject), 'operation', 'header:<name>' or 'metadata:<key>'. A single counter when empty.R\x03key\x12O\n" +
+	"\x05limit\x18\x04 \x01(\x05B9\xbaG6\x92\x023The number of requests allowed per key in a period.R\x05limit\x12h\n" +
+	"\x06period\x18\x05 \x01(\x05BP\xbaGM\x92\x02JThe number of seconds of the period, defaults to that of the rate limiter.R\x06period\x12a\n" +
+	"\talgorithm\x18\x06 \x01(\tBC\xbaG@\x92\x02=The limiting algorithm, defaults to that of the rate limiter.R\talgorithmB\v\n" +
 	"\t_settingsB\xe5\x02\n" +
 	".com.runtime.api.config.middleware.ratelimit.v1B\x10RatelimiterProtoP\x01ZRgithub.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1;ratelimitv1\xa2\x02\x05RACMR\xaa\x02*Runtime.Api.Config.Middleware.Ratelimit.V1\xca\x02*Runtime\\Api\\Config\\Middleware\\Ratelimit\\V1\xe2\x026Runtime\\Api\\Config\\Middleware\\Ratelimit\\V1\\GPBMetadata\xea\x02/Runtime::Api::Config::Middleware::Ratelimit::V1b\x06proto3"
 
@@ -302,22 +426,26 @@ func file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDescGZIP() []byte
 	return file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDescData
 }
 
-var file_config_middleware_ratelimit_v1_ratelimiter_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_config_middleware_ratelimit_v1_ratelimiter_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_config_middleware_ratelimit_v1_ratelimiter_proto_goTypes = []any{
 	(*RateLimiter)(nil),        // 0: runtime.api.config.middleware.ratelimit.v1.RateLimiter
 	(*RateLimiter_Redis)(nil),  // 1: runtime.api.config.middleware.ratelimit.v1.RateLimiter.Redis
 	(*RateLimiter_Memory)(nil), // 2: runtime.api.config.middleware.ratelimit.v1.RateLimiter.Memory
-	(*structpb.Struct)(nil),    // 3: google.protobuf.Struct
+	(*RateLimiter_Rule)(nil),   // 3: runtime.api.config.middleware.ratelimit.v1.RateLimiter.Rule
+	(*structpb.Struct)(nil),    // 4: google.protobuf.Struct
+	(*v1.Selector)(nil),        // 5: runtime.api.config.middleware.selector.v1.Selector
 }
 var file_config_middleware_ratelimit_v1_ratelimiter_proto_depIdxs = []int32{
-	2, // 0: runtime.api.config.middleware.ratelimit.v1.RateLimiter.memory:type_name -> runtime.api.config.middleware.ratelimit.v1.RateLimiter.Memory
-	1, // 1: runtime.api.config.middleware.ratelimit.v1.RateLimiter.redis:type_name -> runtime.api.config.middleware.ratelimit.v1.RateLimiter.Redis
-	3, // 2: runtime.api.config.middleware.ratelimit.v1.RateLimiter.settings:type_name -> google.protobuf.Struct
-	3, // [3:3] is the sub-list for method output_type
-	3, // [3:3] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	3, // 0: runtime.api.config.middleware.ratelimit.v1.RateLimiter.rules:type_name -> runtime.api.config.middleware.ratelimit.v1.RateLimiter.Rule
+	2, // 1: runtime.api.config.middleware.ratelimit.v1.RateLimiter.memory:type_name -> runtime.api.config.middleware.ratelimit.v1.RateLimiter.Memory
+	1, // 2: runtime.api.config.middleware.ratelimit.v1.RateLimiter.redis:type_name -> runtime.api.config.middleware.ratelimit.v1.RateLimiter.Redis
+	4, // 3: runtime.api.config.middleware.ratelimit.v1.RateLimiter.settings:type_name -> google.protobuf.Struct
+	5, // 4: runtime.api.config.middleware.ratelimit.v1.RateLimiter.Rule.selector:type_name -> runtime.api.config.middleware.selector.v1.Selector
+	5, // [5:5] is the sub-list for method output_type
+	5, // [5:5] is the sub-list for method input_type
+	5, // [5:5] is the sub-list for extension type_name
+	5, // [5:5] is the sub-list for extension extendee
+	0, // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_config_middleware_ratelimit_v1_ratelimiter_proto_init() }
@@ -332,7 +460,7 @@ func file_config_middleware_ratelimit_v1_ratelimiter_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDesc), len(file_config_middleware_ratelimit_v1_ratelimiter_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   3,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -69,6 +69,42 @@ func (m *RateLimiter) validate(all bool) error {
 
 	// no validation rules for RetryAfter
 
+	// no validation rules for Algorithm
+
+	for idx, item := range m.GetRules() {
+		_, _ = idx, item
+
+		if all {
+			switch v := interface{}(item).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, RateLimiterValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, RateLimiterValidationError{
+						field:  fmt.Sprintf("Rules[%v]", idx),
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(item).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return RateLimiterValidationError{
+					field:  fmt.Sprintf("Rules[%v]", idx),
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if all {
 		switch v := interface{}(m.GetMemory()).(type) {
 		case interface{ ValidateAll() error }:
@@ -452,3 +488,142 @@ var _ interface {
 	Cause() error
 	ErrorName() string
 } = RateLimiter_MemoryValidationError{}
+
+// Validate checks the field values on RateLimiter_Rule with the rules defined
+// in the proto definition for this message. If any rules are violated, the
+// first error encountered is returned, or nil if there are no violations.
+func (m *RateLimiter_Rule) Validate() error {
+	return m.validate(false)
+}
+
+// ValidateAll checks the field values on RateLimiter_Rule with the rules
+// defined in the proto definition for this message. If any rules are
+// violated, the result is a list of violation errors wrapped in
+// RateLimiter_RuleMultiError, or nil if none found.
+func (m *RateLimiter_Rule) ValidateAll() error {
+	return m.validate(true)
+}
+
+func (m *RateLimiter_Rule) validate(all bool) error {
+	if m == nil {
+		return nil
+	}
+
+	var errors []error
+
+	// no validation rules for Name
+
+	if all {
+		switch v := interface{}(m.GetSelector()).(type) {
+		case interface{ ValidateAll() error }:
+			if err := v.ValidateAll(); err != nil {
+				errors = append(errors, RateLimiter_RuleValidationError{
+					field:  "Selector",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		case interface{ Validate() error }:
+			if err := v.Validate(); err != nil {
+				errors = append(errors, RateLimiter_RuleValidationError{
+					field:  "Selector",
+					reason: "embedded message failed validation",
+					cause:  err,
+				})
+			}
+		}
+	} else if v, ok := interface{}(m.GetSelector()).(interface{ Validate() error }); ok {
+		if err := v.Validate(); err != nil {
+			return RateLimiter_RuleValidationError{
+				field:  "Selector",
+				reason: "embedded message failed validation",
+				cause:  err,
+			}
+		}
+	}
+
+	// no validation rules for Key
+
+	// no validation rules for Limit
+
+	// no validation rules for Period
+
+	// no validation rules for Algorithm
+
+	if len(errors) > 0 {
+		return RateLimiter_RuleMultiError(errors)
+	}
+
+	return nil
+}
+
+// RateLimiter_RuleMultiError is an error wrapping multiple validation errors
+// returned by RateLimiter_Rule.ValidateAll() if the designated constraints
+// aren't met.
+type RateLimiter_RuleMultiError []error
+
+// Error returns a concatenation of all the error messages it wraps.
+func (m RateLimiter_RuleMultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, err := range m {
+		msgs = append(msgs, err.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// AllErrors returns a list of validation violation errors.
+func (m RateLimiter_RuleMultiError) AllErrors() []error { return m }
+
+// RateLimiter_RuleValidationError is the validation error returned by
+// RateLimiter_Rule.Validate if the designated constraints aren't met.
+type RateLimiter_RuleValidationError struct {
+	field  string
+	reason string
+	cause  error
+	key    bool
+}
+
+// Field function returns field value.
+func (e RateLimiter_RuleValidationError) Field() string { return e.field }
+
+// Reason function returns reason value.
+func (e RateLimiter_RuleValidationError) Reason() string { return e.reason }
+
+// Cause function returns cause value.
+func (e RateLimiter_RuleValidationError) Cause() error { return e.cause }
+
+// Key function returns key value.
+func (e RateLimiter_RuleValidationError) Key() bool { return e.key }
+
+// ErrorName returns error name.
+func (e RateLimiter_RuleValidationError) ErrorName() string { return "RateLimiter_RuleValidationError" }
+
+// Error satisfies the builtin error interface
+func (e RateLimiter_RuleValidationError) Error() string {
+	cause := ""
+	if e.cause != nil {
+		cause = fmt.Sprintf(" | caused by: %v", e.cause)
+	}
+
+	key := ""
+	if e.key {
+		key = "key for "
+	}
+
+	return fmt.Sprintf(
+		"invalid %sRateLimiter_Rule.%s: %s%s",
+		key,
+		e.field,
+		e.reason,
+		cause)
+}
+
+var _ error = RateLimiter_RuleValidationError{}
+
+var _ interface {
+	Field() string
+	Reason() string
+	Key() bool
+	Cause() error
+	ErrorName() string
+} = RateLimiter_RuleValidationError{}
@@ -13,7 +13,9 @@ import (
 	v13 "github.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1"
 	v11 "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
 	v1 "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
+	v17 "github.com/origadmin/runtime/api/gen/go/config/middleware/retry/v1"
 	v14 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+	v18 "github.com/origadmin/runtime/api/gen/go/config/middleware/timeout/v1"
 	v12 "github.com/origadmin/runtime/api/gen/go/config/middleware/validator/v1"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
@@ -176,6 +178,8 @@ type Middleware struct {
 	Recovery       *Recovery           `protobuf:"bytes,12,opt,name=recovery,proto3,oneof" json:"recovery,omitempty"`
 	Metadata       *Metadata           `protobuf:"bytes,13,opt,name=metadata,proto3,oneof" json:"metadata,omitempty"`
 	Security       *Security           `protobuf:"bytes,14,opt,name=security,proto3,oneof" json:"security,omitempty"`
+	Retry          *v17.Retry          `protobuf:"bytes,15,opt,name=retry,proto3,oneof" json:"retry,omitempty"`
+	Timeout        *v18.Timeout        `protobuf:"bytes,16,opt,name=timeout,proto3,oneof" json:"timeout,omitempty"`
 	Settings       *structpb.Struct    `protobuf:"bytes,100,opt,name=settings,proto3,oneof" json:"settings,omitempty"` // Add other specific middleware types here as they are defined
 	unknownFields  protoimpl.UnknownFields
 	sizeCache      protoimpl.SizeCache
@@ -309,6 +313,20 @@ func (x *Middleware) GetSecurity() *Security {
 	return nil
 }
 
+func (x *Middleware) GetRetry() *v17.Retry {
+	if x != nil {
+		return x.Retry
+	}
+	return nil
+}
+
+func (x *Middleware) GetTimeout() *v18.Timeout {
+	if x != nil {
+		return x.Timeout
+	}
+	return nil
+}
+
 func (x *Middleware) GetSettings() *structpb.Struct {
 	if x != nil {
 		return x.Settings
@@ -366,7 +384,7 @@ var File_config_middleware_v1_middleware_proto protoreflect.FileDescriptor
 
 const file_config_middleware_v1_middleware_proto_rawDesc = "" +
 	"\n" +
-	"%config/middleware/v1/middleware.proto\x12 runtime.api.config.middleware.v1\x1a8config/middleware/circuitbreaker/v1/circuitbreaker.proto\x1a$config/middleware/cors/v1/cors.proto\x1a\"config/middleware/jwt/v1/jwt.proto\x1a*config/middleware/metrics/v1/metrics.proto\x1a0config/middleware/ratelimit/v1/ratelimiter.proto\x1a,config/middleware/selector/v1/selector.proto\x1a#config/middleware/v1/security.proto\x1a.config/middleware/validator/v1/validator.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xf7\x01\n" +
+	"%config/middleware/v1/middleware.proto\x12 runtime.api.config.middleware.v1\x1a8config/middleware/circuitbreaker/v1/circuitbreaker.proto\x1a$config/middleware/cors/v1/cors.proto\x1a\"config/middleware/jwt/v1/jwt.proto\x1a*config/middleware/metrics/v1/metrics.proto\x1a0config/middleware/ratelimit/v1/ratelimiter.proto\x1a&config/middleware/retry/v1/retry.proto\x1a,config/middleware/selector/v1/selector.proto\x1a*config/middleware/timeout/v1/timeout.proto\x1a#config/middleware/v1/security.proto\x1a.config/middleware/validator/v1/validator.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"\xf7\x01\n" +
 	"\bMetadata\x12D\n" +
 	"\bprefixes\x18\x01 \x03(\tB(\xbaG%\x92\x02\"List of prefixes for the metadata.R\bprefixes\x12l\n" +
 	"\x04data\x18\x02 \x03(\v24.runtime.api.config.middleware.v1.Metadata.DataEntryB\"\xbaG\x1f\x92\x02\x1cKey-value pairs of metadata.R\x04data\x1a7\n" +
@@ -375,7 +393,7 @@ const file_config_middleware_v1_middleware_proto_rawDesc = "" +
 	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\t\n" +
 	"\aLogging\"\n" +
 	"\n" +
-	"\bRecovery\"\xec\x0e\n" +
+	"\bRecovery\"\xee\x10\n" +
 	"\n" +
 	"Middleware\x12>\n" +
 	"\x04name\x18\x01 \x01(\tB*\xbaG'\x92\x02$The name of the middleware instance.R\x04name\x12\x93\x01\n" +
@@ -393,8 +411,10 @@ const file_config_middleware_v1_middleware_proto_rawDesc = "" +
 	"\brecovery\x18\f \x01(\v2*.runtime.api.config.middleware.v1.RecoveryB(\xbaG%\x92\x02\"Recovery middleware configuration.H\bR\brecovery\x88\x01\x01\x12}\n" +
 	"\bmetadata\x18\r \x01(\v2*.runtime.api.config.middleware.v1.MetadataB0\xbaG-\x92\x02*Metadata configuration for the middleware.H\tR\bmetadata\x88\x01\x01\x12\x81\x01\n" +
 	"\bsecurity\x18\x0e \x01(\v2*.runtime.api.config.middleware.v1.SecurityB4\xbaG1\x92\x02.Declarative security middleware configuration.H\n" +
-	"R\bsecurity\x88\x01\x01\x12`\n" +
-	"\bsettings\x18d \x01(\v2\x17.google.protobuf.StructB&\xbaG#\x92\x02 Custom middleware configuration.H\vR\bsettings\x88\x01\x01B\x0f\n" +
+	"R\bsecurity\x88\x01\x01\x12o\n" +
+	"\x05retry\x18\x0f \x01(\v2-.runtime.api.config.middleware.retry.v1.RetryB%\xbaG\"\x92\x02\x1fRetry middleware configuration.H\vR\x05retry\x88\x01\x01\x12y\n" +
+	"\atimeout\x18\x10 \x01(\v21.runtime.api.config.middleware.timeout.v1.TimeoutB'\xbaG$\x92\x02!Timeout middleware configuration.H\fR\atimeout\x88\x01\x01\x12`\n" +
+	"\bsettings\x18d \x01(\v2\x17.google.protobuf.StructB&\xbaG#\x92\x02 Custom middleware configuration.H\rR\bsettings\x88\x01\x01B\x0f\n" +
 	"\r_rate_limiterB\n" +
 	"\n" +
 	"\b_metricsB\f\n" +
@@ -408,7 +428,10 @@ const file_config_middleware_v1_middleware_proto_rawDesc = "" +
 	"\b_loggingB\v\n" +
 	"\t_recoveryB\v\n" +
 	"\t_metadataB\v\n" +
-	"\t_securityB\v\n" +
+	"\t_securityB\b\n" +
+	"\x06_retryB\n" +
+	"\n" +
+	"\b_timeoutB\v\n" +
 	"\t_settings\"\x99\x01\n" +
 	"\vMiddlewares\x12\x89\x01\n" +
 	"\aconfigs\x18\x01 \x03(\v2,.runtime.api.config.middleware.v1.MiddlewareBA\xbaG>\x92\x02;A list of middleware configurations to be applied in order.R\aconfigsB\xa7\x02\n" +
@@ -442,7 +465,9 @@ var file_config_middleware_v1_middleware_proto_goTypes = []any{
 	(*v15.Cors)(nil),           // 11: runtime.api.config.middleware.cors.v1.Cors
 	(*v16.CircuitBreaker)(nil), // 12: runtime.api.config.middleware.circuitbreaker.v1.CircuitBreaker
 	(*Security)(nil),           // 13: runtime.api.config.middleware.v1.Security
-	(*structpb.Struct)(nil),    // 14: google.protobuf.Struct
+	(*v17.Retry)(nil),          // 14: runtime.api.config.middleware.retry.v1.Retry
+	(*v18.Timeout)(nil),        // 15: runtime.api.config.middleware.timeout.v1.Timeout
+	(*structpb.Struct)(nil),    // 16: google.protobuf.Struct
 }
 var file_config_middleware_v1_middleware_proto_depIdxs = []int32{
 	5,  // 0: runtime.api.config.middleware.v1.Metadata.data:type_name -> runtime.api.config.middleware.v1.Metadata.DataEntry
@@ -457,13 +482,15 @@ var file_config_middleware_v1_middleware_proto_depIdxs = []int32{
 	2,  // 9: runtime.api.config.middleware.v1.Middleware.recovery:type_name -> runtime.api.config.middleware.v1.Recovery
 	0,  // 10: runtime.api.config.middleware.v1.Middleware.metadata:type_name -> runtime.api.config.middleware.v1.Metadata
 	13, // 11: runtime.api.config.middleware.v1.Middleware.security:type_name -> runtime.api.config.middleware.v1.Security
-	14, // 12: runtime.api.config.middleware.v1.Middleware.settings:type_name -> google.protobuf.Struct
-	3,  // 13: runtime.api.config.middleware.v1.Middlewares.configs:type_name -> runtime.api.config.middleware.v1.Middleware
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	14, // 12: runtime.api.config.middleware.v1.Middleware.retry:type_name -> runtime.api.config.middleware.retry.v1.Retry
+	15, // 13: runtime.api.config.middleware.v1.Middleware.timeout:type_name -> runtime.api.config.middleware.timeout.v1.Timeout
+	16, // 14: runtime.api.config.middleware.v1.Middleware.settings:type_name -> google.protobuf.Struct
+	3,  // 15: runtime.api.config.middleware.v1.Middlewares.configs:type_name -> runtime.api.config.middleware.v1.Middleware
+	16, // [16:16] is the sub-list for method output_type
+	16, // [16:16] is the sub-list for method input_type
+	16, // [16:16] is the sub-list for extension type_name
+	16, // [16:16] is the sub-list for extension extendee
+	0,  // [0:16] is the sub-list for field type_name
 }
 
 func init() { file_config_middleware_v1_middleware_proto_init() }
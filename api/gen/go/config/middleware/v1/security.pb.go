@@ -26,6 +26,8 @@ const (
 type Security struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// The name of the default security policy to apply if a route does not specify one.
+	// The built-in policies are "public", "authenticated" and "deny", the others are
+	// evaluated by the PolicyEvaluator of the application.
 	// If not set, and a route has no policy, access will be denied.
 	DefaultPolicy string `protobuf:"bytes,1,opt,name=default_policy,proto3" json:"default_policy,omitempty"`
 	unknownFields protoimpl.UnknownFields
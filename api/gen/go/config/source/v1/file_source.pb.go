@@ -28,10 +28,13 @@ type FileSource struct {
 	Format  string                 `protobuf:"bytes,2,opt,name=format,proto3" json:"format,omitempty"`
 	Ignores []string               `protobuf:"bytes,3,rep,name=ignores,proto3" json:"ignores,omitempty"`
 	// supported file formats, if not set, all formats are supported
-	Formats       []string `protobuf:"bytes,4,rep,name=formats,proto3" json:"formats,omitempty"`
-	Reload        bool     `protobuf:"varint,6,opt,name=reload,proto3" json:"reload,omitempty"`
-	Optional      bool     `protobuf:"varint,7,opt,name=optional,proto3" json:"optional,omitempty"`
-	Filter        string   `protobuf:"bytes,8,opt,name=filter,proto3" json:"filter,omitempty"`
+	Formats  []string `protobuf:"bytes,4,rep,name=formats,proto3" json:"formats,omitempty"`
+	Reload   bool     `protobuf:"varint,6,opt,name=reload,proto3" json:"reload,omitempty"`
+	Optional bool     `protobuf:"varint,7,opt,name=optional,proto3" json:"optional,omitempty"`
+	Filter   string   `protobuf:"bytes,8,opt,name=filter,proto3" json:"filter,omitempty"`
+	// allow files loaded by the same source to override each other's keys
+	// instead of reporting a duplicate-key conflict
+	Override      bool `protobuf:"varint,9,opt,name=override,proto3" json:"override,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -115,11 +118,18 @@ func (x *FileSource) GetFilter() string {
 	return ""
 }
 
+func (x *FileSource) GetOverride() bool {
+	if x != nil {
+		return x.Override
+	}
+	return false
+}
+
 var File_config_source_v1_file_source_proto protoreflect.FileDescriptor
 
 const file_config_source_v1_file_source_proto_rawDesc = "" +
 	"\n" +
-	"\"config/source/v1/file_source.proto\x12\x1cruntime.api.config.source.v1\"\xb8\x01\n" +
+	"\"config/source/v1/file_source.proto\x12\x1cruntime.api.config.source.v1\"\xd4\x01\n" +
 	"\n" +
 	"FileSource\x12\x12\n" +
 	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
@@ -128,7 +138,8 @@ const file_config_source_v1_file_source_proto_rawDesc = "" +
 	"\aformats\x18\x04 \x03(\tR\aformats\x12\x16\n" +
 	"\x06reload\x18\x06 \x01(\bR\x06reload\x12\x1a\n" +
 	"\boptional\x18\a \x01(\bR\boptional\x12\x16\n" +
-	"\x06filter\x18\b \x01(\tR\x06filterB\x8b\x02\n" +
+	"\x06filter\x18\b \x01(\tR\x06filter\x12\x1a\n" +
+	"\boverride\x18\t \x01(\bR\boverrideB\x8b\x02\n" +
 	" com.runtime.api.config.source.v1B\x0fFileSourceProtoP\x01ZAgithub.com/origadmin/runtime/api/gen/go/config/source/v1;sourcev1\xa2\x02\x04RACS\xaa\x02\x1cRuntime.Api.Config.Source.V1\xca\x02\x1cRuntime\\Api\\Config\\Source\\V1\xe2\x02(Runtime\\Api\\Config\\Source\\V1\\GPBMetadata\xea\x02 Runtime::Api::Config::Source::V1b\x06proto3"
 
 var (
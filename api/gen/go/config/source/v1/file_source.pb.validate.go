@@ -67,6 +67,8 @@ func (m *FileSource) validate(all bool) error {
 
 	// no validation rules for Filter
 
+	// no validation rules for Override
+
 	if len(errors) > 0 {
 		return FileSourceMultiError(errors)
 	}
@@ -0,0 +1,150 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.10
+// 	protoc        (unknown)
+// source: config/source/v1/flags_source.proto
+
+package sourcev1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// FlagsSource is the command-line flag source
+type FlagsSource struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Name of the repeatable flag carrying key=value overrides, defaults to "set"
+	SetFlag string `protobuf:"bytes,1,opt,name=set_flag,proto3" json:"set_flag,omitempty"`
+	// Explicit key=value overrides, e.g. "servers.configs[0].http.addr=:9000"
+	Values []string `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	// Flag names bound to configuration keys, e.g. "http-addr" -> "servers.configs[0].http.addr"
+	Bindings      map[string]string `protobuf:"bytes,3,rep,name=bindings,proto3" json:"bindings,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FlagsSource) Reset() {
+	*x = FlagsSource{}
+	mi := &file_config_source_v1_flags_source_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FlagsSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FlagsSource) ProtoMessage() {}
+
+func (x *FlagsSource) ProtoReflect() protoreflect.Message {
+	mi := &file_config_source_v1_flags_source_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FlagsSource.ProtoReflect.Descriptor instead.
+func (*FlagsSource) Descriptor() ([]byte, []int) {
+	return file_config_source_v1_flags_source_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *FlagsSource) GetSetFlag() string {
+	if x != nil {
+		return x.SetFlag
+	}
+	return ""
+}
+
+func (x *FlagsSource) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+func (x *FlagsSource) GetBindings() map[string]string {
+	if x != nil {
+		return x.Bindings
+	}
+	return nil
+}
+
+var File_config_source_v1_flags_source_proto protoreflect.FileDescriptor
+
+const file_config_source_v1_flags_source_proto_rawDesc = "" +
+	"\n" +
+	"#config/source/v1/flags_source.proto\x12\x1cruntime.api.config.source.v1\"\xd3\x01\n" +
+	"\vFlagsSource\x12\x1a\n" +
+	"\bset_flag\x18\x01 \x01(\tR\bset_flag\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\x12S\n" +
+	"\bbindings\x18\x03 \x03(\v27.runtime.api.config.source.v1.FlagsSource.BindingsEntryR\bbindings\x1a;\n" +
+	"\rBindingsEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01B\x8c\x02\n" +
+	" com.runtime.api.config.source.v1B\x10FlagsSourceProtoP\x01ZAgithub.com/origadmin/runtime/api/gen/go/config/source/v1;sourcev1\xa2\x02\x04RACS\xaa\x02\x1cRuntime.Api.Config.Source.V1\xca\x02\x1cRuntime\\Api\\Config\\Source\\V1\xe2\x02(Runtime\\Api\\Config\\Source\\V1\\GPBMetadata\xea\x02 Runtime::Api::Config::Source::V1b\x06proto3"
+
+var (
+	file_config_source_v1_flags_source_proto_rawDescOnce sync.Once
+	file_config_source_v1_flags_source_proto_rawDescData []byte
+)
+
+func file_config_source_v1_flags_source_proto_rawDescGZIP() []byte {
+	file_config_source_v1_flags_source_proto_rawDescOnce.Do(func() {
+		file_config_source_v1_flags_source_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_config_source_v1_flags_source_proto_rawDesc), len(file_config_source_v1_flags_source_proto_rawDesc)))
+	})
+	return file_config_source_v1_flags_source_proto_rawDescData
+}
+
+var file_config_source_v1_flags_source_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_config_source_v1_flags_source_proto_goTypes = []any{
+	(*FlagsSource)(nil), // 0: runtime.api.config.source.v1.FlagsSource
+	nil,                 // 1: runtime.api.config.source.v1.FlagsSource.BindingsEntry
+}
+var file_config_source_v1_flags_source_proto_depIdxs = []int32{
+	1, // 0: runtime.api.config.source.v1.FlagsSource.bindings:type_name -> runtime.api.config.source.v1.FlagsSource.BindingsEntry
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_config_source_v1_flags_source_proto_init() }
+func file_config_source_v1_flags_source_proto_init() {
+	if File_config_source_v1_flags_source_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_config_source_v1_flags_source_proto_rawDesc), len(file_config_source_v1_flags_source_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_config_source_v1_flags_source_proto_goTypes,
+		DependencyIndexes: file_config_source_v1_flags_source_proto_depIdxs,
+		MessageInfos:      file_config_source_v1_flags_source_proto_msgTypes,
+	}.Build()
+	File_config_source_v1_flags_source_proto = out.File
+	file_config_source_v1_flags_source_proto_goTypes = nil
+	file_config_source_v1_flags_source_proto_depIdxs = nil
+}
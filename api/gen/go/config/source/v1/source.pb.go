@@ -92,7 +92,7 @@ type SourceConfig struct {
 	// name specifies the configuration name
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// type specifies the type of the configuration source.
-	// For built-in types, specify "env", "file", "etcd", "consul", "apollo", "nacos", "kubernetes", or "polaris".
+	// For built-in types, specify "env", "file", "etcd", "consul", "apollo", "nacos", "kubernetes", "polaris", or "flags".
 	// For custom types, specify the registered name of the custom source.
 	// When a custom type is used, its configuration should be placed in the 'customize' field.
 	Type string `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
@@ -111,6 +111,7 @@ type SourceConfig struct {
 	Apollo        *ApolloSource     `protobuf:"bytes,15,opt,name=apollo,proto3,oneof" json:"apollo,omitempty"`
 	Kubernetes    *KubernetesSource `protobuf:"bytes,16,opt,name=kubernetes,proto3,oneof" json:"kubernetes,omitempty"`
 	Polaris       *PolarisSource    `protobuf:"bytes,17,opt,name=polaris,proto3,oneof" json:"polaris,omitempty"`
+	Flags         *FlagsSource      `protobuf:"bytes,18,opt,name=flags,proto3,oneof" json:"flags,omitempty"`
 	Settings      *structpb.Struct  `protobuf:"bytes,100,opt,name=settings,proto3,oneof" json:"settings,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -230,6 +231,13 @@ func (x *SourceConfig) GetPolaris() *PolarisSource {
 	return nil
 }
 
+func (x *SourceConfig) GetFlags() *FlagsSource {
+	if x != nil {
+		return x.Flags
+	}
+	return nil
+}
+
 func (x *SourceConfig) GetSettings() *structpb.Struct {
 	if x != nil {
 		return x.Settings
@@ -241,11 +249,11 @@ var File_config_source_v1_source_proto protoreflect.FileDescriptor
 
 const file_config_source_v1_source_proto_rawDesc = "" +
 	"\n" +
-	"\x1dconfig/source/v1/source.proto\x12\x1cruntime.api.config.source.v1\x1a$config/source/v1/apollo_source.proto\x1a$config/source/v1/consul_source.proto\x1a!config/source/v1/env_source.proto\x1a\"config/source/v1/etcd_source.proto\x1a\"config/source/v1/file_source.proto\x1a(config/source/v1/kubernetes_source.proto\x1a#config/source/v1/nacos_source.proto\x1a%config/source/v1/polaris_source.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"}\n" +
+	"\x1dconfig/source/v1/source.proto\x12\x1cruntime.api.config.source.v1\x1a$config/source/v1/apollo_source.proto\x1a$config/source/v1/consul_source.proto\x1a!config/source/v1/env_source.proto\x1a\"config/source/v1/etcd_source.proto\x1a\"config/source/v1/file_source.proto\x1a#config/source/v1/flags_source.proto\x1a(config/source/v1/kubernetes_source.proto\x1a#config/source/v1/nacos_source.proto\x1a%config/source/v1/polaris_source.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1cgoogle/protobuf/struct.proto\"}\n" +
 	"\aSources\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
 	"\aversion\x18\x02 \x01(\tR\aversion\x12D\n" +
-	"\aconfigs\x18\x03 \x03(\v2*.runtime.api.config.source.v1.SourceConfigR\aconfigs\"\x90\v\n" +
+	"\aconfigs\x18\x03 \x03(\v2*.runtime.api.config.source.v1.SourceConfigR\aconfigs\"\x93\f\n" +
 	"\fSourceConfig\x12?\n" +
 	"\x04name\x18\x01 \x01(\tB+\xbaG(\x92\x02%The name of the configuration source.R\x04name\x12?\n" +
 	"\x04type\x18\x02 \x01(\tB+\xbaG(\x92\x02%The type of the configuration source.R\x04type\x12Y\n" +
@@ -261,8 +269,9 @@ const file_config_source_v1_source_proto_rawDesc = "" +
 	"\n" +
 	"kubernetes\x18\x10 \x01(\v2..runtime.api.config.source.v1.KubernetesSourceB*\xbaG'\x92\x02$The Kubernetes configuration source.H\x06R\n" +
 	"kubernetes\x88\x01\x01\x12s\n" +
-	"\apolaris\x18\x11 \x01(\v2+.runtime.api.config.source.v1.PolarisSourceB'\xbaG$\x92\x02!The Polaris configuration source.H\aR\apolaris\x88\x01\x01\x12f\n" +
-	"\bsettings\x18d \x01(\v2\x17.google.protobuf.StructB,\xbaG)\x92\x02&Non-standard or user-defined settings.H\bR\bsettings\x88\x01\x01B\x06\n" +
+	"\apolaris\x18\x11 \x01(\v2+.runtime.api.config.source.v1.PolarisSourceB'\xbaG$\x92\x02!The Polaris configuration source.H\aR\apolaris\x88\x01\x01\x12w\n" +
+	"\x05flags\x18\x12 \x01(\v2).runtime.api.config.source.v1.FlagsSourceB1\xbaG.\x92\x02+The command-line flag configuration source.H\bR\x05flags\x88\x01\x01\x12f\n" +
+	"\bsettings\x18d \x01(\v2\x17.google.protobuf.StructB,\xbaG)\x92\x02&Non-standard or user-defined settings.H\tR\bsettings\x88\x01\x01B\x06\n" +
 	"\x04_envB\a\n" +
 	"\x05_fileB\a\n" +
 	"\x05_etcdB\t\n" +
@@ -271,7 +280,8 @@ const file_config_source_v1_source_proto_rawDesc = "" +
 	"\a_apolloB\r\n" +
 	"\v_kubernetesB\n" +
 	"\n" +
-	"\b_polarisB\v\n" +
+	"\b_polarisB\b\n" +
+	"\x06_flagsB\v\n" +
 	"\t_settingsB\x87\x02\n" +
 	" com.runtime.api.config.source.v1B\vSourceProtoP\x01ZAgithub.com/origadmin/runtime/api/gen/go/config/source/v1;sourcev1\xa2\x02\x04RACS\xaa\x02\x1cRuntime.Api.Config.Source.V1\xca\x02\x1cRuntime\\Api\\Config\\Source\\V1\xe2\x02(Runtime\\Api\\Config\\Source\\V1\\GPBMetadata\xea\x02 Runtime::Api::Config::Source::V1b\x06proto3"
 
@@ -299,7 +309,8 @@ var file_config_source_v1_source_proto_goTypes = []any{
 	(*ApolloSource)(nil),     // 7: runtime.api.config.source.v1.ApolloSource
 	(*KubernetesSource)(nil), // 8: runtime.api.config.source.v1.KubernetesSource
 	(*PolarisSource)(nil),    // 9: runtime.api.config.source.v1.PolarisSource
-	(*structpb.Struct)(nil),  // 10: google.protobuf.Struct
+	(*FlagsSource)(nil),      // 10: runtime.api.config.source.v1.FlagsSource
+	(*structpb.Struct)(nil),  // 11: google.protobuf.Struct
 }
 var file_config_source_v1_source_proto_depIdxs = []int32{
 	1,  // 0: runtime.api.config.source.v1.Sources.configs:type_name -> runtime.api.config.source.v1.SourceConfig
@@ -311,12 +322,13 @@ var file_config_source_v1_source_proto_depIdxs = []int32{
 	7,  // 6: runtime.api.config.source.v1.SourceConfig.apollo:type_name -> runtime.api.config.source.v1.ApolloSource
 	8,  // 7: runtime.api.config.source.v1.SourceConfig.kubernetes:type_name -> runtime.api.config.source.v1.KubernetesSource
 	9,  // 8: runtime.api.config.source.v1.SourceConfig.polaris:type_name -> runtime.api.config.source.v1.PolarisSource
-	10, // 9: runtime.api.config.source.v1.SourceConfig.settings:type_name -> google.protobuf.Struct
-	10, // [10:10] is the sub-list for method output_type
-	10, // [10:10] is the sub-list for method input_type
-	10, // [10:10] is the sub-list for extension type_name
-	10, // [10:10] is the sub-list for extension extendee
-	0,  // [0:10] is the sub-list for field type_name
+	10, // 9: runtime.api.config.source.v1.SourceConfig.flags:type_name -> runtime.api.config.source.v1.FlagsSource
+	11, // 10: runtime.api.config.source.v1.SourceConfig.settings:type_name -> google.protobuf.Struct
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_config_source_v1_source_proto_init() }
@@ -329,6 +341,7 @@ func file_config_source_v1_source_proto_init() {
 	file_config_source_v1_env_source_proto_init()
 	file_config_source_v1_etcd_source_proto_init()
 	file_config_source_v1_file_source_proto_init()
+	file_config_source_v1_flags_source_proto_init()
 	file_config_source_v1_kubernetes_source_proto_init()
 	file_config_source_v1_nacos_source_proto_init()
 	file_config_source_v1_polaris_source_proto_init()
@@ -463,6 +463,39 @@ func (m *SourceConfig) validate(all bool) error {
 
 	}
 
+	if m.Flags != nil {
+
+		if all {
+			switch v := interface{}(m.GetFlags()).(type) {
+			case interface{ ValidateAll() error }:
+				if err := v.ValidateAll(); err != nil {
+					errors = append(errors, SourceConfigValidationError{
+						field:  "Flags",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			case interface{ Validate() error }:
+				if err := v.Validate(); err != nil {
+					errors = append(errors, SourceConfigValidationError{
+						field:  "Flags",
+						reason: "embedded message failed validation",
+						cause:  err,
+					})
+				}
+			}
+		} else if v, ok := interface{}(m.GetFlags()).(interface{ Validate() error }); ok {
+			if err := v.Validate(); err != nil {
+				return SourceConfigValidationError{
+					field:  "Flags",
+					reason: "embedded message failed validation",
+					cause:  err,
+				}
+			}
+		}
+
+	}
+
 	if m.Settings != nil {
 
 		if all {
@@ -27,11 +27,14 @@ const (
 // This provides a minimal set of options, allowing for future expansion.
 type Trace struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// name specifies the tracing system to use (e.g., "jaeger", "zipkin", "otlp").
-	// This can be used to select a registered TracerProvider.
+	// name specifies the exporter of the spans: "otlp" or "otlp-grpc" for OTLP over gRPC,
+	// "otlp-http" for OTLP over HTTP, "stdout" and "memory". "jaeger" exports OTLP, which
+	// Jaeger receives, over HTTP to a URL endpoint and over gRPC otherwise. When empty,
+	// the spans are sampled and propagated but not exported.
 	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	// endpoint is the address of the tracing collector/agent.
-	// e.g., "localhost:6831" for Jaeger agent, or "localhost:4317" for OTLP gRPC.
+	// e.g., "localhost:4317" for OTLP gRPC, or "localhost:4318" for OTLP HTTP. A host and
+	// port is reached without TLS, a URL such as "https://collector:4318" by its scheme.
 	Endpoint string `protobuf:"bytes,2,opt,name=endpoint,proto3" json:"endpoint,omitempty"`
 	// service_name is the name of the service reporting traces.
 	// If not set, it might default to the application's name.
@@ -40,7 +43,7 @@ type Trace struct {
 	Timeout *durationpb.Duration `protobuf:"bytes,4,opt,name=timeout,proto3,oneof" json:"timeout,omitempty"`
 	// ratio is the sampling probability (e.g., 0.01 for 1%).
 	// A value of 1.0 means always sample, 0.0 means never sample.
-	// If not set, a default sampling strategy might be applied.
+	// If not set, every span is sampled. The sampling decision of a parent span is kept.
 	Ratio         *float64 `protobuf:"fixed64,5,opt,name=ratio,proto3,oneof" json:"ratio,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
@@ -115,9 +118,9 @@ var File_config_trace_v1_trace_proto protoreflect.FileDescriptor
 
 const file_config_trace_v1_trace_proto_rawDesc = "" +
 	"\n" +
-	"\x1bconfig/trace/v1/trace.proto\x12\x1bruntime.api.config.trace.v1\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\"\xa2\x05\n" +
-	"\x05Trace\x12V\n" +
-	"\x04name\x18\x01 \x01(\tBB\xbaG?\x92\x02<The tracing system to use, e.g., \"jaeger\", \"zipkin\", \"otlp\".R\x04name\x12\x99\x01\n" +
+	"\x1bconfig/trace/v1/trace.proto\x12\x1bruntime.api.config.trace.v1\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\"\xc4\x05\n" +
+	"\x05Trace\x12x\n" +
+	"\x04name\x18\x01 \x01(\tBd\xbaGa\x92\x02^The exporter of the spans: \"otlp\" or \"otlp-grpc\", \"otlp-http\", \"jaeger\", \"stdout\" or \"memory\".R\x04name\x12\x99\x01\n" +
 	"\bendpoint\x18\x02 \x01(\tB}\xbaGz\x92\x02wThe address of the tracing collector/agent, e.g., \"localhost:6831\" for Jaeger agent, or \"localhost:4317\" for OTLP gRPC.R\bendpoint\x12\x8b\x01\n" +
 	"\fservice_name\x18\x03 \x01(\tBg\xbaGd\x92\x02aThe name of the service reporting traces. If not set, it might default to the application's name.R\fservice_name\x12t\n" +
 	"\atimeout\x18\x04 \x01(\v2\x19.google.protobuf.DurationB:\xbaG7\x92\x024Timeout for trace operations, e.g., exporting spans.H\x00R\atimeout\x88\x01\x01\x12\x8a\x01\n" +
@@ -44,9 +44,18 @@ type Server struct {
 	// cors configuration for the HTTP server
 	Cors *v11.Cors `protobuf:"bytes,6,opt,name=cors,proto3,oneof" json:"cors,omitempty"`
 	// enable_pprof indicates whether to enable pprof debugging endpoints.
-	EnablePprof   bool `protobuf:"varint,7,opt,name=enable_pprof,json=enablePprof,proto3" json:"enable_pprof,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	EnablePprof bool `protobuf:"varint,7,opt,name=enable_pprof,json=enablePprof,proto3" json:"enable_pprof,omitempty"`
+	// enable_log_buffer indicates whether to serve the query endpoint of the in-memory
+	// log buffer, when the logger keeps one. The endpoint is not authenticated.
+	EnableLogBuffer bool `protobuf:"varint,8,opt,name=enable_log_buffer,json=enableLogBuffer,proto3" json:"enable_log_buffer,omitempty"`
+	// enable_metrics indicates whether to serve the Prometheus exposition endpoint of the
+	// metrics recorded by the metrics middlewares. The endpoint is not authenticated.
+	EnableMetrics bool `protobuf:"varint,9,opt,name=enable_metrics,json=enableMetrics,proto3" json:"enable_metrics,omitempty"`
+	// enable_log_levels indicates whether to serve the admin endpoint of the module log
+	// levels, at /debug/log/levels. The endpoint is not authenticated.
+	EnableLogLevels bool `protobuf:"varint,10,opt,name=enable_log_levels,json=enableLogLevels,proto3" json:"enable_log_levels,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Server) Reset() {
@@ -128,6 +137,27 @@ func (x *Server) GetEnablePprof() bool {
 	return false
 }
 
+func (x *Server) GetEnableLogBuffer() bool {
+	if x != nil {
+		return x.EnableLogBuffer
+	}
+	return false
+}
+
+func (x *Server) GetEnableMetrics() bool {
+	if x != nil {
+		return x.EnableMetrics
+	}
+	return false
+}
+
+func (x *Server) GetEnableLogLevels() bool {
+	if x != nil {
+		return x.EnableLogLevels
+	}
+	return false
+}
+
 // Client defines the core configuration for creating a Kratos HTTP client.
 type Client struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
@@ -235,7 +265,7 @@ var File_config_transport_http_v1_http_proto protoreflect.FileDescriptor
 
 const file_config_transport_http_v1_http_proto_rawDesc = "" +
 	"\n" +
-	"#config/transport/http/v1/http.proto\x12$runtime.api.config.transport.http.v1\x1a$config/middleware/cors/v1/cors.proto\x1a!config/selector/v1/selector.proto\x1a!config/transport/tls/v1/tls.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\"\xf1\x04\n" +
+	"#config/transport/http/v1/http.proto\x12$runtime.api.config.transport.http.v1\x1a$config/middleware/cors/v1/cors.proto\x1a!config/selector/v1/selector.proto\x1a!config/transport/tls/v1/tls.proto\x1a$gnostic/openapi/v3/annotations.proto\x1a\x1egoogle/protobuf/duration.proto\"\xf0\x05\n" +
 	"\x06Server\x12X\n" +
 	"\x04addr\x18\x01 \x01(\tBD\xbaGA\x92\x02>The address for the server to listen on, e.g., \"0.0.0.0:8000\".R\x04addr\x12X\n" +
 	"\atimeout\x18\x02 \x01(\v2\x19.google.protobuf.DurationB#\xbaG \x92\x02\x1dThe request handling timeout.R\atimeout\x12_\n" +
@@ -245,7 +275,11 @@ const file_config_transport_http_v1_http_proto_rawDesc = "" +
 	"tls_config\x88\x01\x01\x12N\n" +
 	"\anetwork\x18\x05 \x01(\tB4\xbaG1\x92\x02.The network type, e.g., \"tcp\", \"tcp4\", \"tcp6\".R\anetwork\x12D\n" +
 	"\x04cors\x18\x06 \x01(\v2+.runtime.api.config.middleware.cors.v1.CorsH\x01R\x04cors\x88\x01\x01\x12!\n" +
-	"\fenable_pprof\x18\a \x01(\bR\venablePprofB\r\n" +
+	"\fenable_pprof\x18\a \x01(\bR\venablePprof\x12*\n" +
+	"\x11enable_log_buffer\x18\b \x01(\bR\x0fenableLogBuffer\x12%\n" +
+	"\x0eenable_metrics\x18\t \x01(\bR\renableMetrics\x12*\n" +
+	"\x11enable_log_levels\x18\n" +
+	" \x01(\bR\x0fenableLogLevelsB\r\n" +
 	"\v_tls_configB\a\n" +
 	"\x05_cors\"\x89\x04\n" +
 	"\x06Client\x12\x1a\n" +
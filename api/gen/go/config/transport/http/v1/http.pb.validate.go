@@ -91,6 +91,12 @@ func (m *Server) validate(all bool) error {
 
 	// no validation rules for EnablePprof
 
+	// no validation rules for EnableLogBuffer
+
+	// no validation rules for EnableMetrics
+
+	// no validation rules for EnableLogLevels
+
 	if m.TlsConfig != nil {
 
 		if all {
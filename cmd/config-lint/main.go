@@ -0,0 +1,186 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Command config-lint checks configuration files against a protobuf message without
+// starting the service, so that broken configurations can be rejected in CI.
+//
+// Usage:
+//
+//	config-lint -message runtime.api.config.transport.v1.Servers configs/servers.yaml
+//	config-lint -bootstrap -message example.v1.Config -descriptor-set config.binpb bootstrap.yaml
+//
+// The messages of this module are always known. Messages of other modules are loaded
+// from a FileDescriptorSet including its imports, as built by
+// `buf build -o config.binpb` or `protoc --include_imports --descriptor_set_out`.
+//
+// The exit status is 0 when the configuration is valid, 1 when problems were found and
+// 2 when the configuration could not be loaded.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	_ "github.com/origadmin/runtime/api/gen/go/config/app/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/bootstrap/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/kafka/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/mqtt/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/nats/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/nsq/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/pulsar/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/rabbitmq/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/redis_mq/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/rocketmq/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/sqs/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/stomp/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/broker/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/common/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/config/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/data/cache/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/data/database/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/data/oss/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/data/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/discovery/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/mail/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/circuitbreaker/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/cors/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/optimize/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/middleware/validator/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/selector/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/task/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/trace/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/grpc/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/http/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/tls/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/watermill/v1"
+	_ "github.com/origadmin/runtime/api/gen/go/config/transport/websocket/v1"
+	"github.com/origadmin/runtime/config/lint"
+	"github.com/origadmin/runtime/contracts/options"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:]))
+}
+
+func run(args []string) int {
+	fs := flag.NewFlagSet("config-lint", flag.ContinueOnError)
+	message := fs.String("message", "", "fully qualified name of the message to check against")
+	bootstrap := fs.Bool("bootstrap", false, "treat the file as a bootstrap file and check the sources it lists")
+	descriptorSet := fs.String("descriptor-set", "", "FileDescriptorSet with the definition of the message")
+	var prefixes prefixList
+	fs.Var(&prefixes, "env-prefix", "load environment variables with this prefix, may be repeated or comma separated")
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "usage: config-lint -message NAME [flags] FILE")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if *message == "" || fs.NArg() != 1 {
+		fs.Usage()
+		return 2
+	}
+
+	md, err := findMessage(*message, *descriptorSet)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config-lint:", err)
+		return 2
+	}
+	var opts []options.Option
+	if *bootstrap {
+		opts = append(opts, lint.WithBootstrap())
+	}
+	if len(prefixes) > 0 {
+		opts = append(opts, lint.WithEnvPrefixes(prefixes...))
+	}
+	diags, err := lint.Lint(fs.Arg(0), md, opts...)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "config-lint:", err)
+		return 2
+	}
+	wd, _ := os.Getwd()
+	for _, d := range diags {
+		if rel, err := filepath.Rel(wd, d.Position.File); err == nil && !strings.HasPrefix(rel, "..") {
+			d.Position.File = rel
+		}
+		fmt.Println(d)
+	}
+	if len(diags) > 0 {
+		return 1
+	}
+	return 0
+}
+
+// findMessage looks the message up in the descriptor set, when one is given, and in
+// the messages linked into the command otherwise.
+func findMessage(name, descriptorSet string) (protoreflect.MessageDescriptor, error) {
+	var resolver interface {
+		FindDescriptorByName(protoreflect.FullName) (protoreflect.Descriptor, error)
+	} = protoregistry.GlobalFiles
+	if descriptorSet != "" {
+		files, err := loadDescriptorSet(descriptorSet)
+		if err != nil {
+			return nil, err
+		}
+		resolver = files
+	}
+	d, err := resolver.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return nil, fmt.Errorf("message %s: %w", name, err)
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("%s is not a message", name)
+	}
+	return md, nil
+}
+
+func loadDescriptorSet(path string) (*protoregistry.Files, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var set descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(content, &set); err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s: %w", path, err)
+	}
+	files, err := protodesc.NewFiles(&set)
+	if err != nil {
+		return nil, fmt.Errorf("invalid descriptor set %s: %w", path, err)
+	}
+	return files, nil
+}
+
+// prefixList collects the values of a flag that may be repeated or comma separated.
+type prefixList []string
+
+func (p *prefixList) String() string {
+	return strings.Join(*p, ",")
+}
+
+func (p *prefixList) Set(value string) error {
+	for _, prefix := range strings.Split(value, ",") {
+		if prefix = strings.TrimSpace(prefix); prefix != "" {
+			*p = append(*p, prefix)
+		}
+	}
+	return nil
+}
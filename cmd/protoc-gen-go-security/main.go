@@ -0,0 +1,56 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Command protoc-gen-go-security is a protoc and buf plugin that registers the security
+// policies of the methods with the declarative security middleware.
+//
+// For every file with services, the methods annotated with a policy are registered by
+// the init function of a _security.pb.go file next to the code of protoc-gen-go:
+//
+//	import "security/policy.proto";
+//
+//	rpc GetUser(GetUserRequest) returns (User) {
+//	  option (runtime.security.policy) = "authenticated";
+//	  option (google.api.http) = {get: "/api/v1/users/{id}"};
+//	}
+//
+// Each method is registered with its gRPC full method name, the HTTP method and path of
+// each of its google.api.http bindings, and a hash of its policy as version:
+//
+//	plugins:
+//	  - local: protoc-gen-go-security
+//	    out: ./api/gen/go
+//	    opt: paths=source_relative
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+)
+
+// version is the version of the plugin, written in the generated files.
+const version = "v0.1.0"
+
+func main() {
+	var flags flag.FlagSet
+	showVersion := flag.Bool("version", false, "print the version and exit")
+	flag.Parse()
+	if *showVersion {
+		fmt.Printf("protoc-gen-go-security %s\n", version)
+		return
+	}
+
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		for _, f := range gen.Files {
+			if f.Generate {
+				generateFile(gen, f)
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,134 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+
+	securitypb "github.com/origadmin/runtime/api/gen/go/security"
+)
+
+// securityPackage is the package of the policy registry.
+const securityPackage = protogen.GoImportPath("github.com/origadmin/runtime/security")
+
+// policy is the registration of the policy of a method for one of its HTTP bindings.
+type policy struct {
+	serviceMethod string
+	gatewayPath   string
+	name          string
+}
+
+// generateFile generates the _security.pb.go file of f, nothing when none of its methods
+// has a policy.
+func generateFile(gen *protogen.Plugin, f *protogen.File) *protogen.GeneratedFile {
+	var policies []policy
+	for _, service := range f.Services {
+		for _, method := range service.Methods {
+			policies = append(policies, methodPolicies(method)...)
+		}
+	}
+	if len(policies) == 0 {
+		return nil
+	}
+
+	g := gen.NewGeneratedFile(f.GeneratedFilenamePrefix+"_security.pb.go", f.GoImportPath)
+	g.P("// Code generated by protoc-gen-go-security. DO NOT EDIT.")
+	g.P("// versions:")
+	g.P("// - protoc-gen-go-security ", version)
+	g.P("// - protoc                 ", protocVersion(gen))
+	if f.Proto.GetOptions().GetDeprecated() {
+		g.P("// ", f.Desc.Path(), " is a deprecated file.")
+	} else {
+		g.P("// source: ", f.Desc.Path())
+	}
+	g.P()
+	g.P("package ", f.GoPackageName)
+	g.P()
+	g.P("func init() {")
+	g.P(g.QualifiedGoIdent(securityPackage.Ident("RegisterPolicies")), "([]", g.QualifiedGoIdent(securityPackage.Ident("Policy")), "{")
+	for _, p := range policies {
+		g.P("{")
+		g.P("ServiceMethod: ", fmt.Sprintf("%q", p.serviceMethod), ",")
+		g.P("GatewayPath: ", fmt.Sprintf("%q", p.gatewayPath), ",")
+		g.P("Name: ", fmt.Sprintf("%q", p.name), ",")
+		g.P("VersionID: ", fmt.Sprintf("%q", p.versionID()), ",")
+		g.P("},")
+	}
+	g.P("})")
+	g.P("}")
+	return g
+}
+
+// methodPolicies returns the registrations of the policy of method, one per HTTP binding,
+// none without a policy.
+func methodPolicies(method *protogen.Method) []policy {
+	name, _ := proto.GetExtension(method.Desc.Options(), securitypb.E_Policy).(string)
+	if name == "" {
+		return nil
+	}
+	serviceMethod := fmt.Sprintf("/%s/%s", method.Parent.Desc.FullName(), method.Desc.Name())
+	rule, _ := proto.GetExtension(method.Desc.Options(), annotations.E_Http).(*annotations.HttpRule)
+	if rule == nil {
+		return []policy{{serviceMethod: serviceMethod, name: name}}
+	}
+	var policies []policy
+	for _, r := range append([]*annotations.HttpRule{rule}, rule.GetAdditionalBindings()...) {
+		if path := gatewayPath(r); path != "" {
+			policies = append(policies, policy{serviceMethod: serviceMethod, gatewayPath: path, name: name})
+		}
+	}
+	if len(policies) == 0 {
+		policies = append(policies, policy{serviceMethod: serviceMethod, name: name})
+	}
+	return policies
+}
+
+// gatewayPath returns the HTTP method and path of rule, such as GET:/api/v1/users/{id},
+// empty when it has no pattern.
+func gatewayPath(rule *annotations.HttpRule) string {
+	switch pattern := rule.GetPattern().(type) {
+	case *annotations.HttpRule_Get:
+		return "GET:" + pattern.Get
+	case *annotations.HttpRule_Put:
+		return "PUT:" + pattern.Put
+	case *annotations.HttpRule_Post:
+		return "POST:" + pattern.Post
+	case *annotations.HttpRule_Delete:
+		return "DELETE:" + pattern.Delete
+	case *annotations.HttpRule_Patch:
+		return "PATCH:" + pattern.Patch
+	case *annotations.HttpRule_Custom:
+		return strings.ToUpper(pattern.Custom.GetKind()) + ":" + pattern.Custom.GetPath()
+	}
+	return ""
+}
+
+// versionID returns the hash of the definition of p, changing with its method, binding
+// or policy only.
+func (p policy) versionID() string {
+	sum := sha256.Sum256([]byte(p.serviceMethod + "\n" + p.gatewayPath + "\n" + p.name))
+	return hex.EncodeToString(sum[:8])
+}
+
+// protocVersion returns the version of the compiler of gen, (unknown) when it is not
+// given.
+func protocVersion(gen *protogen.Plugin) string {
+	v := gen.Request.GetCompilerVersion()
+	if v == nil {
+		return "(unknown)"
+	}
+	var suffix string
+	if s := v.GetSuffix(); s != "" {
+		suffix = "-" + s
+	}
+	return fmt.Sprintf("v%d.%d.%d%s", v.GetMajor(), v.GetMinor(), v.GetPatch(), suffix)
+}
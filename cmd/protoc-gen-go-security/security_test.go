@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/bufbuild/protocompile"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	_ "google.golang.org/genproto/googleapis/api/annotations"
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	_ "github.com/origadmin/runtime/api/gen/go/security"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+// generate runs the plugin on the proto files of testdata, returning the generated files.
+func generate(t *testing.T, files ...string) []*pluginpb.CodeGeneratorResponse_File {
+	t.Helper()
+	compiler := protocompile.Compiler{
+		Resolver: protocompile.CompositeResolver{
+			&protocompile.SourceResolver{ImportPaths: []string{"testdata"}},
+			// The imported files are those linked in the test.
+			protocompile.ResolverFunc(func(path string) (protocompile.SearchResult, error) {
+				fd, err := protoregistry.GlobalFiles.FindFileByPath(path)
+				if err != nil {
+					return protocompile.SearchResult{}, err
+				}
+				return protocompile.SearchResult{Desc: fd}, nil
+			}),
+		},
+	}
+	compiled, err := compiler.Compile(context.Background(), files...)
+	require.NoError(t, err)
+
+	req := &pluginpb.CodeGeneratorRequest{FileToGenerate: files, Parameter: proto.String("paths=source_relative")}
+	seen := make(map[string]bool)
+	var add func(fd protoreflect.FileDescriptor)
+	add = func(fd protoreflect.FileDescriptor) {
+		if seen[fd.Path()] {
+			return
+		}
+		seen[fd.Path()] = true
+		for i := 0; i < fd.Imports().Len(); i++ {
+			add(fd.Imports().Get(i).FileDescriptor)
+		}
+		req.ProtoFile = append(req.ProtoFile, protodesc.ToFileDescriptorProto(fd))
+	}
+	for _, fd := range compiled {
+		add(fd)
+	}
+	// Decode the request as the plugin does, resolving the options with the linked types.
+	data, err := proto.Marshal(req)
+	require.NoError(t, err)
+	req = &pluginpb.CodeGeneratorRequest{}
+	require.NoError(t, proto.Unmarshal(data, req))
+
+	gen, err := protogen.Options{}.New(req)
+	require.NoError(t, err)
+	for _, f := range gen.Files {
+		if f.Generate {
+			generateFile(gen, f)
+		}
+	}
+	resp := gen.Response()
+	require.Empty(t, resp.GetError())
+	return resp.GetFile()
+}
+
+func TestGenerate(t *testing.T) {
+	files := generate(t, "example.proto")
+	require.Len(t, files, 1)
+	assert.Equal(t, "example_security.pb.go", files[0].GetName())
+
+	golden := filepath.Join("testdata", "example_security.pb.go.golden")
+	if *update {
+		require.NoError(t, os.WriteFile(golden, []byte(files[0].GetContent()), 0o644))
+	}
+	want, err := os.ReadFile(golden)
+	require.NoError(t, err)
+	assert.Equal(t, string(want), files[0].GetContent())
+}
+
+func TestGenerate_NoPolicy(t *testing.T) {
+	assert.Empty(t, generate(t, "empty.proto"))
+}
+
+func TestVersionID(t *testing.T) {
+	p := policy{serviceMethod: "/example.v1.Users/GetUser", gatewayPath: "GET:/api/v1/users/{id}", name: "authenticated"}
+	assert.Equal(t, p.versionID(), p.versionID())
+	assert.Len(t, p.versionID(), 16)
+
+	renamed := p
+	renamed.name = "admin-only"
+	assert.NotEqual(t, p.versionID(), renamed.versionID())
+	rebound := p
+	rebound.gatewayPath = "GET:/api/v1/me"
+	assert.NotEqual(t, p.versionID(), rebound.versionID())
+}
@@ -0,0 +1,97 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Command protoc-gen-jsonschema is a protoc and buf plugin that writes the JSON Schema of
+// configuration messages, for editors and YAML language servers.
+//
+// For every file to generate, a schema is written next to the proto file for each of its
+// top-level messages, or only for the messages named by the message option:
+//
+//	plugins:
+//	  - local: protoc-gen-jsonschema
+//	    out: ./api/gen/jsonschema
+//	    opt:
+//	      - message=runtime.api.config.bootstrap.v1.Bootstrap
+//
+// Options:
+//
+//	message=NAME                 generate the schema of the message NAME, may be repeated
+//	proto_names=true             name properties after the proto field names
+//	additional_properties=true   allow properties that are not fields of the message
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path"
+	"strings"
+
+	"google.golang.org/protobuf/compiler/protogen"
+	"google.golang.org/protobuf/types/pluginpb"
+
+	"github.com/origadmin/runtime/config/jsonschema"
+	"github.com/origadmin/runtime/contracts/options"
+)
+
+func main() {
+	var flags flag.FlagSet
+	var messages messageList
+	flags.Var(&messages, "message", "full name of a message to generate the schema of")
+	protoNames := flags.Bool("proto_names", false, "name properties after the proto field names")
+	additional := flags.Bool("additional_properties", false, "allow properties that are not fields of the message")
+
+	protogen.Options{ParamFunc: flags.Set}.Run(func(gen *protogen.Plugin) error {
+		gen.SupportedFeatures = uint64(pluginpb.CodeGeneratorResponse_FEATURE_PROTO3_OPTIONAL)
+		var opts []options.Option
+		if *protoNames {
+			opts = append(opts, jsonschema.WithProtoNames())
+		}
+		if *additional {
+			opts = append(opts, jsonschema.WithAdditionalProperties())
+		}
+		wanted := make(map[string]bool, len(messages))
+		for _, name := range messages {
+			wanted[name] = false
+		}
+		for _, f := range gen.Files {
+			if !f.Generate && len(wanted) == 0 {
+				continue
+			}
+			for _, m := range f.Messages {
+				name := string(m.Desc.FullName())
+				if len(wanted) > 0 {
+					if _, ok := wanted[name]; !ok {
+						continue
+					}
+					wanted[name] = true
+				}
+				content, err := jsonschema.Marshal(m.Desc, opts...)
+				if err != nil {
+					return err
+				}
+				filename := path.Join(path.Dir(f.Desc.Path()), string(m.Desc.Name())+".schema.json")
+				g := gen.NewGeneratedFile(filename, "")
+				g.P(string(content))
+			}
+		}
+		for name, found := range wanted {
+			if !found {
+				return fmt.Errorf("message %s not found", name)
+			}
+		}
+		return nil
+	})
+}
+
+// messageList collects the values of a repeated option.
+type messageList []string
+
+func (m *messageList) String() string {
+	return strings.Join(*m, ",")
+}
+
+func (m *messageList) Set(value string) error {
+	*m = append(*m, value)
+	return nil
+}
@@ -7,6 +7,7 @@ import (
 	kratosconfig "github.com/go-kratos/kratos/v2/config"
 
 	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	"github.com/origadmin/runtime/config/migration"
 	"github.com/origadmin/runtime/contracts/builder"
 	"github.com/origadmin/runtime/contracts/options"
 	internalfactory "github.com/origadmin/runtime/helpers/builder"
@@ -34,6 +35,14 @@ func (b *Builder) NewConfig(srcs *sourcev1.Sources, opts ...options.Option) (KCo
 	fromOptions := FromOptions(opts...)
 	var sources []kratosconfig.Source
 
+	registry := fromOptions.Migrations
+	if registry == nil {
+		registry = migration.Default()
+	}
+	if version := srcs.GetVersion(); version != "" && !registry.Known(version) {
+		logger.Warnf("No config migrations are registered for version %s", version)
+	}
+
 	// Get the list of sources from the protobuf config.
 	sourceConfigs := srcs.GetConfigs()
 
@@ -61,6 +70,11 @@ func (b *Builder) NewConfig(srcs *sourcev1.Sources, opts ...options.Option) (KCo
 		if source == nil {
 			return nil, fmt.Errorf("config source factory for type '%s' returned a nil source", src.Type)
 		}
+		if SourceType(src.Type) == SourceTypeFile {
+			// Only the files are written in the version of the sources, migrate them before
+			// they are merged with the other sources.
+			source = newMigratingSource(source, registry, srcs.GetVersion(), logger)
+		}
 		logger.Infof("Created source: %s with priority: %d", src.Type, src.Priority)
 		sources = append(sources, source)
 	}
@@ -68,7 +82,10 @@ func (b *Builder) NewConfig(srcs *sourcev1.Sources, opts ...options.Option) (KCo
 		sources = append(sources, fromOptions.Sources...)
 		logger.Infof("Added %d sources from options", len(fromOptions.Sources))
 	}
-	fromOptions.ConfigOptions = append(fromOptions.ConfigOptions, kratosconfig.WithSource(sources...))
+	// The list-aware merge goes first so that an explicit merge option can still replace it.
+	configOptions := []KOption{WithKMergeFunc(Merge)}
+	configOptions = append(configOptions, fromOptions.ConfigOptions...)
+	fromOptions.ConfigOptions = append(configOptions, kratosconfig.WithSource(sources...))
 
 	// Create the underlying Kratos config directly
 	kc := kratosconfig.New(fromOptions.ConfigOptions...)
@@ -97,6 +114,8 @@ func GetSourceFactory(name string) (SourceFactory, bool) {
 // getDefaultPriorityForSourceType returns a default priority based on the source type.
 func getDefaultPriorityForSourceType(sourceType string) int32 {
 	switch SourceType(sourceType) {
+	case SourceTypeFlags:
+		return 1000 // Command-line flags
 	case SourceTypeEnv:
 		return 900 // Environment variables
 	case SourceTypeFile:
@@ -109,6 +128,7 @@ func getDefaultPriorityForSourceType(sourceType string) int32 {
 type SourceType string
 
 const (
-	SourceTypeFile SourceType = "file"
-	SourceTypeEnv  SourceType = "env"
+	SourceTypeFile  SourceType = "file"
+	SourceTypeEnv   SourceType = "env"
+	SourceTypeFlags SourceType = "flags"
 )
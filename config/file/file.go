@@ -6,6 +6,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	kratosconfig "github.com/go-kratos/kratos/v2/config"
 
@@ -37,6 +38,10 @@ type file struct {
 	ignores   []string
 	formatter Formatter
 	optional  bool
+	override  bool
+
+	mu       sync.Mutex
+	included []string
 }
 
 // NewSource creates a new file source instance
@@ -90,36 +95,20 @@ func (f *file) shouldIgnore(filename string) bool {
 	return false
 }
 
-// loadDir loads all non-ignored files from the specified directory
+// loadDir loads all non-ignored files from the specified directory in lexical order
 func (f *file) loadDir(path string) (kvs []*kratosconfig.KeyValue, err error) {
-	files, err := os.ReadDir(path)
+	files, err := f.dirFiles(path, f.filter)
 	if err != nil {
 		return nil, err
 	}
+	l := newLoader(f)
 	for _, file := range files {
-		// ignore hidden files
-		if file.IsDir() || strings.HasPrefix(file.Name(), ".") || f.shouldIgnore(file.Name()) {
-			continue
-		}
-
-		// Apply filter if specified
-		if f.filter != "" {
-			matched, err := filepath.Match(f.filter, file.Name())
-			if err != nil {
-				return nil, err
-			}
-			if !matched {
-				continue
-			}
-		}
-
-		kv, err := f.loadFile(filepath.Join(path, file.Name()))
-		if err != nil {
+		if err := l.load(file, nil); err != nil {
 			return nil, err
 		}
-		kvs = append(kvs, kv)
 	}
-	return
+	f.setIncluded(l.files)
+	return l.kvs, nil
 }
 
 // Load loads configuration data from the file source
@@ -140,18 +129,39 @@ func (f *file) Load() (kvs []*kratosconfig.KeyValue, err error) {
 	if f.shouldIgnore(fi.Name()) {
 		return nil, nil
 	}
-	kv, err := f.loadFile(f.path)
-	if err != nil {
+	l := newLoader(f)
+	if err := l.load(f.path, nil); err != nil {
 		if f.optional && (os.IsNotExist(err) || os.IsPermission(err)) {
 			return []*kratosconfig.KeyValue{}, nil
 		}
 		return nil, err
 	}
+	f.setIncluded(l.files)
 
-	if kv == nil {
+	if len(l.kvs) == 0 {
 		return []*kratosconfig.KeyValue{}, nil
 	}
-	return []*kratosconfig.KeyValue{kv}, nil
+	return l.kvs, nil
+}
+
+// setIncluded records the files loaded by the last Load, so the watcher can follow includes.
+func (f *file) setIncluded(files []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.included = files
+}
+
+// Files returns the absolute paths of the files loaded by the last Load, in merge order,
+// including the files loaded through include directives.
+func (f *file) Files() []string {
+	return f.includedFiles()
+}
+
+// includedFiles returns the files loaded by the last Load.
+func (f *file) includedFiles() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.included
 }
 
 // Watch creates and returns a file watcher instance
@@ -186,6 +196,9 @@ func NewFileSource(cfg *sourcev1.SourceConfig, opts ...options.Option) (kratosco
 	if ignores := fileSrc.GetIgnores(); len(ignores) > 0 {
 		opts = append(opts, WithIgnores(ignores...))
 	}
+	if fileSrc.GetOverride() {
+		opts = append(opts, WithOverride())
+	}
 
 	return NewSource(fileSrc.GetPath(), opts...), nil
 }
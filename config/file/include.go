@@ -0,0 +1,297 @@
+package file
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/go-kratos/kratos/v2/encoding"
+)
+
+// IncludeKey is the top-level key of a configuration file that lists other files to load
+// before it. Paths are relative to the including file and may contain glob patterns.
+const IncludeKey = "include"
+
+// loader loads the files of a source in a deterministic order, expands include
+// directives and reports keys defined by more than one file.
+type loader struct {
+	f *file
+	// kvs holds the loaded files in merge order, included files come before the file
+	// including them.
+	kvs []*kratosconfig.KeyValue
+	// files holds the absolute paths of all loaded files.
+	files []string
+	// includes holds, for each loaded file, the files it includes directly or transitively.
+	includes map[string]map[string]bool
+	// leaves and nodes map a dotted key to the file that defined it first.
+	leaves map[string]string
+	nodes  map[string]string
+}
+
+func newLoader(f *file) *loader {
+	return &loader{
+		f:        f,
+		includes: make(map[string]map[string]bool),
+		leaves:   make(map[string]string),
+		nodes:    make(map[string]string),
+	}
+}
+
+// dirFiles returns the loadable files of a directory in lexical order.
+func (f *file) dirFiles(path string, filter string) ([]string, error) {
+	entries, err := os.ReadDir(path)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, entry := range entries {
+		// ignore hidden files
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || f.shouldIgnore(entry.Name()) {
+			continue
+		}
+		// Apply filter if specified
+		if filter != "" {
+			matched, err := filepath.Match(filter, entry.Name())
+			if err != nil {
+				return nil, err
+			}
+			if !matched {
+				continue
+			}
+		}
+		files = append(files, filepath.Join(path, entry.Name()))
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// load loads a file and, before it, the files it includes. stack holds the chain of
+// including files and is used to detect include cycles.
+func (l *loader) load(path string, stack []string) error {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	for i, p := range stack {
+		if p == abs {
+			chain := append(append([]string(nil), stack[i:]...), abs)
+			return fmt.Errorf("config file include cycle: %s", strings.Join(chain, " -> "))
+		}
+	}
+	if _, ok := l.includes[abs]; ok {
+		// Already loaded through another include.
+		return nil
+	}
+
+	kv, err := l.f.loadFile(path)
+	if err != nil {
+		return err
+	}
+	if kv == nil {
+		l.includes[abs] = map[string]bool{}
+		return nil
+	}
+	data, ok, err := decode(kv)
+	if err != nil {
+		return fmt.Errorf("failed to decode config file %s: %w", path, err)
+	}
+	closure := make(map[string]bool)
+	if ok {
+		patterns, err := includePatterns(data)
+		if err != nil {
+			return fmt.Errorf("invalid %s in config file %s: %w", IncludeKey, path, err)
+		}
+		if patterns != nil {
+			delete(data, IncludeKey)
+			// The file is re-encoded without the directive, so that it does not end up
+			// in the configuration.
+			value, err := json.Marshal(data)
+			if err != nil {
+				return fmt.Errorf("failed to encode config file %s: %w", path, err)
+			}
+			kv = &kratosconfig.KeyValue{Key: kv.Key, Format: "json", Value: value}
+		}
+		included, err := l.expand(filepath.Dir(path), patterns)
+		if err != nil {
+			return fmt.Errorf("config file %s: %w", path, err)
+		}
+		next := append(append([]string(nil), stack...), abs)
+		for _, inc := range included {
+			if err := l.load(inc, next); err != nil {
+				return err
+			}
+			incAbs, _ := filepath.Abs(inc)
+			closure[incAbs] = true
+			for p := range l.includes[incAbs] {
+				closure[p] = true
+			}
+		}
+	}
+	l.includes[abs] = closure
+	if ok {
+		if err := l.check(abs, "", data); err != nil {
+			return err
+		}
+	}
+	l.kvs = append(l.kvs, kv)
+	l.files = append(l.files, abs)
+	return nil
+}
+
+// expand resolves include patterns relative to dir. A pattern without glob characters
+// must match an existing file, a glob pattern may match nothing. Directories are
+// expanded to their files.
+func (l *loader) expand(dir string, patterns []string) ([]string, error) {
+	var files []string
+	for _, pattern := range patterns {
+		if !filepath.IsAbs(pattern) {
+			pattern = filepath.Join(dir, pattern)
+		}
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid include pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 && !hasMeta(pattern) {
+			return nil, fmt.Errorf("included file %s does not exist", pattern)
+		}
+		sort.Strings(matches)
+		for _, match := range matches {
+			fi, err := os.Stat(match)
+			if err != nil {
+				return nil, err
+			}
+			if fi.IsDir() {
+				dirFiles, err := l.f.dirFiles(match, "")
+				if err != nil {
+					return nil, err
+				}
+				files = append(files, dirFiles...)
+				continue
+			}
+			name := filepath.Base(match)
+			if strings.HasPrefix(name, ".") || l.f.shouldIgnore(name) {
+				continue
+			}
+			files = append(files, match)
+		}
+	}
+	return files, nil
+}
+
+// check records the keys of data as defined by file and reports keys that another
+// file, not included by this one, already defined.
+func (l *loader) check(file, prefix string, data map[string]any) error {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := data[key]
+		path := key
+		if prefix != "" {
+			path = prefix + "." + key
+		}
+		if child, ok := value.(map[string]any); ok && len(child) > 0 {
+			if owner, ok := l.leaves[path]; ok && !l.overrides(file, owner) {
+				return l.conflict(path, owner, file)
+			}
+			delete(l.leaves, path)
+			if _, ok := l.nodes[path]; !ok {
+				l.nodes[path] = file
+			}
+			if err := l.check(file, path, child); err != nil {
+				return err
+			}
+			continue
+		}
+		if owner, ok := l.leaves[path]; ok && !l.overrides(file, owner) {
+			return l.conflict(path, owner, file)
+		}
+		if owner, ok := l.nodes[path]; ok && !l.overrides(file, owner) {
+			return l.conflict(path, owner, file)
+		}
+		l.leaves[path] = file
+	}
+	return nil
+}
+
+// overrides reports whether file may replace a key defined by owner.
+func (l *loader) overrides(file, owner string) bool {
+	return l.f.override || file == owner || l.includes[file][owner]
+}
+
+func (l *loader) conflict(key, owner, file string) error {
+	return fmt.Errorf("duplicate config key %q defined in %s and %s", key, owner, file)
+}
+
+// decode decodes a key value into a map with the codec of its format. It returns false
+// when no codec is registered for the format, in which case the file is loaded as is.
+func decode(kv *kratosconfig.KeyValue) (map[string]any, bool, error) {
+	codec := encoding.GetCodec(kv.Format)
+	if codec == nil {
+		return nil, false, nil
+	}
+	data := make(map[string]any)
+	if err := codec.Unmarshal(kv.Value, &data); err != nil {
+		return nil, false, err
+	}
+	return normalize(data).(map[string]any), true, nil
+}
+
+// normalize converts map[any]any values, produced by some decoders, into map[string]any.
+func normalize(v any) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for k, item := range value {
+			value[k] = normalize(item)
+		}
+		return value
+	case map[any]any:
+		m := make(map[string]any, len(value))
+		for k, item := range value {
+			m[fmt.Sprint(k)] = normalize(item)
+		}
+		return m
+	case []any:
+		for i, item := range value {
+			value[i] = normalize(item)
+		}
+		return value
+	default:
+		return v
+	}
+}
+
+// includePatterns returns the include directive of a file, which is either a single
+// pattern or a list of patterns.
+func includePatterns(data map[string]any) ([]string, error) {
+	value, ok := data[IncludeKey]
+	if !ok {
+		return nil, nil
+	}
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case []any:
+		patterns := make([]string, 0, len(v))
+		for _, item := range v {
+			s, ok := item.(string)
+			if !ok {
+				return nil, fmt.Errorf("expected a string, got %T", item)
+			}
+			patterns = append(patterns, s)
+		}
+		return patterns, nil
+	default:
+		return nil, fmt.Errorf("expected a string or a list of strings, got %T", value)
+	}
+}
+
+func hasMeta(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
@@ -0,0 +1,142 @@
+package file
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o666))
+	}
+}
+
+func keys(kvs []*kratosconfig.KeyValue) []string {
+	names := make([]string, 0, len(kvs))
+	for _, kv := range kvs {
+		names = append(names, kv.Key)
+	}
+	return names
+}
+
+func TestLoadDirLexicalOrder(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"servers.yaml":     "servers:\n  addr: :8000\n",
+		"data.yaml":        "data:\n  driver: mysql\n",
+		"middlewares.yaml": "middlewares:\n  - logging\n",
+	})
+
+	kvs, err := NewSource(dir).Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data.yaml", "middlewares.yaml", "servers.yaml"}, keys(kvs))
+}
+
+func TestLoadDirConflict(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"a.yaml": "servers:\n  addr: :8000\n",
+		"b.yaml": "servers:\n  addr: :9000\n",
+	})
+
+	_, err := NewSource(dir).Load()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"servers.addr"`)
+	assert.Contains(t, err.Error(), "a.yaml")
+	assert.Contains(t, err.Error(), "b.yaml")
+
+	kvs, err := NewSource(dir, WithOverride()).Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.yaml", "b.yaml"}, keys(kvs))
+}
+
+func TestLoadInclude(t *testing.T) {
+	dir := t.TempDir()
+	writeFiles(t, dir, map[string]string{
+		"config.yaml":         "include:\n  - conf.d/*.yaml\n  - base.yaml\nservers:\n  addr: :9000\n",
+		"base.yaml":           "servers:\n  addr: :8000\n  timeout: 1s\n",
+		"conf.d/data.yaml":    "data:\n  driver: mysql\n",
+		"conf.d/logger.yaml":  "logger:\n  level: info\n",
+		"conf.d/ignored.json": "{}",
+	})
+
+	kvs, err := NewSource(filepath.Join(dir, "config.yaml")).Load()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"data.yaml", "logger.yaml", "base.yaml", "config.yaml"}, keys(kvs))
+
+	// The including file is re-encoded without the directive and overrides its includes.
+	last := kvs[len(kvs)-1]
+	assert.Equal(t, "json", last.Format)
+	assert.JSONEq(t, `{"servers":{"addr":":9000"}}`, string(last.Value))
+
+	c := kratosconfig.New(kratosconfig.WithSource(NewSource(filepath.Join(dir, "config.yaml"))))
+	require.NoError(t, c.Load())
+	defer c.Close()
+	addr, err := c.Value("servers.addr").String()
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", addr)
+	timeout, err := c.Value("servers.timeout").String()
+	require.NoError(t, err)
+	assert.Equal(t, "1s", timeout)
+	_, err = c.Value("include").String()
+	assert.Error(t, err)
+}
+
+func TestLoadIncludeErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		contains string
+	}{
+		{
+			name: "cycle",
+			files: map[string]string{
+				"config.yaml": "include: a.yaml\n",
+				"a.yaml":      "include: b.yaml\n",
+				"b.yaml":      "include: a.yaml\n",
+			},
+			contains: "include cycle",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"config.yaml": "include: missing.yaml\n",
+			},
+			contains: "does not exist",
+		},
+		{
+			name: "invalid directive",
+			files: map[string]string{
+				"config.yaml": "include:\n  file: a.yaml\n",
+			},
+			contains: "expected a string or a list of strings",
+		},
+		{
+			name: "conflict between includes",
+			files: map[string]string{
+				"config.yaml": "include: [a.yaml, b.yaml]\n",
+				"a.yaml":      "app:\n  name: a\n",
+				"b.yaml":      "app:\n  name: b\n",
+			},
+			contains: `duplicate config key "app.name"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			writeFiles(t, dir, tt.files)
+			_, err := NewSource(filepath.Join(dir, "config.yaml")).Load()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.contains)
+		})
+	}
+}
@@ -40,6 +40,14 @@ func WithFilter(filter string) options.Option {
 	})
 }
 
+// WithOverride lets files loaded by the same source override each other's keys in load
+// order, instead of failing with a duplicate-key conflict.
+func WithOverride() options.Option {
+	return optionutil.Update(func(o *file) {
+		o.override = true
+	})
+}
+
 // FromOptions extracts file options from the provided runtime options.
 // WithCond options is nil or no file options are found, it returns the original file.
 func applyFileOptions(f *file, opts ...options.Option) *file {
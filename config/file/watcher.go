@@ -53,7 +53,7 @@ func (w *watcher) Next() ([]*config.KeyValue, error) {
 		if w.f.shouldIgnore(fileName) {
 			return nil, nil
 		}
-		if w.f.filter != "" {
+		if w.f.filter != "" && !w.isIncluded(event.Name) {
 			matched, err := filepath.Match(w.f.filter, fileName)
 			if err != nil {
 				return nil, err
@@ -65,12 +65,43 @@ func (w *watcher) Next() ([]*config.KeyValue, error) {
 
 		// Trigger reload of the entire source
 		// Kratos config expects the full list of KVs from the source on each change
-		return w.f.Load()
+		kvs, err := w.f.Load()
+		if err != nil {
+			return nil, err
+		}
+		if err := w.watchIncluded(); err != nil {
+			return nil, err
+		}
+		return kvs, nil
 	case err := <-w.fw.Errors:
 		return nil, err
 	}
 }
 
+// watchIncluded adds the files loaded through include directives to the watch list.
+func (w *watcher) watchIncluded() error {
+	for _, path := range w.f.includedFiles() {
+		if err := w.fw.Add(path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isIncluded reports whether path was loaded through an include directive.
+func (w *watcher) isIncluded(path string) bool {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	for _, included := range w.f.includedFiles() {
+		if included == abs {
+			return true
+		}
+	}
+	return false
+}
+
 func (w *watcher) Stop() error {
 	w.cancel()
 	return w.fw.Close()
@@ -90,5 +121,11 @@ func newWatcher(f *file) (config.Watcher, error) {
 		return nil, err
 	}
 	ctx, cancel := context.WithCancel(context.Background())
-	return &watcher{f: f, fw: fw, ctx: ctx, cancel: cancel}, nil
+	w := &watcher{f: f, fw: fw, ctx: ctx, cancel: cancel}
+	if err := w.watchIncluded(); err != nil {
+		cancel()
+		_ = fw.Close()
+		return nil, err
+	}
+	return w, nil
 }
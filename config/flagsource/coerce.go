@@ -0,0 +1,274 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package flagsource is a configuration source that loads command-line flags.
+package flagsource
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	runtimeconfig "github.com/origadmin/runtime/config"
+)
+
+// coercer converts the raw text of a flag into the value stored in the configuration.
+type coercer func(raw string) (any, error)
+
+// set stores value at the dotted key path in data. List elements are addressed as
+// "name[index]" and become runtimeconfig.IndexKey patches. When md is not nil, the
+// path is validated against it and the value is converted to the type of the field.
+func set(data map[string]any, md protoreflect.MessageDescriptor, key, value string) error {
+	path, err := parsePath(key)
+	if err != nil {
+		return err
+	}
+	names, coerce, err := resolve(md, path)
+	if err != nil {
+		return fmt.Errorf("flagsource: %s: %w", key, err)
+	}
+	v, err := coerce(value)
+	if err != nil {
+		return fmt.Errorf("flagsource: %s: %w", key, err)
+	}
+	node := data
+	for _, name := range names[:len(names)-1] {
+		next, ok := node[name].(map[string]any)
+		if !ok {
+			next = make(map[string]any)
+			node[name] = next
+		}
+		node = next
+	}
+	node[names[len(names)-1]] = v
+	return nil
+}
+
+// segment is one element of a key path, either a field name or a list index.
+type segment struct {
+	name  string
+	index int
+}
+
+func (s segment) isIndex() bool {
+	return s.index >= 0
+}
+
+func (s segment) key() string {
+	if s.isIndex() {
+		return runtimeconfig.IndexKey(s.index)
+	}
+	return s.name
+}
+
+// parsePath splits "servers.configs[0].http.addr" into its segments.
+func parsePath(key string) ([]segment, error) {
+	var path []segment
+	for _, part := range strings.Split(key, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name == "" {
+			return nil, fmt.Errorf("flagsource: invalid key %q", key)
+		}
+		path = append(path, segment{name: name, index: -1})
+		for rest != "" {
+			idx, tail, ok := strings.Cut(rest, "]")
+			i, err := strconv.Atoi(idx)
+			if !ok || err != nil || i < 0 || (tail != "" && !strings.HasPrefix(tail, "[")) {
+				return nil, fmt.Errorf("flagsource: invalid index in key %q", key)
+			}
+			path = append(path, segment{index: i})
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return path, nil
+}
+
+// resolve walks the path through md and returns the map keys to write and the
+// conversion for the leaf value. Without a descriptor values are kept as strings.
+func resolve(md protoreflect.MessageDescriptor, path []segment) ([]string, coercer, error) {
+	names := make([]string, 0, len(path))
+	for i := 0; i < len(path); i++ {
+		seg := path[i]
+		if md == nil || isUntyped(md) {
+			names = append(names, seg.key())
+			continue
+		}
+		if seg.isIndex() {
+			return nil, nil, fmt.Errorf("%s is not a list", md.FullName())
+		}
+		fd := findField(md, seg.name)
+		if fd == nil {
+			return nil, nil, fmt.Errorf("unknown field %q in %s", seg.name, md.FullName())
+		}
+		names = append(names, string(fd.Name()))
+		last := i == len(path)-1
+		switch {
+		case fd.IsList():
+			if last {
+				return names, listCoercer(fd), nil
+			}
+			i++
+			if !path[i].isIndex() {
+				return nil, nil, fmt.Errorf("field %q is a list, an index is required", fd.Name())
+			}
+			names = append(names, path[i].key())
+			if i == len(path)-1 {
+				return names, fieldCoercer(fd), nil
+			}
+			if fd.Kind() != protoreflect.MessageKind {
+				return nil, nil, fmt.Errorf("field %q has no nested fields", fd.Name())
+			}
+			md = fd.Message()
+		case fd.IsMap():
+			if last {
+				return nil, nil, fmt.Errorf("field %q is a map, a key is required", fd.Name())
+			}
+			i++
+			if path[i].isIndex() {
+				return nil, nil, fmt.Errorf("field %q is a map, not a list", fd.Name())
+			}
+			names = append(names, path[i].name)
+			value := fd.MapValue()
+			if i == len(path)-1 {
+				return names, fieldCoercer(value), nil
+			}
+			if value.Kind() != protoreflect.MessageKind {
+				return nil, nil, fmt.Errorf("field %q has no nested fields", fd.Name())
+			}
+			md = value.Message()
+		case fd.Kind() == protoreflect.MessageKind:
+			if last {
+				return names, fieldCoercer(fd), nil
+			}
+			md = fd.Message()
+		default:
+			if !last {
+				return nil, nil, fmt.Errorf("field %q has no nested fields", fd.Name())
+			}
+			return names, fieldCoercer(fd), nil
+		}
+	}
+	return names, stringValue, nil
+}
+
+// findField looks a field up by its proto name or its JSON name.
+func findField(md protoreflect.MessageDescriptor, name string) protoreflect.FieldDescriptor {
+	fields := md.Fields()
+	if fd := fields.ByName(protoreflect.Name(name)); fd != nil {
+		return fd
+	}
+	if fd := fields.ByJSONName(name); fd != nil {
+		return fd
+	}
+	// Allow kebab-case keys, the usual spelling of flag names.
+	return fields.ByName(protoreflect.Name(strings.ReplaceAll(name, "-", "_")))
+}
+
+// isUntyped reports whether md holds free-form data that cannot be checked further.
+func isUntyped(md protoreflect.MessageDescriptor) bool {
+	switch md.FullName() {
+	case "google.protobuf.Struct", "google.protobuf.Value", "google.protobuf.ListValue", "google.protobuf.Any":
+		return true
+	}
+	return false
+}
+
+// listCoercer converts a comma separated value into a list of elements of fd.
+func listCoercer(fd protoreflect.FieldDescriptor) coercer {
+	elem := fieldCoercer(fd)
+	return func(raw string) (any, error) {
+		if raw == "" {
+			return []any{}, nil
+		}
+		parts := strings.Split(raw, ",")
+		list := make([]any, 0, len(parts))
+		for _, part := range parts {
+			v, err := elem(strings.TrimSpace(part))
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, v)
+		}
+		return list, nil
+	}
+}
+
+// fieldCoercer returns the conversion for a single value of fd.
+func fieldCoercer(fd protoreflect.FieldDescriptor) coercer {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return func(raw string) (any, error) {
+			return strconv.ParseBool(raw)
+		}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		return func(raw string) (any, error) {
+			return strconv.ParseInt(raw, 10, 32)
+		}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return func(raw string) (any, error) {
+			return strconv.ParseUint(raw, 10, 32)
+		}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// 64-bit integers are kept as strings, which protojson accepts, to avoid
+		// losing precision when the configuration is decoded into float64.
+		return func(raw string) (any, error) {
+			if _, err := strconv.ParseInt(raw, 10, 64); err != nil {
+				return nil, err
+			}
+			return raw, nil
+		}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return func(raw string) (any, error) {
+			if _, err := strconv.ParseUint(raw, 10, 64); err != nil {
+				return nil, err
+			}
+			return raw, nil
+		}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return func(raw string) (any, error) {
+			return strconv.ParseFloat(raw, 64)
+		}
+	case protoreflect.EnumKind:
+		return enumCoercer(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageCoercer(fd.Message())
+	default:
+		return stringValue
+	}
+}
+
+// enumCoercer accepts an enum value name or number.
+func enumCoercer(ed protoreflect.EnumDescriptor) coercer {
+	return func(raw string) (any, error) {
+		if n, err := strconv.ParseInt(raw, 10, 32); err == nil {
+			return n, nil
+		}
+		if ed.Values().ByName(protoreflect.Name(raw)) == nil {
+			return nil, fmt.Errorf("unknown value %q for enum %s", raw, ed.FullName())
+		}
+		return raw, nil
+	}
+}
+
+// messageCoercer handles the well-known types that have a scalar JSON form.
+func messageCoercer(md protoreflect.MessageDescriptor) coercer {
+	if md.FullName().Parent() == "google.protobuf" && strings.HasSuffix(string(md.Name()), "Value") {
+		if value := md.Fields().ByName("value"); value != nil {
+			return fieldCoercer(value)
+		}
+	}
+	switch md.FullName() {
+	case "google.protobuf.Duration", "google.protobuf.Timestamp", "google.protobuf.FieldMask", "google.protobuf.Value":
+		return stringValue
+	}
+	return func(string) (any, error) {
+		return nil, fmt.Errorf("message %s cannot be set from a single value", md.FullName())
+	}
+}
+
+func stringValue(raw string) (any, error) {
+	return raw, nil
+}
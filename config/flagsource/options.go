@@ -0,0 +1,78 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package flagsource is a configuration source that loads command-line flags.
+package flagsource
+
+import (
+	"flag"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultSetFlag is the name of the repeatable flag that carries key=value overrides.
+const DefaultSetFlag = "set"
+
+// flagOptions holds the settings of a flag source.
+type flagOptions struct {
+	flagSet  *flag.FlagSet
+	setFlag  string
+	values   []string
+	bindings map[string]string
+	target   protoreflect.MessageDescriptor
+}
+
+// WithFlagSet sets the flag set whose explicitly set flags are turned into configuration.
+// When it is not set, flag.CommandLine is used once it has been parsed.
+func WithFlagSet(fs *flag.FlagSet) options.Option {
+	return optionutil.Update(func(o *flagOptions) {
+		o.flagSet = fs
+	})
+}
+
+// WithSetFlag changes the name of the repeatable override flag, "set" by default.
+func WithSetFlag(name string) options.Option {
+	return optionutil.Update(func(o *flagOptions) {
+		o.setFlag = name
+	})
+}
+
+// WithValues appends explicit key=value overrides,
+// e.g. "servers.configs[0].http.addr=:9000".
+func WithValues(pairs ...string) options.Option {
+	return optionutil.Update(func(o *flagOptions) {
+		o.values = append(o.values, pairs...)
+	})
+}
+
+// WithBinding binds a flag to a configuration key, e.g. "http-addr" to
+// "servers.configs[0].http.addr". The flag is only applied when it is set explicitly.
+func WithBinding(flagName, key string) options.Option {
+	return optionutil.Update(func(o *flagOptions) {
+		if o.bindings == nil {
+			o.bindings = make(map[string]string)
+		}
+		o.bindings[flagName] = key
+	})
+}
+
+// WithTarget sets the message the configuration is scanned into. Its descriptor is used
+// to validate keys and to convert flag values into the types of the target fields.
+func WithTarget(target proto.Message) options.Option {
+	return optionutil.Update(func(o *flagOptions) {
+		if target != nil {
+			o.target = target.ProtoReflect().Descriptor()
+		}
+	})
+}
+
+func fromOptions(opts ...options.Option) *flagOptions {
+	var flagOpts flagOptions
+	optionutil.Apply(&flagOpts, opts...)
+	return &flagOpts
+}
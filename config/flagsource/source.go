@@ -0,0 +1,153 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package flagsource is a configuration source that loads command-line flags.
+package flagsource
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/config"
+
+	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	runtimeconfig "github.com/origadmin/runtime/config"
+	"github.com/origadmin/runtime/contracts/options"
+)
+
+// Values is a repeatable flag.Value collecting key=value overrides, e.g.
+//
+//	fs.Var(&flagsource.Values{}, flagsource.DefaultSetFlag, "override a configuration key")
+type Values []string
+
+// String implements flag.Value.
+func (v *Values) String() string {
+	return strings.Join(*v, ",")
+}
+
+// Set implements flag.Value.
+func (v *Values) Set(s string) error {
+	*v = append(*v, s)
+	return nil
+}
+
+// Get implements flag.Getter.
+func (v *Values) Get() any {
+	return []string(*v)
+}
+
+type source struct {
+	opts *flagOptions
+}
+
+// NewSource creates a source from explicit key=value pairs and the explicitly set
+// flags of a flag set. Flags are read when the source is loaded, so it can be
+// created before the flag set is parsed.
+func NewSource(opts ...options.Option) config.Source {
+	o := fromOptions(opts...)
+	if o.setFlag == "" {
+		o.setFlag = DefaultSetFlag
+	}
+	return &source{opts: o}
+}
+
+func (s *source) Load() ([]*config.KeyValue, error) {
+	data := make(map[string]any)
+	for _, pair := range s.pairs() {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || strings.TrimSpace(key) == "" {
+			return nil, fmt.Errorf("flagsource: invalid override %q, expected key=value", pair)
+		}
+		if err := set(data, s.opts.target, strings.TrimSpace(key), value); err != nil {
+			return nil, err
+		}
+	}
+	if len(data) == 0 {
+		return nil, nil
+	}
+	value, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	return []*config.KeyValue{{
+		Key:    "flags",
+		Value:  value,
+		Format: "json",
+	}}, nil
+}
+
+// pairs returns the overrides in the order they are applied: explicit values first,
+// then bound flags, then the override flag, so that a later pair wins.
+func (s *source) pairs() []string {
+	pairs := append([]string(nil), s.opts.values...)
+	fs := s.opts.flagSet
+	if fs == nil {
+		if !flag.Parsed() {
+			return pairs
+		}
+		fs = flag.CommandLine
+	}
+	var overrides []string
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == s.opts.setFlag {
+			if getter, ok := f.Value.(flag.Getter); ok {
+				if values, ok := getter.Get().([]string); ok {
+					overrides = append(overrides, values...)
+					return
+				}
+			}
+			overrides = append(overrides, f.Value.String())
+			return
+		}
+		if key, ok := s.opts.bindings[f.Name]; ok {
+			pairs = append(pairs, key+"="+f.Value.String())
+		}
+	})
+	return append(pairs, overrides...)
+}
+
+func (s *source) Watch() (config.Watcher, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &watcher{ctx: ctx, cancel: cancel}, nil
+}
+
+// watcher never reports changes, flags are fixed for the lifetime of the process.
+type watcher struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+}
+
+func (w *watcher) Next() ([]*config.KeyValue, error) {
+	<-w.ctx.Done()
+	return nil, w.ctx.Err()
+}
+
+func (w *watcher) Stop() error {
+	w.cancel()
+	return nil
+}
+
+// NewFlagSource creates a flag source from the source configuration. Options take
+// precedence over the set flag name of the configuration, values and bindings are merged.
+func NewFlagSource(sourceCfg *sourcev1.SourceConfig, opts ...options.Option) (runtimeconfig.KSource, error) {
+	flagsSrc := sourceCfg.GetFlags()
+	if flagsSrc == nil {
+		return NewSource(opts...), nil
+	}
+	cfgOpts := []options.Option{WithValues(flagsSrc.GetValues()...)}
+	if name := flagsSrc.GetSetFlag(); name != "" {
+		cfgOpts = append(cfgOpts, WithSetFlag(name))
+	}
+	for name, key := range flagsSrc.GetBindings() {
+		cfgOpts = append(cfgOpts, WithBinding(name, key))
+	}
+	return NewSource(append(cfgOpts, opts...)...), nil
+}
+
+func init() {
+	runtimeconfig.RegisterSourceFactory("flags", runtimeconfig.SourceFunc(NewFlagSource))
+}
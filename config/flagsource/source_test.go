@@ -0,0 +1,149 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package flagsource is a configuration source that loads command-line flags.
+package flagsource
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	transportv1 "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
+)
+
+func TestSourceLoad(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.Var(&Values{}, DefaultSetFlag, "override a configuration key")
+	fs.String("http-addr", "", "http listen address")
+	fs.String("unbound", "", "a flag without binding")
+	require.NoError(t, fs.Parse([]string{
+		"--http-addr=:8000",
+		"--unbound=ignored",
+		"--set", "configs[0].http.timeout=3s",
+		"--set", "configs[0].name=api",
+	}))
+
+	src := NewSource(
+		WithFlagSet(fs),
+		WithBinding("http-addr", "configs[0].http.addr"),
+		WithValues("configs[0].http.addr=:7000"),
+		WithTarget(&transportv1.Servers{}),
+	)
+	kvs, err := src.Load()
+	require.NoError(t, err)
+	require.Len(t, kvs, 1)
+	assert.Equal(t, "json", kvs[0].Format)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(kvs[0].Value, &got))
+	assert.Equal(t, map[string]any{
+		"configs": map[string]any{
+			"[0]": map[string]any{
+				"name": "api",
+				"http": map[string]any{
+					"addr":    ":8000",
+					"timeout": "3s",
+				},
+			},
+		},
+	}, got)
+}
+
+func TestSourceLoadCoercion(t *testing.T) {
+	tests := []struct {
+		name     string
+		pair     string
+		expected map[string]any
+		wantErr  bool
+	}{
+		{
+			name:     "string field",
+			pair:     "configs[0].protocol=http",
+			expected: map[string]any{"configs": map[string]any{"[0]": map[string]any{"protocol": "http"}}},
+		},
+		{
+			name:     "json name is normalized",
+			pair:     "configs[1].http.enablePprof=true",
+			expected: map[string]any{"configs": map[string]any{"[1]": map[string]any{"http": map[string]any{"enable_pprof": true}}}},
+		},
+		{
+			name:     "kebab-case name",
+			pair:     "configs[0].http.enable-pprof=1",
+			expected: map[string]any{"configs": map[string]any{"[0]": map[string]any{"http": map[string]any{"enable_pprof": true}}}},
+		},
+		{
+			name:     "list field",
+			pair:     "configs[0].http.middlewares=logging, recovery",
+			expected: map[string]any{"configs": map[string]any{"[0]": map[string]any{"http": map[string]any{"middlewares": []any{"logging", "recovery"}}}}},
+		},
+		{
+			name:    "invalid bool",
+			pair:    "configs[0].http.enable_pprof=maybe",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			pair:    "configs[0].unknown=1",
+			wantErr: true,
+		},
+		{
+			name:    "missing index",
+			pair:    "configs.name=api",
+			wantErr: true,
+		},
+		{
+			name:    "malformed index",
+			pair:    "configs[x].name=api",
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			pair:    "configs[0].name",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			src := NewSource(WithValues(tt.pair), WithTarget(&transportv1.Servers{}))
+			kvs, err := src.Load()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, kvs, 1)
+			var got map[string]any
+			require.NoError(t, json.Unmarshal(kvs[0].Value, &got))
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestSourceLoadWithoutTarget(t *testing.T) {
+	src := NewSource(WithValues("app.debug=true", "app.ports[2]=80"))
+	kvs, err := src.Load()
+	require.NoError(t, err)
+	require.Len(t, kvs, 1)
+
+	var got map[string]any
+	require.NoError(t, json.Unmarshal(kvs[0].Value, &got))
+	assert.Equal(t, map[string]any{
+		"app": map[string]any{
+			"debug": "true",
+			"ports": map[string]any{"[2]": "80"},
+		},
+	}, got)
+}
+
+func TestSourceLoadEmpty(t *testing.T) {
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	kvs, err := NewSource(WithFlagSet(fs)).Load()
+	require.NoError(t, err)
+	assert.Empty(t, kvs)
+}
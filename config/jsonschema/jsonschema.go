@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package jsonschema generates JSON Schema documents for configuration messages, so that
+// editors and YAML language servers can complete and check configuration files.
+//
+// Properties are described with the gnostic openapi.v3.property annotations, or the
+// comments of the proto files when they are available, and carry the bounds of the
+// protoc-gen-validate and protovalidate rules of their fields.
+package jsonschema
+
+import (
+	"encoding/json"
+	"strings"
+
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Generate returns the JSON Schema of the message described by md. Other messages are
+// placed in the definitions of the document and referenced by their full name.
+func Generate(md protoreflect.MessageDescriptor, opts ...options.Option) *Schema {
+	g := &generator{
+		opts: optionutil.NewT[schemaOptions](opts...),
+		root: md.FullName(),
+		defs: make(map[string]*Schema),
+	}
+	schema := g.message(md)
+	schema.Schema = Draft
+	schema.ID = g.opts.id
+	if len(g.defs) > 0 {
+		schema.Definitions = g.defs
+	}
+	return schema
+}
+
+// Marshal returns the JSON Schema of the message described by md as an indented JSON document.
+func Marshal(md protoreflect.MessageDescriptor, opts ...options.Option) ([]byte, error) {
+	return json.MarshalIndent(Generate(md, opts...), "", "  ")
+}
+
+type generator struct {
+	opts *schemaOptions
+	root protoreflect.FullName
+	defs map[string]*Schema
+}
+
+// ref returns a reference to the schema of md, generating it on first use.
+func (g *generator) ref(md protoreflect.MessageDescriptor) *Schema {
+	if md.FullName() == g.root {
+		return &Schema{Ref: "#"}
+	}
+	name := string(md.FullName())
+	if _, ok := g.defs[name]; !ok {
+		// Reserve the name first, the message may refer to itself.
+		g.defs[name] = nil
+		g.defs[name] = g.message(md)
+	}
+	return &Schema{Ref: "#/definitions/" + name}
+}
+
+// message returns the object schema of md.
+func (g *generator) message(md protoreflect.MessageDescriptor) *Schema {
+	schema := &Schema{
+		Title:       string(md.Name()),
+		Description: messageDescription(md),
+		Type:        "object",
+		Properties:  make(map[string]*Schema),
+	}
+	if !g.opts.additionalProperties {
+		schema.AdditionalProperties = false
+	}
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		fd := fields.Get(i)
+		name := g.propertyName(fd)
+		property, required := g.field(fd)
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	g.oneofs(schema, md)
+	return schema
+}
+
+func (g *generator) propertyName(fd protoreflect.FieldDescriptor) string {
+	if g.opts.protoNames {
+		return string(fd.Name())
+	}
+	return fd.JSONName()
+}
+
+// field returns the schema of a field and whether the field is required.
+func (g *generator) field(fd protoreflect.FieldDescriptor) (*Schema, bool) {
+	var schema *Schema
+	switch {
+	case fd.IsList():
+		schema = &Schema{Type: "array", Items: g.singular(fd)}
+	case fd.IsMap():
+		schema = &Schema{
+			Type:                 "object",
+			PropertyNames:        mapKey(fd.MapKey()),
+			AdditionalProperties: g.singular(fd.MapValue()),
+		}
+	default:
+		schema = g.singular(fd)
+	}
+	if desc := fieldDescription(fd); desc != "" {
+		schema.Description = desc
+	}
+	required := applyFieldRules(schema, fd)
+	return schema, required
+}
+
+// singular returns the schema of a single value of fd, ignoring its cardinality.
+func (g *generator) singular(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		return &Schema{Type: "boolean"}
+	case protoreflect.StringKind:
+		return &Schema{Type: "string"}
+	case protoreflect.BytesKind:
+		return &Schema{Type: "string", ContentEncoding: "base64"}
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		return &Schema{Type: "number"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind,
+		protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		return &Schema{Type: "integer"}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		// protojson writes 64-bit integers as strings and accepts both forms.
+		return &Schema{Type: []string{"integer", "string"}, Pattern: `^-?[0-9]+$`}
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Type: []string{"integer", "string"}, Pattern: `^[0-9]+$`}
+	case protoreflect.EnumKind:
+		return enum(fd.Enum())
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		if schema, ok := wellKnown(fd.Message()); ok {
+			return schema
+		}
+		return g.ref(fd.Message())
+	}
+	return &Schema{}
+}
+
+// oneofs adds the constraints of the oneofs of md: at most one of their fields may be
+// set, and exactly one when the oneof is required.
+func (g *generator) oneofs(schema *Schema, md protoreflect.MessageDescriptor) {
+	oneofs := md.Oneofs()
+	for i := 0; i < oneofs.Len(); i++ {
+		od := oneofs.Get(i)
+		if od.IsSynthetic() {
+			continue
+		}
+		var names []string
+		for j := 0; j < od.Fields().Len(); j++ {
+			names = append(names, g.propertyName(od.Fields().Get(j)))
+		}
+		if c := exclusive(names, oneofRequired(od)); c != nil {
+			schema.AllOf = append(schema.AllOf, c)
+		}
+	}
+	for _, rule := range messageOneofRules(md) {
+		var names []string
+		for _, field := range rule.fields {
+			if fd := md.Fields().ByName(protoreflect.Name(field)); fd != nil {
+				names = append(names, g.propertyName(fd))
+			}
+		}
+		if c := exclusive(names, rule.required); c != nil {
+			schema.AllOf = append(schema.AllOf, c)
+		}
+	}
+}
+
+// exclusive returns a schema allowing at most one of the properties, or exactly one
+// when required is set.
+func exclusive(names []string, required bool) *Schema {
+	if len(names) == 0 || (len(names) == 1 && !required) {
+		return nil
+	}
+	var branches []*Schema
+	for _, name := range names {
+		branches = append(branches, &Schema{Required: []string{name}})
+	}
+	if required {
+		return &Schema{OneOf: branches}
+	}
+	return &Schema{Not: &Schema{AnyOf: pairs(names)}}
+}
+
+// pairs returns a schema for each pair of properties being set together.
+func pairs(names []string) []*Schema {
+	var schemas []*Schema
+	for i := range names {
+		for j := i + 1; j < len(names); j++ {
+			schemas = append(schemas, &Schema{Required: []string{names[i], names[j]}})
+		}
+	}
+	return schemas
+}
+
+func enum(ed protoreflect.EnumDescriptor) *Schema {
+	schema := &Schema{Type: []string{"string", "integer"}}
+	values := ed.Values()
+	for i := 0; i < values.Len(); i++ {
+		schema.Enum = append(schema.Enum, string(values.Get(i).Name()))
+	}
+	for i := 0; i < values.Len(); i++ {
+		schema.Enum = append(schema.Enum, int32(values.Get(i).Number()))
+	}
+	if desc := comments(ed.ParentFile().SourceLocations().ByDescriptor(ed)); desc != "" {
+		schema.Description = desc
+	}
+	return schema
+}
+
+func mapKey(fd protoreflect.FieldDescriptor) *Schema {
+	switch fd.Kind() {
+	case protoreflect.StringKind:
+		return nil
+	case protoreflect.BoolKind:
+		return &Schema{Enum: []any{"true", "false"}}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind, protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		return &Schema{Pattern: `^[0-9]+$`}
+	default:
+		return &Schema{Pattern: `^-?[0-9]+$`}
+	}
+}
+
+// wellKnown returns the schema of the well-known types that protojson represents
+// with a JSON value other than an object of their fields.
+func wellKnown(md protoreflect.MessageDescriptor) (*Schema, bool) {
+	switch md.FullName() {
+	case "google.protobuf.Duration":
+		return &Schema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?s$`}, true
+	case "google.protobuf.Timestamp":
+		return &Schema{Type: "string", Format: "date-time"}, true
+	case "google.protobuf.FieldMask":
+		return &Schema{Type: "string"}, true
+	case "google.protobuf.Struct":
+		return &Schema{Type: "object"}, true
+	case "google.protobuf.ListValue":
+		return &Schema{Type: "array"}, true
+	case "google.protobuf.Value":
+		return &Schema{}, true
+	case "google.protobuf.Empty":
+		return &Schema{Type: "object", AdditionalProperties: false}, true
+	case "google.protobuf.Any":
+		return &Schema{
+			Type:       "object",
+			Properties: map[string]*Schema{"@type": {Type: "string"}},
+			Required:   []string{"@type"},
+		}, true
+	}
+	if md.FullName().Parent() == "google.protobuf" && strings.HasSuffix(string(md.Name()), "Value") {
+		if value := md.Fields().ByName("value"); value != nil {
+			return (&generator{}).singular(value), true
+		}
+	}
+	return nil, false
+}
+
+// fieldDescription returns the description of the openapi.v3.property annotation of
+// fd, or its comments.
+func fieldDescription(fd protoreflect.FieldDescriptor) string {
+	if property, ok := proto.GetExtension(fd.Options(), openapiv3.E_Property).(*openapiv3.Schema); ok && property != nil {
+		if desc := property.GetDescription(); desc != "" {
+			return desc
+		}
+	}
+	return comments(fd.ParentFile().SourceLocations().ByDescriptor(fd))
+}
+
+// messageDescription returns the description of the openapi.v3.schema annotation of
+// md, or its comments.
+func messageDescription(md protoreflect.MessageDescriptor) string {
+	if schema, ok := proto.GetExtension(md.Options(), openapiv3.E_Schema).(*openapiv3.Schema); ok && schema != nil {
+		if desc := schema.GetDescription(); desc != "" {
+			return desc
+		}
+	}
+	return comments(md.ParentFile().SourceLocations().ByDescriptor(md))
+}
+
+// comments returns the comments of a declaration. Source information is only present
+// in descriptors read from the compiler, not in those linked into a binary.
+func comments(loc protoreflect.SourceLocation) string {
+	text := loc.LeadingComments
+	if text == "" {
+		text = loc.TrailingComments
+	}
+	lines := strings.Split(strings.TrimSpace(text), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(line)
+	}
+	return strings.Join(lines, "\n")
+}
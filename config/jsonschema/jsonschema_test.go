@@ -0,0 +1,68 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	bootstrapv1 "github.com/origadmin/runtime/api/gen/go/config/bootstrap/v1"
+	ossv1 "github.com/origadmin/runtime/api/gen/go/config/data/oss/v1"
+	jwtv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1"
+)
+
+func TestGenerateRules(t *testing.T) {
+	schema := Generate((&jwtv1.JWT{}).ProtoReflect().Descriptor())
+	assert.Equal(t, Draft, schema.Schema)
+	assert.Equal(t, "JWT", schema.Title)
+	assert.Equal(t, false, schema.AdditionalProperties)
+	assert.Equal(t, "The type of the claim used to extract the token.", schema.Properties["claim_type"].Description)
+	assert.Equal(t, "#/definitions/runtime.api.config.middleware.jwt.v1.AuthConfig", schema.Properties["config"].Ref)
+
+	config := schema.Definitions["runtime.api.config.middleware.jwt.v1.AuthConfig"]
+	require.NotNil(t, config)
+
+	method := config.Properties["signing_method"]
+	assert.Equal(t, "string", method.Type)
+	assert.Equal(t, uint64(1), *method.MinLength)
+	assert.Equal(t, uint64(1024), *method.MaxLength)
+	assert.Equal(t, "^[A-Z0-9]+$", method.Pattern)
+
+	lifetime := config.Properties["access_token_lifetime"]
+	assert.Equal(t, json.Number("1"), lifetime.Minimum)
+	assert.Equal(t, json.Number("31536000"), lifetime.Maximum)
+
+	audience := config.Properties["audience"]
+	assert.Equal(t, "array", audience.Type)
+	assert.Equal(t, uint64(1), *audience.MinItems)
+	assert.True(t, audience.UniqueItems)
+}
+
+func TestGenerateOneof(t *testing.T) {
+	schema := Generate((&ossv1.WriteRequest{}).ProtoReflect().Descriptor())
+	require.Len(t, schema.AllOf, 1)
+	assert.Equal(t, &Schema{Not: &Schema{AnyOf: []*Schema{{Required: []string{"metadata", "chunk"}}}}}, schema.AllOf[0])
+	assert.Equal(t, "base64", schema.Properties["chunk"].ContentEncoding)
+}
+
+func TestMarshalBootstrap(t *testing.T) {
+	content, err := Marshal((&bootstrapv1.Bootstrap{}).ProtoReflect().Descriptor(), WithID("bootstrap.schema.json"))
+	require.NoError(t, err)
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(content, &doc))
+	assert.Equal(t, "bootstrap.schema.json", doc["$id"])
+	assert.Contains(t, doc["properties"], "sources")
+	assert.Contains(t, doc["definitions"], "runtime.api.config.source.v1.SourceConfig")
+}
+
+func TestGenerateProtoNames(t *testing.T) {
+	schema := Generate((&jwtv1.AuthConfig{}).ProtoReflect().Descriptor(), WithProtoNames(), WithAdditionalProperties())
+	assert.Contains(t, schema.Properties, "token_source")
+	assert.Nil(t, schema.AdditionalProperties)
+}
@@ -0,0 +1,40 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package jsonschema
+
+import (
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// schemaOptions holds the settings of the generator.
+type schemaOptions struct {
+	id                   string
+	protoNames           bool
+	additionalProperties bool
+}
+
+// WithID sets the $id of the generated document.
+func WithID(id string) options.Option {
+	return optionutil.Update(func(o *schemaOptions) {
+		o.id = id
+	})
+}
+
+// WithProtoNames names properties after the proto field names instead of their JSON
+// names. protojson accepts both when decoding a configuration.
+func WithProtoNames() options.Option {
+	return optionutil.Update(func(o *schemaOptions) {
+		o.protoNames = true
+	})
+}
+
+// WithAdditionalProperties allows properties that are not fields of the message. By
+// default they are rejected, like protojson does.
+func WithAdditionalProperties() options.Option {
+	return optionutil.Update(func(o *schemaOptions) {
+		o.additionalProperties = true
+	})
+}
@@ -0,0 +1,283 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package jsonschema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	pgv "github.com/envoyproxy/protoc-gen-validate/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// stringFormats maps the string rules to their JSON Schema format.
+var stringFormats = map[protoreflect.Name]string{
+	"email":    "email",
+	"hostname": "hostname",
+	"ipv4":     "ipv4",
+	"ipv6":     "ipv6",
+	"uri":      "uri",
+	"uri_ref":  "uri-reference",
+	"uuid":     "uuid",
+}
+
+// applyFieldRules adds the validation rules of fd to its schema and reports whether
+// the field is required. The protoc-gen-validate and protovalidate rules share the
+// names of their fields, so both are read through reflection.
+func applyFieldRules(schema *Schema, fd protoreflect.FieldDescriptor) bool {
+	required := false
+	if disabled, _ := proto.GetExtension(fd.ContainingMessage().Options(), pgv.E_Disabled).(bool); !disabled {
+		if rules, ok := proto.GetExtension(fd.Options(), pgv.E_Rules).(*pgv.FieldRules); ok && rules != nil {
+			required = rules.GetMessage().GetRequired()
+			applyRules(schema, rules.ProtoReflect())
+		}
+	}
+	if rules, ok := proto.GetExtension(fd.Options(), validate.E_Field).(*validate.FieldRules); ok && rules != nil {
+		required = required || rules.GetRequired()
+		applyRules(schema, rules.ProtoReflect())
+	}
+	return required
+}
+
+// applyRules adds the type rules of a FieldRules message to schema.
+func applyRules(schema *Schema, rules protoreflect.Message) {
+	od := rules.Descriptor().Oneofs().ByName("type")
+	if od == nil {
+		return
+	}
+	fd := rules.WhichOneof(od)
+	if fd == nil || fd.Kind() != protoreflect.MessageKind {
+		return
+	}
+	typed := rules.Get(fd).Message()
+	switch fd.Name() {
+	case "repeated":
+		schema.MinItems = uintRule(typed, "min_items")
+		schema.MaxItems = uintRule(typed, "max_items")
+		schema.UniqueItems = boolRule(typed, "unique")
+		if items := messageRule(typed, "items"); items != nil && schema.Items != nil {
+			applyRules(schema.Items, items)
+		}
+	case "map":
+		schema.MinProperties = uintRule(typed, "min_pairs")
+		schema.MaxProperties = uintRule(typed, "max_pairs")
+		if keys := messageRule(typed, "keys"); keys != nil {
+			if schema.PropertyNames == nil {
+				schema.PropertyNames = &Schema{}
+			}
+			applyRules(schema.PropertyNames, keys)
+		}
+		if values, ok := schema.AdditionalProperties.(*Schema); ok {
+			if rule := messageRule(typed, "values"); rule != nil {
+				applyRules(values, rule)
+			}
+		}
+	case "string":
+		applyStringRules(schema, typed)
+	case "enum":
+		if value, ok := listRule(typed, "in"); ok {
+			schema.Enum = filterEnum(schema.Enum, value, true)
+		}
+		if value, ok := listRule(typed, "not_in"); ok {
+			schema.Enum = filterEnum(schema.Enum, value, false)
+		}
+		if value, ok := scalarRule(typed, "const"); ok {
+			schema.Enum = filterEnum(schema.Enum, []any{value}, true)
+		}
+	case "float", "double", "int32", "int64", "uint32", "uint64", "sint32", "sint64",
+		"fixed32", "fixed64", "sfixed32", "sfixed64":
+		applyNumberRules(schema, typed)
+	}
+}
+
+func applyNumberRules(schema *Schema, rules protoreflect.Message) {
+	if value, ok := scalarRule(rules, "const"); ok {
+		schema.Const = value
+	}
+	schema.Minimum = numberRule(rules, "gte")
+	schema.ExclusiveMinimum = numberRule(rules, "gt")
+	schema.Maximum = numberRule(rules, "lte")
+	schema.ExclusiveMaximum = numberRule(rules, "lt")
+	if values, ok := listRule(rules, "in"); ok {
+		schema.Enum = values
+	}
+	if values, ok := listRule(rules, "not_in"); ok {
+		schema.Not = &Schema{Enum: values}
+	}
+}
+
+func applyStringRules(schema *Schema, rules protoreflect.Message) {
+	if value, ok := scalarRule(rules, "const"); ok {
+		schema.Const = value
+	}
+	if n := uintRule(rules, "len"); n != nil {
+		schema.MinLength, schema.MaxLength = n, n
+	}
+	if n := uintRule(rules, "min_len"); n != nil {
+		schema.MinLength = n
+	}
+	if n := uintRule(rules, "max_len"); n != nil {
+		schema.MaxLength = n
+	}
+	var patterns []string
+	if value, ok := scalarRule(rules, "pattern"); ok {
+		patterns = append(patterns, value.(string))
+	}
+	if value, ok := scalarRule(rules, "prefix"); ok {
+		patterns = append(patterns, "^"+regexp.QuoteMeta(value.(string)))
+	}
+	if value, ok := scalarRule(rules, "suffix"); ok {
+		patterns = append(patterns, regexp.QuoteMeta(value.(string))+"$")
+	}
+	if value, ok := scalarRule(rules, "contains"); ok {
+		patterns = append(patterns, regexp.QuoteMeta(value.(string)))
+	}
+	for i, pattern := range patterns {
+		if i == 0 && schema.Pattern == "" {
+			schema.Pattern = pattern
+			continue
+		}
+		schema.AllOf = append(schema.AllOf, &Schema{Pattern: pattern})
+	}
+	for name, format := range stringFormats {
+		if boolRule(rules, name) {
+			schema.Format = format
+		}
+	}
+	if values, ok := listRule(rules, "in"); ok {
+		schema.Enum = values
+	}
+	if values, ok := listRule(rules, "not_in"); ok {
+		schema.Not = &Schema{Enum: values}
+	}
+}
+
+// filterEnum keeps the enum values whose number is in numbers, or is not when keep is false.
+func filterEnum(values []any, numbers []any, keep bool) []any {
+	set := make(map[string]bool, len(numbers))
+	for _, n := range numbers {
+		set[fmt.Sprint(n)] = true
+	}
+	// Enum schemas list the value names followed by the value numbers.
+	half := len(values) / 2
+	var names, nums []any
+	for i := 0; i < half; i++ {
+		if set[fmt.Sprint(values[half+i])] == keep {
+			names = append(names, values[i])
+			nums = append(nums, values[half+i])
+		}
+	}
+	return append(names, nums...)
+}
+
+// oneofRequired reports whether one of the fields of od must be set.
+func oneofRequired(od protoreflect.OneofDescriptor) bool {
+	if required, _ := proto.GetExtension(od.Options(), pgv.E_Required).(bool); required {
+		return true
+	}
+	rules, ok := proto.GetExtension(od.Options(), validate.E_Oneof).(*validate.OneofRules)
+	return ok && rules.GetRequired()
+}
+
+// oneofRule is a protovalidate message-level oneof rule.
+type oneofRule struct {
+	fields   []string
+	required bool
+}
+
+func messageOneofRules(md protoreflect.MessageDescriptor) []oneofRule {
+	rules, ok := proto.GetExtension(md.Options(), validate.E_Message).(*validate.MessageRules)
+	if !ok || rules == nil {
+		return nil
+	}
+	var oneofs []oneofRule
+	for _, rule := range rules.GetOneof() {
+		oneofs = append(oneofs, oneofRule{fields: rule.GetFields(), required: rule.GetRequired()})
+	}
+	return oneofs
+}
+
+// rule returns the value of the named field of a rules message, if it is set.
+func rule(rules protoreflect.Message, name protoreflect.Name) (protoreflect.FieldDescriptor, protoreflect.Value, bool) {
+	fd := rules.Descriptor().Fields().ByName(name)
+	if fd == nil || !rules.Has(fd) {
+		return nil, protoreflect.Value{}, false
+	}
+	return fd, rules.Get(fd), true
+}
+
+func scalarRule(rules protoreflect.Message, name protoreflect.Name) (any, bool) {
+	fd, value, ok := rule(rules, name)
+	if !ok || fd.IsList() || fd.Kind() == protoreflect.MessageKind {
+		return nil, false
+	}
+	return value.Interface(), true
+}
+
+func listRule(rules protoreflect.Message, name protoreflect.Name) ([]any, bool) {
+	fd, value, ok := rule(rules, name)
+	if !ok || !fd.IsList() || fd.Kind() == protoreflect.MessageKind {
+		return nil, false
+	}
+	list := value.List()
+	values := make([]any, 0, list.Len())
+	for i := 0; i < list.Len(); i++ {
+		values = append(values, list.Get(i).Interface())
+	}
+	return values, true
+}
+
+func messageRule(rules protoreflect.Message, name protoreflect.Name) protoreflect.Message {
+	fd, value, ok := rule(rules, name)
+	if !ok || fd.Kind() != protoreflect.MessageKind {
+		return nil
+	}
+	return value.Message()
+}
+
+func boolRule(rules protoreflect.Message, name protoreflect.Name) bool {
+	value, ok := scalarRule(rules, name)
+	b, _ := value.(bool)
+	return ok && b
+}
+
+func uintRule(rules protoreflect.Message, name protoreflect.Name) *uint64 {
+	value, ok := scalarRule(rules, name)
+	if !ok {
+		return nil
+	}
+	n, ok := value.(uint64)
+	if !ok {
+		return nil
+	}
+	return &n
+}
+
+// numberRule returns a numeric bound. Bounds of durations and timestamps are messages
+// and are not represented in the schema.
+func numberRule(rules protoreflect.Message, name protoreflect.Name) json.Number {
+	value, ok := scalarRule(rules, name)
+	if !ok {
+		return ""
+	}
+	switch v := value.(type) {
+	case float32:
+		if math.IsInf(float64(v), 0) || math.IsNaN(float64(v)) {
+			return ""
+		}
+	case float64:
+		if math.IsInf(v, 0) || math.IsNaN(v) {
+			return ""
+		}
+	case int32, int64, uint32, uint64:
+	default:
+		return ""
+	}
+	return json.Number(fmt.Sprint(value))
+}
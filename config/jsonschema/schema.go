@@ -0,0 +1,58 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package jsonschema
+
+import (
+	"encoding/json"
+)
+
+// Draft is the JSON Schema dialect of the generated documents. Draft 7 is the newest
+// dialect supported by most editors and YAML language servers.
+const Draft = "http://json-schema.org/draft-07/schema#"
+
+// Schema is a JSON Schema document or subschema. Only the keywords used by the
+// generator are defined.
+type Schema struct {
+	Schema      string `json:"$schema,omitempty"`
+	ID          string `json:"$id,omitempty"`
+	Ref         string `json:"$ref,omitempty"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Type is either a single type name or a list of type names.
+	Type any `json:"type,omitempty"`
+
+	Properties map[string]*Schema `json:"properties,omitempty"`
+	// AdditionalProperties is either a boolean or a *Schema.
+	AdditionalProperties any      `json:"additionalProperties,omitempty"`
+	PropertyNames        *Schema  `json:"propertyNames,omitempty"`
+	Required             []string `json:"required,omitempty"`
+	MinProperties        *uint64  `json:"minProperties,omitempty"`
+	MaxProperties        *uint64  `json:"maxProperties,omitempty"`
+
+	Items       *Schema `json:"items,omitempty"`
+	MinItems    *uint64 `json:"minItems,omitempty"`
+	MaxItems    *uint64 `json:"maxItems,omitempty"`
+	UniqueItems bool    `json:"uniqueItems,omitempty"`
+
+	Enum             []any       `json:"enum,omitempty"`
+	Const            any         `json:"const,omitempty"`
+	Minimum          json.Number `json:"minimum,omitempty"`
+	ExclusiveMinimum json.Number `json:"exclusiveMinimum,omitempty"`
+	Maximum          json.Number `json:"maximum,omitempty"`
+	ExclusiveMaximum json.Number `json:"exclusiveMaximum,omitempty"`
+
+	MinLength       *uint64 `json:"minLength,omitempty"`
+	MaxLength       *uint64 `json:"maxLength,omitempty"`
+	Pattern         string  `json:"pattern,omitempty"`
+	Format          string  `json:"format,omitempty"`
+	ContentEncoding string  `json:"contentEncoding,omitempty"`
+
+	AllOf []*Schema `json:"allOf,omitempty"`
+	AnyOf []*Schema `json:"anyOf,omitempty"`
+	OneOf []*Schema `json:"oneOf,omitempty"`
+	Not   *Schema   `json:"not,omitempty"`
+
+	Definitions map[string]*Schema `json:"definitions,omitempty"`
+}
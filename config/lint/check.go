@@ -0,0 +1,289 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package lint
+
+import (
+	"encoding/base64"
+	"math"
+	"sort"
+	"strconv"
+	"time"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// reporter records a diagnostic for a field path.
+type reporter func(path string, kind Kind, format string, args ...any)
+
+// checkMessage checks decoded configuration data against md the way protojson decodes
+// it, but reports every problem instead of stopping at the first one.
+func checkMessage(md protoreflect.MessageDescriptor, data map[string]any, path string, report reporter) {
+	keys := make([]string, 0, len(data))
+	for key := range data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := md.Fields()
+	for _, key := range keys {
+		fd := fields.ByJSONName(key)
+		if fd == nil {
+			fd = fields.ByTextName(key)
+		}
+		if fd == nil {
+			report(joinPath(path, key), KindUnknownField, "%s has no field %q", md.FullName(), key)
+			continue
+		}
+		checkField(fd, data[key], joinPath(path, string(fd.Name())), report)
+	}
+}
+
+func checkField(fd protoreflect.FieldDescriptor, value any, path string, report reporter) {
+	if value == nil {
+		return
+	}
+	switch {
+	case fd.IsList():
+		list, ok := value.([]any)
+		if !ok {
+			report(path, KindTypeMismatch, "expected a list, got %s", typeName(value))
+			return
+		}
+		for i, item := range list {
+			checkSingular(fd, item, path+"["+strconv.Itoa(i)+"]", report)
+		}
+	case fd.IsMap():
+		m, ok := value.(map[string]any)
+		if !ok {
+			report(path, KindTypeMismatch, "expected a map, got %s", typeName(value))
+			return
+		}
+		keys := make([]string, 0, len(m))
+		for key := range m {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			elemPath := path + "[" + strconv.Quote(key) + "]"
+			if msg := checkMapKey(fd.MapKey(), key); msg != "" {
+				report(elemPath, KindTypeMismatch, "%s", msg)
+			}
+			checkSingular(fd.MapValue(), m[key], elemPath, report)
+		}
+	default:
+		checkSingular(fd, value, path, report)
+	}
+}
+
+// checkSingular checks a single value of fd, ignoring its cardinality.
+func checkSingular(fd protoreflect.FieldDescriptor, value any, path string, report reporter) {
+	if value == nil {
+		return
+	}
+	var msg string
+	switch fd.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		checkMessageValue(fd.Message(), value, path, report)
+		return
+	case protoreflect.EnumKind:
+		msg = checkEnum(fd.Enum(), value)
+	case protoreflect.BoolKind:
+		if _, ok := value.(bool); !ok {
+			msg = "expected a boolean, got " + typeName(value)
+		}
+	case protoreflect.StringKind:
+		if _, ok := value.(string); !ok {
+			msg = "expected a string, got " + typeName(value)
+		}
+	case protoreflect.BytesKind:
+		msg = checkBytes(value)
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		msg = checkFloat(value)
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		msg = checkInt(value, math.MinInt32, math.MaxInt32)
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		msg = checkInt(value, math.MinInt64, math.MaxInt64)
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		msg = checkInt(value, 0, math.MaxUint32)
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		msg = checkInt(value, 0, math.MaxUint64)
+	}
+	if msg != "" {
+		report(path, KindTypeMismatch, "%s", msg)
+	}
+}
+
+// checkMessageValue checks a message value, including the well-known types that
+// protojson represents as scalars.
+func checkMessageValue(md protoreflect.MessageDescriptor, value any, path string, report reporter) {
+	switch md.FullName() {
+	case "google.protobuf.Duration":
+		s, ok := value.(string)
+		if !ok {
+			report(path, KindTypeMismatch, "expected a duration string such as \"1.5s\", got %s", typeName(value))
+			return
+		}
+		if _, err := time.ParseDuration(s); err != nil || s == "" || s[len(s)-1] != 's' {
+			report(path, KindTypeMismatch, "invalid duration %q, protojson expects seconds such as \"1.5s\"", s)
+		}
+		return
+	case "google.protobuf.Timestamp":
+		s, ok := value.(string)
+		if !ok {
+			report(path, KindTypeMismatch, "expected an RFC 3339 timestamp, got %s", typeName(value))
+			return
+		}
+		if _, err := time.Parse(time.RFC3339Nano, s); err != nil {
+			report(path, KindTypeMismatch, "invalid timestamp %q", s)
+		}
+		return
+	case "google.protobuf.FieldMask":
+		if _, ok := value.(string); !ok {
+			report(path, KindTypeMismatch, "expected a field mask string, got %s", typeName(value))
+		}
+		return
+	case "google.protobuf.Value", "google.protobuf.Any":
+		return
+	case "google.protobuf.Struct":
+		if _, ok := value.(map[string]any); !ok {
+			report(path, KindTypeMismatch, "expected an object, got %s", typeName(value))
+		}
+		return
+	case "google.protobuf.ListValue":
+		if _, ok := value.([]any); !ok {
+			report(path, KindTypeMismatch, "expected a list, got %s", typeName(value))
+		}
+		return
+	case "google.protobuf.DoubleValue", "google.protobuf.FloatValue", "google.protobuf.Int64Value",
+		"google.protobuf.UInt64Value", "google.protobuf.Int32Value", "google.protobuf.UInt32Value",
+		"google.protobuf.BoolValue", "google.protobuf.StringValue", "google.protobuf.BytesValue":
+		checkSingular(md.Fields().ByName("value"), value, path, report)
+		return
+	}
+	m, ok := value.(map[string]any)
+	if !ok {
+		report(path, KindTypeMismatch, "expected an object for %s, got %s", md.FullName(), typeName(value))
+		return
+	}
+	checkMessage(md, m, path, report)
+}
+
+func checkEnum(ed protoreflect.EnumDescriptor, value any) string {
+	switch v := value.(type) {
+	case string:
+		if ed.Values().ByName(protoreflect.Name(v)) == nil {
+			return "unknown value " + strconv.Quote(v) + " for enum " + string(ed.FullName())
+		}
+	case float64:
+		if v != math.Trunc(v) {
+			return "expected an enum value, got " + strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	default:
+		return "expected an enum value, got " + typeName(value)
+	}
+	return ""
+}
+
+func checkBytes(value any) string {
+	s, ok := value.(string)
+	if !ok {
+		return "expected a base64 string, got " + typeName(value)
+	}
+	if _, err := base64.StdEncoding.DecodeString(s); err == nil {
+		return ""
+	}
+	if _, err := base64.URLEncoding.DecodeString(s); err == nil {
+		return ""
+	}
+	if _, err := base64.RawStdEncoding.DecodeString(s); err == nil {
+		return ""
+	}
+	if _, err := base64.RawURLEncoding.DecodeString(s); err == nil {
+		return ""
+	}
+	return "invalid base64 string"
+}
+
+func checkFloat(value any) string {
+	switch v := value.(type) {
+	case float64:
+		return ""
+	case string:
+		switch v {
+		case "NaN", "Infinity", "-Infinity":
+			return ""
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return "expected a number, got " + strconv.Quote(v)
+		}
+		return ""
+	default:
+		return "expected a number, got " + typeName(value)
+	}
+}
+
+// checkInt accepts numbers and numeric strings within [min, max], like protojson.
+func checkInt(value any, min, max float64) string {
+	var f float64
+	switch v := value.(type) {
+	case float64:
+		f = v
+	case string:
+		n, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return "expected an integer, got " + strconv.Quote(v)
+		}
+		f = n
+	default:
+		return "expected an integer, got " + typeName(value)
+	}
+	if f != math.Trunc(f) {
+		return "expected an integer, got " + strconv.FormatFloat(f, 'g', -1, 64)
+	}
+	if f < min || f > max {
+		return "integer " + strconv.FormatFloat(f, 'f', -1, 64) + " out of range"
+	}
+	return ""
+}
+
+func checkMapKey(fd protoreflect.FieldDescriptor, key string) string {
+	switch fd.Kind() {
+	case protoreflect.BoolKind:
+		if key != "true" && key != "false" {
+			return "expected a boolean map key, got " + strconv.Quote(key)
+		}
+	case protoreflect.StringKind:
+	default:
+		if _, err := strconv.ParseInt(key, 10, 64); err != nil {
+			if _, err := strconv.ParseUint(key, 10, 64); err != nil {
+				return "expected an integer map key, got " + strconv.Quote(key)
+			}
+		}
+	}
+	return ""
+}
+
+func typeName(value any) string {
+	switch v := value.(type) {
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string " + strconv.Quote(v)
+	case []any:
+		return "list"
+	case map[string]any:
+		return "object"
+	default:
+		return "unsupported value"
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
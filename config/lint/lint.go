@@ -0,0 +1,229 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package lint checks configuration files against a protobuf message without starting
+// the service. Files are loaded through the same file and environment sources used at
+// runtime, then checked for unknown fields, type mismatches and validation rules.
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/dynamicpb"
+
+	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	runtimeconfig "github.com/origadmin/runtime/config"
+	"github.com/origadmin/runtime/config/envsource"
+	"github.com/origadmin/runtime/config/file"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/engine/bootstrap"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Kind classifies a Diagnostic.
+type Kind string
+
+const (
+	KindUnknownField Kind = "unknown field"
+	KindTypeMismatch Kind = "type mismatch"
+	KindViolation    Kind = "rule violation"
+	KindDecode       Kind = "decode error"
+)
+
+// Position is a location in a configuration file.
+type Position struct {
+	File   string
+	Line   int
+	Column int
+}
+
+// String formats the position as file:line:column, or returns an empty string when
+// the location is unknown.
+func (p Position) String() string {
+	if p.File == "" {
+		return ""
+	}
+	if p.Line == 0 {
+		return p.File
+	}
+	return p.File + ":" + strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// Diagnostic is a single problem found in the configuration.
+type Diagnostic struct {
+	Position Position
+	// Path is the field path in the message, e.g. "servers.configs[0].http.addr".
+	Path    string
+	Kind    Kind
+	Message string
+}
+
+// String formats the diagnostic as "file:line:col: path: kind: message".
+func (d Diagnostic) String() string {
+	var parts []string
+	if pos := d.Position.String(); pos != "" {
+		parts = append(parts, pos)
+	}
+	if d.Path != "" {
+		parts = append(parts, d.Path)
+	}
+	parts = append(parts, string(d.Kind), d.Message)
+	return strings.Join(parts, ": ")
+}
+
+// lintOptions holds the settings of a lint run.
+type lintOptions struct {
+	bootstrap   bool
+	envPrefixes []string
+}
+
+// WithBootstrap treats the path as a bootstrap file whose sources are loaded,
+// instead of a configuration file.
+func WithBootstrap() options.Option {
+	return optionutil.Update(func(o *lintOptions) {
+		o.bootstrap = true
+	})
+}
+
+// WithEnvPrefixes loads the environment variables with the given prefixes on top of a
+// configuration file, like the env source does at runtime.
+func WithEnvPrefixes(prefixes ...string) options.Option {
+	return optionutil.Update(func(o *lintOptions) {
+		o.envPrefixes = append(o.envPrefixes, prefixes...)
+	})
+}
+
+// Lint loads the configuration at path and checks it against the message described by md.
+// The returned error reports failures to load the configuration, problems found in the
+// configuration itself are returned as diagnostics.
+func Lint(path string, md protoreflect.MessageDescriptor, opts ...options.Option) ([]Diagnostic, error) {
+	lintOpts := optionutil.NewT[lintOptions](opts...)
+	data, files, err := load(path, lintOpts)
+	if err != nil {
+		return nil, err
+	}
+	positions := newPositionIndex()
+	for _, f := range files {
+		positions.add(f)
+	}
+
+	var diags []Diagnostic
+	report := func(path string, kind Kind, format string, args ...any) {
+		diags = append(diags, Diagnostic{
+			Position: positions.lookup(path),
+			Path:     path,
+			Kind:     kind,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+	checkMessage(md, data, "", report)
+
+	if len(diags) == 0 {
+		msg := newMessage(md)
+		raw, err := json.Marshal(data)
+		if err != nil {
+			return nil, err
+		}
+		if err := protojson.Unmarshal(raw, msg); err != nil {
+			report("", KindDecode, "%v", err)
+		} else {
+			validateMessage(msg, report)
+		}
+	}
+
+	sort.SliceStable(diags, func(i, j int) bool {
+		a, b := diags[i].Position, diags[j].Position
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Column < b.Column
+	})
+	return diags, nil
+}
+
+// newMessage returns a generated message for md when one is registered, so that the
+// legacy Validate methods are available, or a dynamic message otherwise.
+func newMessage(md protoreflect.MessageDescriptor) proto.Message {
+	if mt, err := protoregistry.GlobalTypes.FindMessageByName(md.FullName()); err == nil && mt.Descriptor() == md {
+		return mt.New().Interface()
+	}
+	return dynamicpb.NewMessage(md)
+}
+
+// load reads the configuration through the runtime config pipeline and returns the
+// merged data together with the files it came from, in merge order.
+func load(path string, lintOpts *lintOptions) (map[string]any, []string, error) {
+	var providerOpts []bootstrap.Option
+	if lintOpts.bootstrap {
+		providerOpts = append(providerOpts, bootstrap.WithFrameworkOptions(envsource.WithPrefixes(lintOpts.envPrefixes...)))
+	} else {
+		providerOpts = append(providerOpts, bootstrap.WithDirectly(true))
+		if len(lintOpts.envPrefixes) > 0 {
+			providerOpts = append(providerOpts,
+				bootstrap.WithEnvSource(),
+				bootstrap.WithFrameworkOptions(envsource.WithPrefixes(lintOpts.envPrefixes...)),
+			)
+		}
+	}
+	bc, cfg, err := bootstrap.LoadConfig(path, bootstrap.FromOptions(providerOpts...))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer cfg.Close()
+
+	data := make(map[string]any)
+	if err := cfg.Scan(&data); err != nil {
+		return nil, nil, err
+	}
+
+	var sources []*sourcev1.SourceConfig
+	if bc != nil {
+		sources = bc.GetSources()
+	} else {
+		sources = []*sourcev1.SourceConfig{bootstrap.SourceWithFile(path)}
+	}
+	var files []string
+	for _, src := range sources {
+		if src.GetType() != string(runtimeconfig.SourceTypeFile) || src.GetFile() == nil {
+			continue
+		}
+		loaded, err := sourceFiles(src)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, loaded...)
+	}
+	return data, files, nil
+}
+
+// sourceFiles returns the files a file source loads, including the included ones.
+func sourceFiles(src *sourcev1.SourceConfig) ([]string, error) {
+	source, err := file.NewFileSource(src)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := source.Load(); err != nil {
+		return nil, err
+	}
+	if lister, ok := source.(interface{ Files() []string }); ok {
+		return lister.Files(), nil
+	}
+	abs, err := filepath.Abs(src.GetFile().GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return []string{abs}, nil
+}
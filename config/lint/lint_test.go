@@ -0,0 +1,95 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	jwtv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1"
+	transportv1 "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+	return path
+}
+
+func TestLintStructure(t *testing.T) {
+	path := writeFile(t, "servers.yaml", `configs:
+  - name: api
+    protocol: http
+    http:
+      addr: ":8000"
+      enable_pprof: "yes"
+      timeout: 3
+  - name: rpc
+    grpcc:
+      addr: ":9000"
+`)
+	diags, err := Lint(path, (&transportv1.Servers{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+	require.Len(t, diags, 3)
+
+	assert.Equal(t, "configs[0].http.enable_pprof", diags[0].Path)
+	assert.Equal(t, KindTypeMismatch, diags[0].Kind)
+	assert.Equal(t, Position{File: path, Line: 6, Column: 7}, diags[0].Position)
+
+	assert.Equal(t, "configs[0].http.timeout", diags[1].Path)
+	assert.Equal(t, KindTypeMismatch, diags[1].Kind)
+	assert.Equal(t, 7, diags[1].Position.Line)
+
+	assert.Equal(t, "configs[1].grpcc", diags[2].Path)
+	assert.Equal(t, KindUnknownField, diags[2].Kind)
+	assert.Equal(t, 9, diags[2].Position.Line)
+}
+
+func TestLintRules(t *testing.T) {
+	path := writeFile(t, "jwt.json", `{
+  "claim_type": "default",
+  "config": {
+    "signing_method": "hs256",
+    "signing_key": "secret",
+    "access_token_lifetime": 0,
+    "refresh_token_lifetime": "7200",
+    "audience": ["web"]
+  }
+}`)
+	diags, err := Lint(path, (&jwtv1.JWT{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+	require.Len(t, diags, 2)
+
+	assert.Equal(t, "config.signing_method", diags[0].Path)
+	assert.Equal(t, KindViolation, diags[0].Kind)
+	assert.Equal(t, 4, diags[0].Position.Line)
+
+	assert.Equal(t, "config.access_token_lifetime", diags[1].Path)
+	assert.Equal(t, KindViolation, diags[1].Kind)
+	assert.Equal(t, 6, diags[1].Position.Line)
+}
+
+func TestLintValid(t *testing.T) {
+	path := writeFile(t, "servers.yaml", `configs:
+  - name: api
+    protocol: http
+    http:
+      addr: ":8000"
+      timeout: 3s
+`)
+	diags, err := Lint(path, (&transportv1.Servers{}).ProtoReflect().Descriptor())
+	require.NoError(t, err)
+	assert.Empty(t, diags)
+}
+
+func TestCanonicalPath(t *testing.T) {
+	assert.Equal(t, []string{"servers", "configs", "[0]", "tlsstore", "my.key"},
+		canonicalPath(`servers.configs[0].tls_store["My.Key"]`))
+}
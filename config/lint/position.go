@@ -0,0 +1,116 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package lint
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// positionIndex maps field paths to the place in the configuration files where they
+// are defined. Files added later override earlier ones, like the merge does.
+type positionIndex struct {
+	positions map[string]Position
+}
+
+func newPositionIndex() *positionIndex {
+	return &positionIndex{positions: make(map[string]Position)}
+}
+
+// add indexes the keys of a YAML or JSON file. Files in other formats are skipped,
+// their diagnostics are reported without a position.
+func (idx *positionIndex) add(file string) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return
+	}
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err != nil || len(doc.Content) == 0 {
+		return
+	}
+	idx.walk(file, doc.Content[0], nil)
+}
+
+func (idx *positionIndex) walk(file string, node *yaml.Node, path []string) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			child := append(append([]string(nil), path...), canonicalName(key.Value))
+			idx.set(file, child, key)
+			idx.walk(file, value, child)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			child := append(append([]string(nil), path...), "["+strconv.Itoa(i)+"]")
+			idx.set(file, child, item)
+			idx.walk(file, item, child)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			idx.walk(file, node.Alias, path)
+		}
+	}
+}
+
+func (idx *positionIndex) set(file string, path []string, node *yaml.Node) {
+	idx.positions[strings.Join(path, ".")] = Position{File: file, Line: node.Line, Column: node.Column}
+}
+
+// lookup returns the position of path, or of its closest indexed parent when the
+// path itself does not appear in any file, e.g. because it was set by the environment.
+func (idx *positionIndex) lookup(path string) Position {
+	segments := canonicalPath(path)
+	for i := len(segments); i > 0; i-- {
+		if pos, ok := idx.positions[strings.Join(segments[:i], ".")]; ok {
+			return pos
+		}
+	}
+	return Position{}
+}
+
+// canonicalPath splits a field path such as `servers.configs[0].tls_store["key"]` into
+// canonical segments, so that it matches the keys of a file whatever their spelling.
+func canonicalPath(path string) []string {
+	var segments []string
+	for path != "" {
+		switch path[0] {
+		case '.':
+			path = path[1:]
+		case '[':
+			end := strings.IndexByte(path, ']')
+			if len(path) > 1 && path[1] == '"' {
+				// Map keys are quoted and may contain brackets.
+				if key, err := strconv.QuotedPrefix(path[1:]); err == nil {
+					value, _ := strconv.Unquote(key)
+					segments = append(segments, canonicalName(value))
+					path = strings.TrimPrefix(path[1+len(key):], "]")
+					continue
+				}
+			}
+			if end < 0 {
+				return append(segments, canonicalName(path))
+			}
+			segments = append(segments, path[:end+1])
+			path = path[end+1:]
+		default:
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, canonicalName(path[:end]))
+			path = path[end:]
+		}
+	}
+	return segments
+}
+
+// canonicalName folds the proto, JSON and Go spellings of a field name together.
+func canonicalName(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,112 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package lint
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+
+	"buf.build/go/protovalidate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// validateMessage checks msg against its protovalidate rules and, for generated
+// messages, against the protoc-gen-validate rules of its ValidateAll method.
+func validateMessage(msg proto.Message, report reporter) {
+	if err := protovalidate.Validate(msg); err != nil {
+		var verr *protovalidate.ValidationError
+		if !errors.As(err, &verr) {
+			report("", KindViolation, "%v", err)
+		} else {
+			for _, v := range verr.Violations {
+				message := v.Proto.GetMessage()
+				if id := v.Proto.GetRuleId(); id != "" {
+					message += " [" + id + "]"
+				}
+				report(protovalidate.FieldPathString(v.Proto.GetField()), KindViolation, "%s", message)
+			}
+		}
+	}
+	if v, ok := msg.(interface{ ValidateAll() error }); ok {
+		if err := v.ValidateAll(); err != nil {
+			reportLegacy(msg.ProtoReflect().Descriptor(), "", err, report)
+		}
+	}
+}
+
+// legacyError is the error generated by protoc-gen-validate for a single field.
+type legacyError interface {
+	Field() string
+	Reason() string
+	Cause() error
+}
+
+// reportLegacy flattens the errors returned by protoc-gen-validate, which nest the
+// errors of embedded messages in their cause.
+func reportLegacy(md protoreflect.MessageDescriptor, path string, err error, report reporter) {
+	if multi, ok := err.(interface{ AllErrors() []error }); ok {
+		for _, e := range multi.AllErrors() {
+			reportLegacy(md, path, e, report)
+		}
+		return
+	}
+	ferr, ok := err.(legacyError)
+	if !ok {
+		report(path, KindViolation, "%v", err)
+		return
+	}
+	fieldPath, next := legacyPath(md, ferr.Field())
+	fieldPath = joinPath(path, fieldPath)
+	if cause := ferr.Cause(); cause != nil && next != nil {
+		switch cause.(type) {
+		case legacyError, interface{ AllErrors() []error }:
+			reportLegacy(next, fieldPath, cause, report)
+			return
+		}
+	}
+	message := ferr.Reason()
+	if cause := ferr.Cause(); cause != nil {
+		message += ": " + cause.Error()
+	}
+	report(fieldPath, KindViolation, "%s", message)
+}
+
+// legacyPath converts a protoc-gen-validate field such as "TlsStore[key]" into the
+// protovalidate form `tls_store["key"]`, and returns the descriptor of the embedded
+// message, if any.
+func legacyPath(md protoreflect.MessageDescriptor, field string) (string, protoreflect.MessageDescriptor) {
+	name, suffix, indexed := strings.Cut(field, "[")
+	var fd protoreflect.FieldDescriptor
+	fields := md.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		if canonicalName(string(fields.Get(i).Name())) == canonicalName(name) {
+			fd = fields.Get(i)
+			break
+		}
+	}
+	if fd == nil {
+		return field, nil
+	}
+	path := string(fd.Name())
+	if indexed {
+		key := strings.TrimSuffix(suffix, "]")
+		if fd.IsMap() {
+			path += "[" + strconv.Quote(key) + "]"
+		} else {
+			path += "[" + key + "]"
+		}
+	}
+	switch {
+	case fd.IsMap():
+		if fd.MapValue().Kind() == protoreflect.MessageKind {
+			return path, fd.MapValue().Message()
+		}
+	case fd.Kind() == protoreflect.MessageKind:
+		return path, fd.Message()
+	}
+	return path, nil
+}
@@ -0,0 +1,140 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"dario.cat/mergo"
+)
+
+// indexKeyRegexp matches the "[n]" keys used by sources to patch a single element of a list.
+var indexKeyRegexp = regexp.MustCompile(`^\[(\d+)\]$`)
+
+// IndexKey returns the map key that addresses the i-th element of a list when merging.
+// A map whose keys are all index keys is applied element-wise to the list at the same
+// position in the merged configuration instead of replacing it.
+func IndexKey(i int) string {
+	return "[" + strconv.Itoa(i) + "]"
+}
+
+// Merge is the merge function used by configurations created through the Builder.
+// It behaves like the Kratos default merge (maps are merged recursively, other values
+// are overridden) and additionally understands list patches keyed by IndexKey, which
+// allows a source to override `servers.configs[0].http.addr` without replacing the
+// whole list.
+func Merge(dst, src any) error {
+	target, ok := dst.(*map[string]any)
+	if !ok {
+		return mergo.Map(dst, src, mergo.WithOverride)
+	}
+	patch, ok := src.(map[string]any)
+	if !ok {
+		return mergo.Map(dst, src, mergo.WithOverride)
+	}
+	if *target == nil {
+		*target = make(map[string]any)
+	}
+	if err := applyIndexPatches(*target, patch); err != nil {
+		return err
+	}
+	return mergo.Map(dst, patch, mergo.WithOverride)
+}
+
+// applyIndexPatches rewrites every list patch found in src into a complete list,
+// built from the matching list in dst, so that the regular map merge can take over.
+func applyIndexPatches(dst, src map[string]any) error {
+	for key, value := range src {
+		patch, ok := value.(map[string]any)
+		if !ok {
+			continue
+		}
+		if isIndexPatch(patch) {
+			list, err := patchList(dst[key], patch)
+			if err != nil {
+				return err
+			}
+			src[key] = list
+			continue
+		}
+		next, _ := dst[key].(map[string]any)
+		if next == nil {
+			next = make(map[string]any)
+		}
+		if err := applyIndexPatches(next, patch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchList applies an index patch to a copy of the given list. A patch may modify the
+// elements of the list or append to it, an index beyond its end is rejected.
+func patchList(current any, patch map[string]any) ([]any, error) {
+	origin, _ := current.([]any)
+	list := make([]any, len(origin), len(origin)+len(patch))
+	copy(list, origin)
+	indexes := make([]int, 0, len(patch))
+	values := make(map[int]any, len(patch))
+	for key, value := range patch {
+		index, err := strconv.Atoi(indexKeyRegexp.FindStringSubmatch(key)[1])
+		if err != nil {
+			return nil, fmt.Errorf("invalid list index %s: %w", key, err)
+		}
+		indexes = append(indexes, index)
+		values[index] = value
+	}
+	// Apply the patches in order so that consecutive indexes append to the list.
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		if index > len(list) {
+			return nil, fmt.Errorf("list index %d out of range, the list has %d elements", index, len(list))
+		}
+		value := values[index]
+		elemPatch, ok := value.(map[string]any)
+		if !ok {
+			list = setElem(list, index, value)
+			continue
+		}
+		elem := make(map[string]any)
+		if index < len(list) {
+			if existing, ok := list[index].(map[string]any); ok {
+				for k, v := range existing {
+					elem[k] = v
+				}
+			}
+		}
+		if err := Merge(&elem, elemPatch); err != nil {
+			return nil, err
+		}
+		list = setElem(list, index, elem)
+	}
+	return list, nil
+}
+
+// setElem sets the element of list at index, appending it when index is the length of list.
+func setElem(list []any, index int, value any) []any {
+	if index == len(list) {
+		return append(list, value)
+	}
+	list[index] = value
+	return list
+}
+
+// isIndexPatch reports whether every key of m is an index key.
+func isIndexPatch(m map[string]any) bool {
+	if len(m) == 0 {
+		return false
+	}
+	for key := range m {
+		if !indexKeyRegexp.MatchString(key) {
+			return false
+		}
+	}
+	return true
+}
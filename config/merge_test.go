@@ -0,0 +1,39 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	runtimeconfig "github.com/origadmin/runtime/config"
+)
+
+func TestMergeIndexPatch(t *testing.T) {
+	dst := map[string]any{"list": []any{map[string]any{"addr": ":8000", "name": "http"}}}
+	require.NoError(t, runtimeconfig.Merge(&dst, map[string]any{"list": map[string]any{
+		runtimeconfig.IndexKey(0): map[string]any{"addr": ":9000"},
+		runtimeconfig.IndexKey(2): map[string]any{"addr": ":9002"},
+		runtimeconfig.IndexKey(1): map[string]any{"addr": ":9001"},
+	}}))
+	assert.Equal(t, []any{
+		map[string]any{"addr": ":9000", "name": "http"},
+		map[string]any{"addr": ":9001"},
+		map[string]any{"addr": ":9002"},
+	}, dst["list"])
+
+	scalars := map[string]any{"tags": []any{"a"}}
+	require.NoError(t, runtimeconfig.Merge(&scalars, map[string]any{"tags": map[string]any{runtimeconfig.IndexKey(1): "b"}}))
+	assert.Equal(t, []any{"a", "b"}, scalars["tags"])
+}
+
+func TestMergeIndexPatchOutOfRange(t *testing.T) {
+	dst := map[string]any{"tags": []any{"a"}}
+	err := runtimeconfig.Merge(&dst, map[string]any{"tags": map[string]any{runtimeconfig.IndexKey(999999999): "b"}})
+	assert.ErrorContains(t, err, "out of range")
+	assert.Equal(t, []any{"a"}, dst["tags"])
+}
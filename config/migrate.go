@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package config
+
+import (
+	"fmt"
+
+	"github.com/go-kratos/kratos/v2/encoding"
+	"github.com/go-kratos/kratos/v2/encoding/json"
+
+	"github.com/origadmin/runtime/config/migration"
+	"github.com/origadmin/runtime/log"
+)
+
+// migratingSource is a source whose loaded values are migrated from version to the latest
+// registered version once per load, before they are merged, warning about the deprecated
+// keys they still set. It wraps the file sources only: the other sources, such as the
+// environment and the flags, are written in the latest version.
+type migratingSource struct {
+	KSource
+	registry *migration.Registry
+	version  string
+	logger   *log.Helper
+}
+
+// newMigratingSource returns source migrating its values from version.
+func newMigratingSource(source KSource, registry *migration.Registry, version string, logger *log.Helper) KSource {
+	return &migratingSource{KSource: source, registry: registry, version: version, logger: logger}
+}
+
+// Load loads and migrates the values of the source.
+func (s *migratingSource) Load() ([]*KKeyValue, error) {
+	kvs, err := s.KSource.Load()
+	if err != nil {
+		return nil, err
+	}
+	return s.migrate(kvs)
+}
+
+// Watch watches the source, migrating the changed values.
+func (s *migratingSource) Watch() (KWatcher, error) {
+	w, err := s.KSource.Watch()
+	if err != nil {
+		return nil, err
+	}
+	return &migratingWatcher{KWatcher: w, source: s}, nil
+}
+
+// migrate migrates each value of kvs, re-encoded as JSON when it has changed.
+func (s *migratingSource) migrate(kvs []*KKeyValue) ([]*KKeyValue, error) {
+	codec := encoding.GetCodec(json.Name)
+	migrated := make([]*KKeyValue, 0, len(kvs))
+	for _, kv := range kvs {
+		decoder := encoding.GetCodec(kv.Format)
+		if decoder == nil {
+			migrated = append(migrated, kv)
+			continue
+		}
+		tree := make(map[string]any)
+		if err := decoder.Unmarshal(kv.Value, &tree); err != nil {
+			return nil, fmt.Errorf("migrate %s: %w", kv.Key, err)
+		}
+		to, err := s.registry.Migrate(tree, s.version)
+		if err != nil {
+			return nil, fmt.Errorf("migrate %s: %w", kv.Key, err)
+		}
+		for _, d := range s.registry.Deprecated(tree) {
+			s.logger.Warnf("%s: %s", kv.Key, d)
+		}
+		if to == s.version {
+			migrated = append(migrated, kv)
+			continue
+		}
+		value, err := codec.Marshal(tree)
+		if err != nil {
+			return nil, fmt.Errorf("migrate %s: %w", kv.Key, err)
+		}
+		migrated = append(migrated, &KKeyValue{Key: kv.Key, Value: value, Format: json.Name})
+	}
+	return migrated, nil
+}
+
+// migratingWatcher is the watcher of a migratingSource.
+type migratingWatcher struct {
+	KWatcher
+	source *migratingSource
+}
+
+// Next returns the next changed values, migrated.
+func (w *migratingWatcher) Next() ([]*KKeyValue, error) {
+	kvs, err := w.KWatcher.Next()
+	if err != nil {
+		return nil, err
+	}
+	return w.source.migrate(kvs)
+}
@@ -0,0 +1,90 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package config_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	runtimeconfig "github.com/origadmin/runtime/config"
+	_ "github.com/origadmin/runtime/config/file"
+	"github.com/origadmin/runtime/config/migration"
+)
+
+func TestNewConfigMigrates(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  addr: \":8000\"\n"), 0o644))
+
+	registry := migration.NewRegistry()
+	registry.Register("v1", "v2", migration.Rename("server.addr", "server.http.addr"))
+
+	cfg, err := runtimeconfig.New(&sourcev1.Sources{
+		Version: "v1",
+		Configs: []*sourcev1.SourceConfig{{Type: "file", File: &sourcev1.FileSource{Path: path}}},
+	}, runtimeconfig.WithMigrations(registry))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Load())
+	defer cfg.Close()
+
+	addr, err := cfg.Value("server.http.addr").String()
+	require.NoError(t, err)
+	assert.Equal(t, ":8000", addr)
+	_, err = cfg.Value("server.addr").String()
+	assert.Error(t, err)
+}
+
+// staticSource is a source of a fixed JSON value.
+type staticSource struct {
+	value string
+	done  chan struct{}
+}
+
+func (s *staticSource) Load() ([]*kratosconfig.KeyValue, error) {
+	return []*kratosconfig.KeyValue{{Key: "static", Value: []byte(s.value), Format: "json"}}, nil
+}
+
+func (s *staticSource) Watch() (kratosconfig.Watcher, error) { return s, nil }
+
+func (s *staticSource) Next() ([]*kratosconfig.KeyValue, error) {
+	<-s.done
+	return nil, context.Canceled
+}
+
+func (s *staticSource) Stop() error {
+	close(s.done)
+	return nil
+}
+
+func TestNewConfigMigratesFilesOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("server:\n  addr: \":8000\"\n"), 0o644))
+
+	registry := migration.NewRegistry()
+	registry.Register("v1", "v2", migration.Rename("server.addr", "server.http.addr"))
+
+	// The override is written in the latest version, where server.addr is another key.
+	override := &staticSource{value: `{"server": {"addr": ":9000"}}`, done: make(chan struct{})}
+	cfg, err := runtimeconfig.New(&sourcev1.Sources{
+		Version: "v1",
+		Configs: []*sourcev1.SourceConfig{{Type: "file", File: &sourcev1.FileSource{Path: path}}},
+	}, runtimeconfig.WithMigrations(registry), runtimeconfig.WithSource(override))
+	require.NoError(t, err)
+	require.NoError(t, cfg.Load())
+	defer cfg.Close()
+
+	addr, err := cfg.Value("server.http.addr").String()
+	require.NoError(t, err)
+	assert.Equal(t, ":8000", addr)
+	addr, err = cfg.Value("server.addr").String()
+	require.NoError(t, err)
+	assert.Equal(t, ":9000", addr)
+}
@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package migratecmd implements a command rewriting configuration files to the latest
+// schema version of a migration registry.
+//
+// The migrations are those of an application, which builds the command with the
+// packages registering them imported:
+//
+//	func main() {
+//		os.Exit(migratecmd.Run(migration.Default(), os.Args[1:]))
+//	}
+//
+// Usage:
+//
+//	config-migrate -from v1 [-w] FILE...
+//	config-migrate -bootstrap bootstrap.yaml [-w]
+//
+// With -bootstrap, the YAML and JSON files of the file sources listed by the bootstrap
+// file, including the files they include, are migrated from the version it declares, and its version is
+// updated. Without -w the migrated files are printed instead of written.
+package migratecmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"google.golang.org/protobuf/proto"
+	"gopkg.in/yaml.v3"
+
+	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
+	"github.com/origadmin/runtime/config/file"
+	"github.com/origadmin/runtime/config/migration"
+	"github.com/origadmin/runtime/engine/bootstrap"
+)
+
+// Run runs the command with args, the arguments without the program name, migrating the
+// files with the migrations of registry. It returns the exit code of the command.
+func Run(registry *migration.Registry, args []string) int {
+	fs := flag.NewFlagSet("config-migrate", flag.ContinueOnError)
+	from := fs.String("from", "", "version the files were written for")
+	bootstrapPath := fs.String("bootstrap", "", "bootstrap file declaring the version and the files to migrate")
+	write := fs.Bool("w", false, "write the migrated files instead of printing them")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	files := fs.Args()
+	version := *from
+	if *bootstrapPath != "" {
+		bc, err := bootstrap.LoadBootstrapConfig(*bootstrapPath)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "config-migrate:", err)
+			return 2
+		}
+		if version == "" {
+			version = bc.GetVersion()
+		}
+		sourceFiles, err := bootstrapFiles(*bootstrapPath, bc.GetSources())
+		if err != nil {
+			fmt.Fprintln(os.Stderr, "config-migrate:", err)
+			return 2
+		}
+		files = append(files, sourceFiles...)
+	}
+	if version == "" || len(files) == 0 {
+		fmt.Fprintln(fs.Output(), "usage: config-migrate -from VERSION [-w] FILE... | -bootstrap FILE [-w]")
+		fs.PrintDefaults()
+		return 2
+	}
+
+	latest := registry.Latest(version)
+	if latest == version {
+		fmt.Fprintf(os.Stderr, "config-migrate: version %s is the latest, nothing to do\n", version)
+		return 0
+	}
+	for _, file := range files {
+		if err := migrate(registry, file, version, *write); err != nil {
+			fmt.Fprintln(os.Stderr, "config-migrate:", err)
+			return 1
+		}
+	}
+	if *bootstrapPath != "" && *write {
+		if err := setVersion(*bootstrapPath, latest); err != nil {
+			fmt.Fprintln(os.Stderr, "config-migrate:", err)
+			return 1
+		}
+	}
+	fmt.Fprintf(os.Stderr, "config-migrate: migrated %d file(s) from %s to %s\n", len(files), version, latest)
+	return 0
+}
+
+func migrate(registry *migration.Registry, file, version string, write bool) error {
+	if write {
+		_, err := registry.RewriteFile(file, version)
+		return err
+	}
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return err
+	}
+	out, _, err := registry.Rewrite(content, migration.Format(file), version)
+	if err != nil {
+		return fmt.Errorf("%s: %w", file, err)
+	}
+	fmt.Printf("# %s\n%s", file, out)
+	return nil
+}
+
+// bootstrapFiles returns the YAML and JSON files loaded by the file sources of a
+// bootstrap file, resolved relative to it, along with the files they include.
+func bootstrapFiles(bootstrapPath string, sources []*sourcev1.SourceConfig) ([]string, error) {
+	var files []string
+	dir := filepath.Dir(bootstrapPath)
+	for _, src := range sources {
+		if src.GetType() != "file" || src.GetFile() == nil {
+			continue
+		}
+		if path := src.GetFile().GetPath(); !filepath.IsAbs(path) {
+			src = proto.CloneOf(src)
+			src.File.Path = filepath.Join(dir, path)
+		}
+		source, err := file.NewFileSource(src)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := source.Load(); err != nil {
+			return nil, err
+		}
+		lister, ok := source.(interface{ Files() []string })
+		if !ok {
+			continue
+		}
+		for _, path := range lister.Files() {
+			switch migration.Format(path) {
+			case "yaml", "yml", "json":
+				files = append(files, path)
+			}
+		}
+	}
+	return files, nil
+}
+
+// setVersion updates the version declared by a bootstrap file. YAML files are edited
+// in place so that their comments are kept.
+func setVersion(path, version string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	var out []byte
+	if migration.Format(path) == "json" {
+		tree := make(map[string]any)
+		if err := json.Unmarshal(content, &tree); err != nil {
+			return err
+		}
+		tree["version"] = version
+		if out, err = json.MarshalIndent(tree, "", "  "); err != nil {
+			return err
+		}
+		out = append(out, '\n')
+	} else {
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return err
+		}
+		if len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+			return fmt.Errorf("%s: not a mapping", path)
+		}
+		root := doc.Content[0]
+		found := false
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			if root.Content[i].Value == "version" {
+				root.Content[i+1].SetString(version)
+				found = true
+			}
+		}
+		if !found {
+			key, value := &yaml.Node{}, &yaml.Node{}
+			key.SetString("version")
+			value.SetString(version)
+			root.Content = append([]*yaml.Node{key, value}, root.Content...)
+		}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(2)
+		if err := enc.Encode(&doc); err != nil {
+			return err
+		}
+		out = buf.Bytes()
+	}
+	return os.WriteFile(path, out, info.Mode().Perm())
+}
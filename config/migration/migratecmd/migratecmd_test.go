@@ -0,0 +1,57 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package migratecmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/origadmin/runtime/config/migration"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestRunBootstrap(t *testing.T) {
+	dir := t.TempDir()
+	bootstrapPath := filepath.Join(dir, "bootstrap.yaml")
+	writeFile(t, bootstrapPath, `version: v1
+sources:
+  - type: file
+    file:
+      path: configs
+`)
+	writeFile(t, filepath.Join(dir, "configs", "app.yaml"), `include:
+  - extra/*.yaml
+name: app
+`)
+	included := filepath.Join(dir, "configs", "extra", "server.yaml")
+	writeFile(t, included, `server:
+  addr: ":8000"
+`)
+
+	registry := migration.NewRegistry()
+	registry.Register("v1", "v2", migration.Rename("server.addr", "server.http.addr"))
+	require.Equal(t, 0, Run(registry, []string{"-bootstrap", bootstrapPath, "-w"}))
+
+	// The files included by those of the sources are migrated too.
+	content, err := os.ReadFile(included)
+	require.NoError(t, err)
+	assert.YAMLEq(t, "server:\n  http:\n    addr: \":8000\"\n", string(content))
+	content, err = os.ReadFile(bootstrapPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "version: v2")
+}
+
+func TestRunUsage(t *testing.T) {
+	assert.Equal(t, 2, Run(migration.NewRegistry(), nil))
+}
@@ -0,0 +1,179 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package migration upgrades configuration files written for an older schema version.
+//
+// Migrations are registered by the version they upgrade from and to, and rewrite the raw
+// configuration tree before it is decoded. A chain of migrations is applied from the
+// version declared by the configuration up to the latest registered version:
+//
+//	func init() {
+//		migration.Register("v1", "v2", migration.Rename("gateway.hosts", "gateway.host"))
+//		migration.Deprecate("server.http.timeout_ms", "use server.http.timeout")
+//	}
+//
+// Registry.Rewrite and Registry.RewriteFile migrate the files themselves, package
+// migratecmd builds a command around them for the migrations of an application.
+package migration
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Func rewrites a raw configuration tree in place. The tree may hold only part of the
+// configuration, e.g. a single file or the values of the environment, so a migration
+// must leave missing keys alone.
+type Func func(tree map[string]any) error
+
+// Deprecation is a deprecated configuration key found in a tree.
+type Deprecation struct {
+	// Path is the dotted path of the key, with list indexes in brackets.
+	Path string
+	// Hint tells what to use instead.
+	Hint string
+}
+
+// String formats the deprecation as a warning message.
+func (d Deprecation) String() string {
+	if d.Hint == "" {
+		return fmt.Sprintf("config key %q is deprecated", d.Path)
+	}
+	return fmt.Sprintf("config key %q is deprecated: %s", d.Path, d.Hint)
+}
+
+type migration struct {
+	to string
+	fn Func
+}
+
+// Registry holds the migrations between configuration versions.
+type Registry struct {
+	mu           sync.RWMutex
+	migrations   map[string]migration
+	deprecations map[string]string
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		migrations:   make(map[string]migration),
+		deprecations: make(map[string]string),
+	}
+}
+
+// Register adds the migration from version from to version to. Only one migration may
+// start from a version, and migrations must not form a cycle; Register panics otherwise,
+// as registrations happen at init time.
+func (r *Registry) Register(from, to string, fn Func) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if from == "" || to == "" || from == to {
+		panic(fmt.Sprintf("migration: invalid migration from %q to %q", from, to))
+	}
+	if m, ok := r.migrations[from]; ok {
+		panic(fmt.Sprintf("migration: a migration from %q to %q is already registered", from, m.to))
+	}
+	for v, ok := to, true; ok; {
+		if v == from {
+			panic(fmt.Sprintf("migration: migration from %q to %q creates a cycle", from, to))
+		}
+		var m migration
+		m, ok = r.migrations[v]
+		v = m.to
+	}
+	r.migrations[from] = migration{to: to, fn: fn}
+}
+
+// Deprecate marks a configuration key as deprecated. The path is dotted, "*" matches any
+// map key or list element. Deprecated keys still present after the migrations are
+// reported by Deprecated.
+func (r *Registry) Deprecate(path, hint string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deprecations[path] = hint
+}
+
+// Latest returns the version reached by applying all migrations starting at from.
+func (r *Registry) Latest(from string) string {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for {
+		m, ok := r.migrations[from]
+		if !ok {
+			return from
+		}
+		from = m.to
+	}
+}
+
+// Known reports whether a migration starts or ends at version.
+func (r *Registry) Known(version string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if _, ok := r.migrations[version]; ok {
+		return true
+	}
+	for _, m := range r.migrations {
+		if m.to == version {
+			return true
+		}
+	}
+	return false
+}
+
+// Migrate applies the migrations from version from to the latest version and returns
+// the version reached. Nothing is done when from is empty.
+func (r *Registry) Migrate(tree map[string]any, from string) (string, error) {
+	if from == "" {
+		return from, nil
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for {
+		m, ok := r.migrations[from]
+		if !ok {
+			return from, nil
+		}
+		if err := m.fn(tree); err != nil {
+			return from, fmt.Errorf("migration: failed to migrate config from %s to %s: %w", from, m.to, err)
+		}
+		from = m.to
+	}
+}
+
+// Deprecated returns the deprecated keys present in tree, sorted by path.
+func (r *Registry) Deprecated(tree map[string]any) []Deprecation {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var found []Deprecation
+	for pattern, hint := range r.deprecations {
+		for _, path := range match(tree, splitPath(pattern), nil) {
+			found = append(found, Deprecation{Path: formatPath(path), Hint: hint})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Path < found[j].Path
+	})
+	return found
+}
+
+// defaultRegistry is the package-level registry used by the configuration loader.
+var defaultRegistry = NewRegistry()
+
+// Default returns the package-level registry.
+func Default() *Registry {
+	return defaultRegistry
+}
+
+// Register adds a migration to the package-level registry.
+func Register(from, to string, fn Func) {
+	defaultRegistry.Register(from, to, fn)
+}
+
+// Deprecate marks a configuration key as deprecated in the package-level registry.
+func Deprecate(path, hint string) {
+	defaultRegistry.Deprecate(path, hint)
+}
@@ -0,0 +1,101 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package migration
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	configv1 "github.com/origadmin/runtime/api/gen/go/config/config/v1"
+)
+
+func TestRegistryMigrate(t *testing.T) {
+	r := NewRegistry()
+	r.Register("v1", "v2", Rename("server.addr", "server.http.addr"))
+	r.Register("v2", "v3", Chain(
+		Rename("servers[*].timeout_ms", "servers[*].timeout"),
+		Delete("legacy"),
+	))
+
+	tree := map[string]any{
+		"server": map[string]any{"addr": ":8000"},
+		"servers": []any{
+			map[string]any{"name": "a", "timeout_ms": 100.0},
+			map[string]any{"name": "b"},
+		},
+		"legacy": true,
+	}
+	version, err := r.Migrate(tree, "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v3", version)
+	assert.Equal(t, map[string]any{
+		"server": map[string]any{"http": map[string]any{"addr": ":8000"}},
+		"servers": []any{
+			map[string]any{"name": "a", "timeout": 100.0},
+			map[string]any{"name": "b"},
+		},
+	}, tree)
+
+	assert.Equal(t, "v3", r.Latest("v2"))
+	assert.True(t, r.Known("v3"))
+	assert.False(t, r.Known("v0"))
+
+	version, err = r.Migrate(tree, "v3")
+	require.NoError(t, err)
+	assert.Equal(t, "v3", version)
+}
+
+func TestRegistryRegisterInvalid(t *testing.T) {
+	r := NewRegistry()
+	r.Register("v1", "v2", Delete("a"))
+	assert.Panics(t, func() { r.Register("v1", "v3", Delete("a")) })
+	assert.Panics(t, func() { r.Register("v2", "v1", Delete("a")) })
+	assert.Panics(t, func() { r.Register("v2", "v2", Delete("a")) })
+}
+
+func TestRenameKeepsNewKey(t *testing.T) {
+	tree := map[string]any{"old": 1, "new": 2}
+	require.NoError(t, Rename("old", "new")(tree))
+	assert.Equal(t, map[string]any{"new": 2}, tree)
+}
+
+func TestRegistryDeprecated(t *testing.T) {
+	r := NewRegistry()
+	r.Deprecate("gateways.*.hosts", "use endpoints[].host")
+	tree := map[string]any{
+		"gateways": map[string]any{
+			"public":   map[string]any{"hosts": []any{"a"}},
+			"internal": map[string]any{"name": "b"},
+		},
+	}
+	assert.Equal(t, []Deprecation{{Path: "gateways.public.hosts", Hint: "use endpoints[].host"}}, r.Deprecated(tree))
+}
+
+func TestDeprecatedFields(t *testing.T) {
+	tree := map[string]any{
+		"name":  "gw",
+		"hosts": []any{"example.com"},
+	}
+	found := DeprecatedFields((&configv1.Gateway{}).ProtoReflect().Descriptor(), tree)
+	require.Len(t, found, 1)
+	assert.Equal(t, "hosts", found[0].Path)
+	assert.Equal(t, "Use host in Endpoint instead", found[0].Hint)
+	assert.Equal(t, `config key "hosts" is deprecated: Use host in Endpoint instead`, found[0].String())
+}
+
+func TestRewrite(t *testing.T) {
+	r := NewRegistry()
+	r.Register("v1", "v2", Rename("gateway.hosts", "gateway.endpoints"))
+
+	out, version, err := r.Rewrite([]byte("gateway:\n  hosts:\n    - example.com\n"), "yaml", "v1")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", version)
+	assert.Equal(t, "gateway:\n    endpoints:\n        - example.com\n", string(out))
+
+	_, _, err = r.Rewrite([]byte("a = 1"), "toml", "v1")
+	assert.Error(t, err)
+}
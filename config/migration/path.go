@@ -0,0 +1,217 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package migration
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Rename returns a migration that moves the value at path from to path to. Paths are
+// dotted, list elements are written as "name[0]" and "*" or "[*]" matches any map key or
+// list element. The keys matched by the wildcards of from replace, in order, the wildcards of
+// to, e.g. Rename("servers.*.timeout_ms", "servers.*.timeout"). When the new key is
+// already set, it is kept and the old one is dropped.
+func Rename(from, to string) Func {
+	return func(tree map[string]any) error {
+		target := splitPath(to)
+		for _, path := range match(tree, splitPath(from), nil) {
+			value, _ := get(tree, path)
+			newPath, err := substitute(target, path, splitPath(from))
+			if err != nil {
+				return err
+			}
+			remove(tree, path)
+			if _, ok := get(tree, newPath); ok {
+				continue
+			}
+			if err := set(tree, newPath, value); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// Delete returns a migration that removes the keys matched by path.
+func Delete(path string) Func {
+	return func(tree map[string]any) error {
+		for _, p := range match(tree, splitPath(path), nil) {
+			remove(tree, p)
+		}
+		return nil
+	}
+}
+
+// Chain returns a migration that applies fns in order.
+func Chain(fns ...Func) Func {
+	return func(tree map[string]any) error {
+		for _, fn := range fns {
+			if err := fn(tree); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// splitPath splits "servers[0].http.addr" into "servers", "[0]", "http" and "addr".
+// A "[*]" index is a wildcard like "*".
+func splitPath(path string) []string {
+	var segments []string
+	for _, part := range strings.Split(path, ".") {
+		name, rest, _ := strings.Cut(part, "[")
+		if name != "" {
+			segments = append(segments, name)
+		}
+		for rest != "" {
+			idx, tail, _ := strings.Cut(rest, "]")
+			if idx == "*" {
+				segments = append(segments, "*")
+			} else {
+				segments = append(segments, "["+idx+"]")
+			}
+			rest = strings.TrimPrefix(tail, "[")
+		}
+	}
+	return segments
+}
+
+// formatPath is the inverse of splitPath.
+func formatPath(segments []string) string {
+	var b strings.Builder
+	for i, seg := range segments {
+		if i > 0 && !strings.HasPrefix(seg, "[") {
+			b.WriteByte('.')
+		}
+		b.WriteString(seg)
+	}
+	return b.String()
+}
+
+// match returns the concrete paths in node matched by pattern.
+func match(node any, pattern []string, prefix []string) [][]string {
+	if len(pattern) == 0 {
+		return [][]string{append([]string(nil), prefix...)}
+	}
+	seg, rest := pattern[0], pattern[1:]
+	var paths [][]string
+	switch n := node.(type) {
+	case map[string]any:
+		if seg == "*" {
+			for key, child := range n {
+				paths = append(paths, match(child, rest, append(prefix, key))...)
+			}
+		} else if child, ok := n[seg]; ok {
+			paths = append(paths, match(child, rest, append(prefix, seg))...)
+		}
+	case []any:
+		for i, child := range n {
+			key := "[" + strconv.Itoa(i) + "]"
+			if seg == "*" || seg == key {
+				paths = append(paths, match(child, rest, append(prefix, key))...)
+			}
+		}
+	}
+	return paths
+}
+
+// substitute replaces the wildcards of target with the keys path matched for the
+// wildcards of pattern.
+func substitute(target, path, pattern []string) ([]string, error) {
+	var bound []string
+	for i, seg := range pattern {
+		if seg == "*" {
+			bound = append(bound, path[i])
+		}
+	}
+	result := make([]string, 0, len(target))
+	for _, seg := range target {
+		if seg == "*" {
+			if len(bound) == 0 {
+				return nil, fmt.Errorf("path %s has more wildcards than the renamed path", formatPath(target))
+			}
+			seg, bound = bound[0], bound[1:]
+		}
+		result = append(result, seg)
+	}
+	return result, nil
+}
+
+func get(node any, path []string) (any, bool) {
+	for _, seg := range path {
+		switch n := node.(type) {
+		case map[string]any:
+			child, ok := n[seg]
+			if !ok {
+				return nil, false
+			}
+			node = child
+		case []any:
+			i, ok := index(seg)
+			if !ok || i >= len(n) {
+				return nil, false
+			}
+			node = n[i]
+		default:
+			return nil, false
+		}
+	}
+	return node, true
+}
+
+func remove(tree map[string]any, path []string) {
+	parent, ok := get(tree, path[:len(path)-1])
+	if !ok {
+		return
+	}
+	// List elements are left in place, removing them would shift the following ones.
+	if m, ok := parent.(map[string]any); ok {
+		delete(m, path[len(path)-1])
+	}
+}
+
+// set stores value at path, creating the missing maps on the way.
+func set(tree map[string]any, path []string, value any) error {
+	var node any = tree
+	for i, seg := range path {
+		last := i == len(path)-1
+		switch n := node.(type) {
+		case map[string]any:
+			if last {
+				n[seg] = value
+				return nil
+			}
+			child, ok := n[seg]
+			if !ok {
+				child = make(map[string]any)
+				n[seg] = child
+			}
+			node = child
+		case []any:
+			idx, ok := index(seg)
+			if !ok || idx >= len(n) {
+				return fmt.Errorf("cannot set %s: no list element %s", formatPath(path), seg)
+			}
+			if last {
+				n[idx] = value
+				return nil
+			}
+			node = n[idx]
+		default:
+			return fmt.Errorf("cannot set %s: %s is not a map", formatPath(path), formatPath(path[:i]))
+		}
+	}
+	return nil
+}
+
+func index(seg string) (int, bool) {
+	if !strings.HasPrefix(seg, "[") || !strings.HasSuffix(seg, "]") {
+		return 0, false
+	}
+	i, err := strconv.Atoi(seg[1 : len(seg)-1])
+	return i, err == nil && i >= 0
+}
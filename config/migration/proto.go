@@ -0,0 +1,85 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package migration
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	openapiv3 "github.com/google/gnostic/openapiv3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// DeprecatedFields returns the keys of tree that set a field marked deprecated in the
+// message described by md. The hint is taken from a "Deprecated:" note in the
+// openapi.v3.property description of the field.
+func DeprecatedFields(md protoreflect.MessageDescriptor, tree map[string]any) []Deprecation {
+	var found []Deprecation
+	deprecatedFields(md, tree, nil, &found)
+	sort.Slice(found, func(i, j int) bool {
+		return found[i].Path < found[j].Path
+	})
+	return found
+}
+
+func deprecatedFields(md protoreflect.MessageDescriptor, tree map[string]any, prefix []string, found *[]Deprecation) {
+	for key, value := range tree {
+		fd := md.Fields().ByJSONName(key)
+		if fd == nil {
+			fd = md.Fields().ByTextName(key)
+		}
+		if fd == nil {
+			continue
+		}
+		path := append(append([]string(nil), prefix...), key)
+		if opts, ok := fd.Options().(interface{ GetDeprecated() bool }); ok && opts.GetDeprecated() {
+			*found = append(*found, Deprecation{Path: formatPath(path), Hint: deprecationHint(fd)})
+		}
+		if fd.Kind() != protoreflect.MessageKind {
+			continue
+		}
+		switch {
+		case fd.IsMap():
+			if m, ok := value.(map[string]any); ok && fd.MapValue().Kind() == protoreflect.MessageKind {
+				for k, v := range m {
+					if child, ok := v.(map[string]any); ok {
+						deprecatedFields(fd.MapValue().Message(), child, append(path, k), found)
+					}
+				}
+			}
+		case fd.IsList():
+			if list, ok := value.([]any); ok {
+				for i, v := range list {
+					if child, ok := v.(map[string]any); ok {
+						deprecatedFields(fd.Message(), child, append(path, "["+strconv.Itoa(i)+"]"), found)
+					}
+				}
+			}
+		default:
+			if child, ok := value.(map[string]any); ok {
+				deprecatedFields(fd.Message(), child, path, found)
+			}
+		}
+	}
+}
+
+func deprecationHint(fd protoreflect.FieldDescriptor) string {
+	property, ok := proto.GetExtension(fd.Options(), openapiv3.E_Property).(*openapiv3.Schema)
+	if !ok || property == nil {
+		return ""
+	}
+	desc := property.GetDescription()
+	if !strings.HasPrefix(desc, "Deprecated:") {
+		return ""
+	}
+	hint := strings.TrimSpace(strings.TrimPrefix(desc, "Deprecated:"))
+	// Keep the first sentence, the rest describes the field itself.
+	if i := strings.Index(hint, ". "); i >= 0 {
+		hint = hint[:i]
+	}
+	return strings.TrimSuffix(hint, ".")
+}
@@ -0,0 +1,76 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package migration
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rewrite migrates the content of a YAML or JSON configuration file from version from
+// to the latest version and returns the new content with the version reached. Keys are
+// written in sorted order and comments are not preserved.
+func (r *Registry) Rewrite(content []byte, format, from string) ([]byte, string, error) {
+	tree := make(map[string]any)
+	switch format {
+	case "yaml", "yml":
+		if err := yaml.Unmarshal(content, &tree); err != nil {
+			return nil, from, err
+		}
+	case "json":
+		if err := json.Unmarshal(content, &tree); err != nil {
+			return nil, from, err
+		}
+	default:
+		return nil, from, fmt.Errorf("migration: unsupported config format %q", format)
+	}
+	to, err := r.Migrate(tree, from)
+	if err != nil {
+		return nil, from, err
+	}
+	if format == "json" {
+		out, err := json.MarshalIndent(tree, "", "  ")
+		if err != nil {
+			return nil, from, err
+		}
+		return append(out, '\n'), to, nil
+	}
+	out, err := yaml.Marshal(tree)
+	if err != nil {
+		return nil, from, err
+	}
+	return out, to, nil
+}
+
+// RewriteFile migrates the configuration file at path from version from to the latest
+// version and writes it back. It returns the version reached.
+func (r *Registry) RewriteFile(path, from string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return from, err
+	}
+	out, to, err := r.Rewrite(content, Format(path), from)
+	if err != nil {
+		return from, fmt.Errorf("%s: %w", path, err)
+	}
+	if to == from {
+		return to, nil
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return from, err
+	}
+	return to, os.WriteFile(path, out, info.Mode().Perm())
+}
+
+// Format returns the format of a configuration file from its extension.
+func Format(path string) string {
+	return strings.TrimPrefix(filepath.Ext(path), ".")
+}
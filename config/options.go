@@ -3,6 +3,7 @@ package config
 import (
 	"github.com/go-kratos/kratos/v2/config"
 
+	"github.com/origadmin/runtime/config/migration"
 	"github.com/origadmin/runtime/contracts/options"
 	"github.com/origadmin/runtime/helpers/optionutil"
 )
@@ -12,6 +13,9 @@ type Options struct {
 	ConfigOptions []KOption
 	// EnvPrefixes   []string
 	Sources []KSource
+	// Migrations upgrades the loaded files from the version declared by the sources.
+	// The package-level migration registry is used when it is nil.
+	Migrations *migration.Registry
 }
 
 // WithConfigOption appends Kratos config.Option to the Options.
@@ -35,6 +39,13 @@ func WithSource(s ...config.Source) options.Option {
 	})
 }
 
+// WithMigrations sets the registry of the migrations applied to the loaded files.
+func WithMigrations(registry *migration.Registry) options.Option {
+	return optionutil.Update(func(c *Options) {
+		c.Migrations = registry
+	})
+}
+
 // FromOptions retrieves Options pointer from the provided options.Option.
 // It returns nil if the options are not found or opt is nil.
 func FromOptions(opts ...options.Option) *Options {
@@ -6,6 +6,7 @@ import (
 	discoveryv1 "github.com/origadmin/runtime/api/gen/go/config/discovery/v1"
 	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
 	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	tracev1 "github.com/origadmin/runtime/api/gen/go/config/trace/v1"
 	transportv1 "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
 )
 
@@ -19,6 +20,11 @@ type LoggerConfig interface {
 	GetLogger() *loggerv1.Logger
 }
 
+// LoggersConfig defines the contract for a configuration that provides named logger information.
+type LoggersConfig interface {
+	GetLoggers() *loggerv1.Loggers
+}
+
 // MiddlewareConfig defines the contract for a configuration that provides middleware information.
 type MiddlewareConfig interface {
 	GetMiddlewares() *middlewarev1.Middlewares
@@ -44,6 +50,11 @@ type ClientConfig interface {
 	GetClients() *transportv1.Clients
 }
 
+// TraceConfig defines the contract for a configuration that provides trace information.
+type TraceConfig interface {
+	GetTrace() *tracev1.Trace
+}
+
 // ConfigObject aggregates common configuration accessors.
 type ConfigObject interface {
 	AppConfig
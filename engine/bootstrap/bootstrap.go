@@ -3,10 +3,14 @@ package bootstrap
 import (
 	"fmt"
 
+	"google.golang.org/protobuf/proto"
+
 	appv1 "github.com/origadmin/runtime/api/gen/go/config/app/v1"
 	bootstrapv1 "github.com/origadmin/runtime/api/gen/go/config/bootstrap/v1"
+	runtimeconfig "github.com/origadmin/runtime/config"
 	_ "github.com/origadmin/runtime/config/envsource"
 	_ "github.com/origadmin/runtime/config/file"
+	"github.com/origadmin/runtime/config/migration"
 	"github.com/origadmin/runtime/log"
 )
 
@@ -22,7 +26,7 @@ func New(bootstrapPath string, opts ...Option) (res Result, err error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to load config: %w", err)
 	}
-	log.Debugf("Load bootstrap config : %+v", bootstrap)
+	log.Debugf("Load bootstrap config : %+v", log.RedactMessage(bootstrap))
 
 	// Ensure app info exists
 	if bootstrap == nil {
@@ -43,6 +47,11 @@ func New(bootstrapPath string, opts ...Option) (res Result, err error) {
 		}
 	}
 
+	// Warn about deprecated fields of a protobuf target that the configuration still sets.
+	if target, ok := providerOpts.configTarget.(proto.Message); ok {
+		warnDeprecated(cfg, target)
+	}
+
 	// Priority 2: Automatic decoding into target struct
 	if providerOpts.configTarget != nil {
 		if err := cfg.Scan(providerOpts.configTarget); err != nil {
@@ -74,3 +83,14 @@ func New(bootstrapPath string, opts ...Option) (res Result, err error) {
 	}
 	return res, nil
 }
+
+// warnDeprecated logs the fields of target marked deprecated that the configuration sets.
+func warnDeprecated(cfg runtimeconfig.KConfig, target proto.Message) {
+	tree := make(map[string]any)
+	if err := cfg.Scan(&tree); err != nil {
+		return
+	}
+	for _, d := range migration.DeprecatedFields(target.ProtoReflect().Descriptor(), tree) {
+		log.Warn(d.String())
+	}
+}
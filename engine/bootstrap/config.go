@@ -5,12 +5,15 @@ import (
 	"path/filepath"
 
 	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	"google.golang.org/protobuf/proto"
 
 	bootstrapv1 "github.com/origadmin/runtime/api/gen/go/config/bootstrap/v1"
 	sourcev1 "github.com/origadmin/runtime/api/gen/go/config/source/v1"
 	runtimeconfig "github.com/origadmin/runtime/config"
 	"github.com/origadmin/runtime/config/envsource"
 	"github.com/origadmin/runtime/config/file"
+	"github.com/origadmin/runtime/config/flagsource"
+	"github.com/origadmin/runtime/contracts/options"
 	"github.com/origadmin/runtime/log"
 )
 
@@ -48,7 +51,13 @@ func LoadConfig(bootstrapPath string, providerOpts *ProviderOptions) (*bootstrap
 		logger.Infof("Loading config directly from: %s", bootstrapPath)
 		sources := []*sourcev1.SourceConfig{SourceWithFile(bootstrapPath)}
 		sources = append(sources, providerOpts.extraSources...)
-		baseConfig, err = runtimeconfig.New(&sourcev1.Sources{Configs: sources}, providerOpts.frameworkOptions...)
+		if providerOpts.flagSource != nil {
+			sources = append(sources, providerOpts.flagSource)
+		}
+		baseConfig, err = runtimeconfig.New(&sourcev1.Sources{
+			Version: providerOpts.configVersion,
+			Configs: sources,
+		}, sourceOptions(providerOpts)...)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create base config for direct loading: %w", err)
 		}
@@ -59,7 +68,18 @@ func LoadConfig(bootstrapPath string, providerOpts *ProviderOptions) (*bootstrap
 			return nil, nil, fmt.Errorf("no configuration sources found in bootstrap file: %s", bootstrapPath)
 		}
 
-		baseConfig, err = runtimeconfig.New(&sourcev1.Sources{Configs: bootstrapConfig.GetSources()}, providerOpts.frameworkOptions...)
+		sources := bootstrapConfig.GetSources()
+		if providerOpts.flagSource != nil {
+			sources = append(sources, providerOpts.flagSource)
+		}
+		version := bootstrapConfig.GetVersion()
+		if version == "" {
+			version = providerOpts.configVersion
+		}
+		baseConfig, err = runtimeconfig.New(&sourcev1.Sources{
+			Version: version,
+			Configs: sources,
+		}, sourceOptions(providerOpts)...)
 		if err != nil {
 			return nil, nil, fmt.Errorf("failed to create base config from bootstrap sources: %w", err)
 		}
@@ -73,6 +93,15 @@ func LoadConfig(bootstrapPath string, providerOpts *ProviderOptions) (*bootstrap
 	return bootstrapConfig, baseConfig, nil
 }
 
+// sourceOptions returns the options passed to the source factories. When the business
+// config target is a protobuf message, it is used to type the command-line flag values.
+func sourceOptions(providerOpts *ProviderOptions) []options.Option {
+	if target, ok := providerOpts.configTarget.(proto.Message); ok {
+		return append([]options.Option{flagsource.WithTarget(target)}, providerOpts.frameworkOptions...)
+	}
+	return providerOpts.frameworkOptions
+}
+
 func isFileSource(source *sourcev1.SourceConfig) bool {
 	return source != nil && source.Type == "file" && source.File != nil
 }
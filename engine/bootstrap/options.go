@@ -19,6 +19,8 @@ type ProviderOptions struct {
 	config            any
 	pathResolver      func(string) string
 	prefixes          []string
+	flagSource        *sourcev1.SourceConfig
+	configVersion     string
 }
 
 type Option = options.Option
@@ -86,6 +88,25 @@ func WithEnvSource() Option {
 	})
 }
 
+// WithFlagSource adds a command-line flag source on top of the configured sources,
+// in both bootstrap and direct loading. The given options are passed to the source,
+// e.g. flagsource.WithFlagSet(fs) or flagsource.WithBinding("http-addr", "...").
+func WithFlagSource(opts ...options.Option) Option {
+	return optionutil.Update(func(opt *ProviderOptions) {
+		opt.flagSource = &sourcev1.SourceConfig{Type: "flags"}
+		opt.frameworkOptions = append(opt.frameworkOptions, opts...)
+	})
+}
+
+// WithConfigVersion declares the schema version of the configuration files, from which
+// they are migrated to the latest registered version. A version declared by the
+// bootstrap file takes precedence.
+func WithConfigVersion(version string) Option {
+	return optionutil.Update(func(opt *ProviderOptions) {
+		opt.configVersion = version
+	})
+}
+
 func FromOptions(opts ...Option) *ProviderOptions {
 	return optionutil.NewT[ProviderOptions](opts...)
 }
@@ -18,6 +18,7 @@ type (
 	idKey        struct{}
 	tokenKey     struct{}
 	createdByKey struct{}
+	tenantKey    struct{}
 )
 
 // NewContext creates a new context with common runtime values.
@@ -90,6 +91,19 @@ func FromToken(ctx context.Context) string {
 	return ""
 }
 
+// NewTenant creates a new context with the tenant ID.
+func NewTenant(ctx context.Context, tenantID string) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// FromTenant returns the tenant ID from the context.
+func FromTenant(ctx context.Context) string {
+	if v, ok := ctx.Value(tenantKey{}).(string); ok {
+		return v
+	}
+	return ""
+}
+
 // NewTrans creates a new context with the transaction flag.
 func NewTrans(ctx context.Context, trans bool) context.Context {
 	return context.WithValue(ctx, transKey{}, trans)
@@ -0,0 +1,244 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+const (
+	// DefaultBufferRecords is the number of entries a RingBuffer keeps by default.
+	DefaultBufferRecords = 5000
+	// DefaultBufferBytes is the size in bytes of the entries a RingBuffer keeps by default.
+	DefaultBufferBytes = 16 << 20
+	// DefaultBufferPath is the path of the query endpoint of the buffer on the HTTP servers.
+	DefaultBufferPath = "/debug/logs"
+)
+
+// TraceIDKey is the key of the trace ID of the entries, as added by WithDecorate.
+const TraceIDKey = "trace_id"
+
+// Record is an entry kept by a RingBuffer.
+type Record struct {
+	Time    time.Time      `json:"time"`
+	Level   string         `json:"level"`
+	Module  string         `json:"module,omitempty"`
+	TraceID string         `json:"trace_id,omitempty"`
+	Message string         `json:"msg"`
+	Fields  map[string]any `json:"fields,omitempty"`
+
+	size int
+}
+
+// RecordFilter selects the records of a RingBuffer.
+type RecordFilter struct {
+	// Level is the minimum level of the records. Its zero value is LevelInfo, LevelDebug
+	// selects the records of all levels.
+	Level Level
+	// Module selects the records of a module and of its submodules.
+	Module string
+	// Since and Until bound the time of the records.
+	Since time.Time
+	Until time.Time
+	// TraceID selects the records of a trace.
+	TraceID string
+	// Limit keeps the latest records only.
+	Limit int
+}
+
+// match reports whether r is selected by f.
+func (f RecordFilter) match(r *Record, level Level) bool {
+	switch {
+	case level < f.Level,
+		f.Module != "" && r.Module != f.Module && !strings.HasPrefix(r.Module, f.Module+"."),
+		!f.Since.IsZero() && r.Time.Before(f.Since),
+		!f.Until.IsZero() && r.Time.After(f.Until),
+		f.TraceID != "" && r.TraceID != f.TraceID:
+		return false
+	}
+	return true
+}
+
+// RingBuffer is a logger keeping the latest entries in memory, within a number of
+// records and a size in bytes, for them to be queried without a log pipeline. It is
+// safe for concurrent use.
+type RingBuffer struct {
+	maxRecords int
+	maxBytes   int
+	path       string
+	redactor   *Redactor
+	now        func() time.Time
+
+	mu      sync.RWMutex
+	records []*Record
+	levels  []Level
+	head    int
+	count   int
+	bytes   int
+	evicted atomic.Uint64
+}
+
+// NewRingBuffer creates a RingBuffer from the buffer configuration. Its entries are
+// masked by redactor unless it is nil.
+func NewRingBuffer(cfg *loggerv1.Logger_Buffer, redactor *Redactor) *RingBuffer {
+	maxRecords := int(cfg.GetMaxRecords())
+	if maxRecords <= 0 {
+		maxRecords = DefaultBufferRecords
+	}
+	maxBytes := int(cfg.GetMaxBytes())
+	if maxBytes <= 0 {
+		maxBytes = DefaultBufferBytes
+	}
+	path := cfg.GetPath()
+	if path == "" {
+		path = DefaultBufferPath
+	}
+	return &RingBuffer{
+		maxRecords: maxRecords,
+		maxBytes:   maxBytes,
+		path:       path,
+		redactor:   redactor,
+		now:        time.Now,
+		records:    make([]*Record, maxRecords),
+		levels:     make([]Level, maxRecords),
+	}
+}
+
+// Path returns the path of the query endpoint of b on the HTTP servers.
+func (b *RingBuffer) Path() string {
+	return b.path
+}
+
+// Log keeps an entry, evicting the oldest ones beyond the limits of b.
+func (b *RingBuffer) Log(level Level, keyvals ...any) error {
+	if b.redactor != nil {
+		keyvals = b.redactor.RedactKeyvals(keyvals)
+	}
+	r := &Record{Time: b.now(), Level: levelName(level)}
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var value any
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		if v, ok := value.(Valuer); ok {
+			value = v(context.Background())
+		}
+		switch key {
+		case DefaultMessageKey:
+			r.Message = fmt.Sprint(value)
+			r.size += len(r.Message)
+			continue
+		case ModuleKey:
+			r.Module = fmt.Sprint(value)
+			r.size += len(r.Module)
+			continue
+		case TraceIDKey:
+			r.TraceID = fmt.Sprint(value)
+			r.size += len(r.TraceID)
+			continue
+		}
+		if r.Fields == nil {
+			r.Fields = make(map[string]any)
+		}
+		value = recordValue(value)
+		r.Fields[key] = value
+		r.size += len(key) + valueSize(value)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.count == b.maxRecords {
+		b.evict()
+	}
+	for b.count > 0 && b.bytes+r.size > b.maxBytes {
+		b.evict()
+	}
+	i := (b.head + b.count) % b.maxRecords
+	b.records[i], b.levels[i] = r, level
+	b.count++
+	b.bytes += r.size
+	return nil
+}
+
+// evict drops the oldest record.
+func (b *RingBuffer) evict() {
+	b.bytes -= b.records[b.head].size
+	b.records[b.head] = nil
+	b.head = (b.head + 1) % b.maxRecords
+	b.count--
+	b.evicted.Add(1)
+}
+
+// Records returns the records selected by filter, the oldest first.
+func (b *RingBuffer) Records(filter RecordFilter) []Record {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	records := make([]Record, 0)
+	for n := 0; n < b.count; n++ {
+		i := (b.head + n) % b.maxRecords
+		if filter.match(b.records[i], b.levels[i]) {
+			records = append(records, *b.records[i])
+		}
+	}
+	if filter.Limit > 0 && len(records) > filter.Limit {
+		records = records[len(records)-filter.Limit:]
+	}
+	return records
+}
+
+// Len returns the number of records kept and their size in bytes.
+func (b *RingBuffer) Len() (records, bytes int) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return b.count, b.bytes
+}
+
+// Evicted returns the number of records evicted to make room for newer ones.
+func (b *RingBuffer) Evicted() uint64 {
+	return b.evicted.Load()
+}
+
+// recordValue returns v as a value encoded as is to JSON, its string form for the
+// types that are not.
+func recordValue(v any) any {
+	switch v := v.(type) {
+	case nil, string, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return v
+	case time.Duration:
+		return v.String()
+	case error:
+		return v.Error()
+	}
+	return fmt.Sprint(v)
+}
+
+// valueSize estimates the memory of a value returned by recordValue.
+func valueSize(v any) int {
+	if s, ok := v.(string); ok {
+		return len(s)
+	}
+	return 8
+}
+
+var recentBuffer atomic.Pointer[RingBuffer]
+
+// Buffer returns the RingBuffer of the default logger, or of the first logger created
+// with one, nil when there is none. The HTTP servers serve its query endpoint.
+func Buffer() *RingBuffer {
+	return recentBuffer.Load()
+}
+
+// SetBuffer sets the RingBuffer returned by Buffer.
+func SetBuffer(b *RingBuffer) {
+	recentBuffer.Store(b)
+}
@@ -0,0 +1,144 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+func newTestBuffer(cfg *loggerv1.Logger_Buffer) (*RingBuffer, *time.Time) {
+	b := NewRingBuffer(cfg, defaultRedactor)
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	b.now = func() time.Time { return now }
+	return b, &now
+}
+
+func messages(records []Record) []string {
+	var msgs []string
+	for _, r := range records {
+		msgs = append(msgs, r.Message)
+	}
+	return msgs
+}
+
+func TestRingBuffer(t *testing.T) {
+	b, now := newTestBuffer(&loggerv1.Logger_Buffer{MaxRecords: 3})
+	for _, msg := range []string{"a", "b", "c", "d"} {
+		*now = now.Add(time.Second)
+		require.NoError(t, b.Log(LevelInfo, "msg", msg, "password", "x", "err", errors.New("boom")))
+	}
+	records := b.Records(RecordFilter{})
+	assert.Equal(t, []string{"b", "c", "d"}, messages(records))
+	assert.Equal(t, uint64(1), b.Evicted())
+	assert.Equal(t, map[string]any{"password": DefaultMask, "err": "boom"}, records[0].Fields)
+
+	b, _ = newTestBuffer(&loggerv1.Logger_Buffer{MaxBytes: 10})
+	_ = b.Log(LevelInfo, "msg", "12345")
+	_ = b.Log(LevelInfo, "msg", "67890")
+	_ = b.Log(LevelInfo, "msg", "abc")
+	assert.Equal(t, []string{"67890", "abc"}, messages(b.Records(RecordFilter{})))
+	n, size := b.Len()
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 8, size)
+
+	// An entry larger than the buffer is kept alone.
+	_ = b.Log(LevelInfo, "msg", "0123456789ab")
+	assert.Equal(t, []string{"0123456789ab"}, messages(b.Records(RecordFilter{})))
+}
+
+func TestRingBufferFilter(t *testing.T) {
+	b, now := newTestBuffer(nil)
+	start := *now
+	entries := []struct {
+		level   Level
+		keyvals []any
+	}{
+		{LevelDebug, []any{"msg", "debug", ModuleKey, "service.user"}},
+		{LevelInfo, []any{"msg", "info", ModuleKey, "service.user.store", TraceIDKey, "t1"}},
+		{LevelWarn, []any{"msg", "warn", ModuleKey, "service.users"}},
+		{LevelError, []any{"msg", "error", TraceIDKey, "t1"}},
+	}
+	for _, e := range entries {
+		*now = now.Add(time.Minute)
+		require.NoError(t, b.Log(e.level, e.keyvals...))
+	}
+
+	tests := []struct {
+		filter RecordFilter
+		want   []string
+	}{
+		{RecordFilter{Level: LevelWarn}, []string{"warn", "error"}},
+		{RecordFilter{}, []string{"info", "warn", "error"}},
+		{RecordFilter{Level: LevelDebug, Module: "service.user"}, []string{"debug", "info"}},
+		{RecordFilter{TraceID: "t1"}, []string{"info", "error"}},
+		{RecordFilter{Since: start.Add(2 * time.Minute), Until: start.Add(3 * time.Minute)}, []string{"info", "warn"}},
+		{RecordFilter{Limit: 1}, []string{"error"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, messages(b.Records(tt.filter)), "%+v", tt.filter)
+	}
+	assert.Empty(t, b.Records(RecordFilter{TraceID: "t2"}))
+	assert.Len(t, bufferHandlerRecords(t, b, "/"), 4)
+}
+
+func bufferHandlerRecords(t *testing.T, b *RingBuffer, target string) []map[string]any {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	BufferHandler(b).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body struct {
+		Records []map[string]any `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body.Records
+}
+
+func TestBufferHandler(t *testing.T) {
+	b, now := newTestBuffer(nil)
+	_ = b.Log(LevelInfo, "msg", "old", TraceIDKey, "t1")
+	*now = now.Add(time.Hour)
+	_ = b.Log(LevelError, "msg", "failed", TraceIDKey, "t1", ModuleKey, "data.cache", "attempt", 3)
+	_ = b.Log(LevelError, "msg", "other")
+
+	records := bufferHandlerRecords(t, b, "/debug/logs?level=error&since=10m&trace_id=t1")
+	require.Len(t, records, 1)
+	assert.Equal(t, "failed", records[0]["msg"])
+	assert.Equal(t, "error", records[0]["level"])
+	assert.Equal(t, "data.cache", records[0]["module"])
+	assert.Equal(t, map[string]any{"attempt": float64(3)}, records[0]["fields"])
+
+	handler := BufferHandler(b)
+	for _, target := range []string{"/?level=loud", "/?since=yesterday", "/?limit=-1"} {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, target, nil))
+		assert.Equal(t, http.StatusBadRequest, rec.Code, target)
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestLoggerBuffer(t *testing.T) {
+	defer SetBuffer(nil)
+	SetBuffer(nil)
+	logger := NewLogger(&loggerv1.Logger{
+		Outputs: []*loggerv1.Logger_Output{{Level: "error"}},
+		Buffer:  &loggerv1.Logger_Buffer{Enabled: true, Path: "/logs"},
+	})
+	require.NotNil(t, Buffer())
+	assert.Equal(t, "/logs", Buffer().Path())
+
+	_ = WithModule(logger, "buffer.test").Log(LevelInfo, "msg", "kept")
+	records := Buffer().Records(RecordFilter{Module: "buffer.test"})
+	require.Len(t, records, 1)
+	assert.Equal(t, "kept", records[0].Message)
+	assert.Contains(t, records[0].Fields, callerKey)
+}
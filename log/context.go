@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"sync"
+
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+
+	enginecontext "github.com/origadmin/runtime/engine/context"
+)
+
+// Keys of the fields added by the default extractors.
+const (
+	// RequestIDKey holds the request ID, the trace ID of engine/context.
+	RequestIDKey = "request_id"
+	// UserIDKey holds the user ID, the creator of engine/context or the subject of its token.
+	UserIDKey = "user_id"
+	// TenantIDKey holds the tenant ID of engine/context.
+	TenantIDKey = "tenant_id"
+	// ObjectIDKey holds the object ID of engine/context.
+	ObjectIDKey = "object_id"
+)
+
+// Extractor returns the value of a field from the context of an entry, nil or an empty
+// string when the context has none.
+type Extractor func(ctx context.Context) any
+
+// extractorRegistry holds the extractors by key, in the order they were registered.
+type extractorRegistry struct {
+	mu         sync.RWMutex
+	keys       []string
+	extractors map[string]Extractor
+}
+
+var extractors = &extractorRegistry{extractors: make(map[string]Extractor)}
+
+func init() {
+	RegisterExtractor(RequestIDKey, func(ctx context.Context) any {
+		return enginecontext.FromTrace(ctx)
+	})
+	RegisterExtractor(UserIDKey, func(ctx context.Context) any {
+		if id := enginecontext.FromCreatedBy(ctx); id != "" {
+			return id
+		}
+		return tokenSubject(enginecontext.FromToken(ctx))
+	})
+	RegisterExtractor(TenantIDKey, func(ctx context.Context) any {
+		return enginecontext.FromTenant(ctx)
+	})
+	RegisterExtractor(ObjectIDKey, func(ctx context.Context) any {
+		return enginecontext.FromID(ctx)
+	})
+}
+
+// RegisterExtractor registers the extractor of the field key, replacing the one already
+// registered for it. Loggers only apply the extractors registered when they are created.
+func RegisterExtractor(key string, extractor Extractor) {
+	extractors.mu.Lock()
+	defer extractors.mu.Unlock()
+	if _, ok := extractors.extractors[key]; !ok {
+		extractors.keys = append(extractors.keys, key)
+	}
+	extractors.extractors[key] = extractor
+}
+
+// ExtractorKeys returns the keys of the registered extractors.
+func ExtractorKeys() []string {
+	extractors.mu.RLock()
+	defer extractors.mu.RUnlock()
+	return append([]string(nil), extractors.keys...)
+}
+
+// WithContextFields returns a logger adding the fields extracted from the contexts of
+// its entries to those of logger, the fields of keys or of all the registered
+// extractors when none is given. The context is the one given to WithContext,
+// Helper.WithContext or Context, the fields missing from it are omitted.
+func WithContextFields(logger Logger, keys ...string) Logger {
+	extractors.mu.RLock()
+	defer extractors.mu.RUnlock()
+	if len(keys) == 0 {
+		keys = extractors.keys
+	}
+	kv := make([]any, 0, 2*len(keys))
+	for _, key := range keys {
+		extractor, ok := extractors.extractors[key]
+		if !ok {
+			kratoslog.Warnf("logger: no context extractor registered for %q", key)
+			continue
+		}
+		kv = append(kv, key, Valuer(func(ctx context.Context) any {
+			switch v := extractor(ctx); v {
+			case nil, "":
+				return omitted{}
+			default:
+				return v
+			}
+		}))
+	}
+	if len(kv) == 0 {
+		return logger
+	}
+	return kratoslog.With(&omitLogger{logger: logger}, kv...)
+}
+
+// omitted is the value of the fields missing from a context.
+type omitted struct{}
+
+// omitLogger drops the fields missing from the context of an entry.
+type omitLogger struct {
+	logger Logger
+}
+
+func (l *omitLogger) Log(level Level, keyvals ...any) error {
+	kept := keyvals[:0:0]
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if _, ok := keyvals[i+1].(omitted); !ok {
+			kept = append(kept, keyvals[i], keyvals[i+1])
+		}
+	}
+	if len(keyvals)%2 == 1 {
+		kept = append(kept, keyvals[len(keyvals)-1])
+	}
+	return l.logger.Log(level, kept...)
+}
+
+// tokenSubject returns the subject of a JSON Web Token without verifying it, the token
+// has been verified by the authentication of the request already.
+func tokenSubject(token string) string {
+	parts := strings.Split(strings.TrimPrefix(token, "Bearer "), ".")
+	if len(parts) != 3 {
+		return ""
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return ""
+	}
+	var claims struct {
+		Subject string `json:"sub"`
+	}
+	if json.Unmarshal(payload, &claims) != nil {
+		return ""
+	}
+	return claims.Subject
+}
@@ -0,0 +1,67 @@
+package log
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	enginecontext "github.com/origadmin/runtime/engine/context"
+)
+
+func TestContextFields(t *testing.T) {
+	rec := &keyvalsLogger{}
+	logger := WithContextFields(rec)
+
+	ctx := enginecontext.NewTrace(context.Background(), "req-1")
+	ctx = enginecontext.NewTenant(ctx, "acme")
+	token := "eyJhbGciOiJIUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"alice"}`)) + ".sig"
+	ctx = enginecontext.NewToken(ctx, "Bearer "+token)
+
+	helper := NewHelper(logger)
+	helper.WithContext(ctx).Info("handled")
+	helper.Info("background")
+	NewHelper(WithModule(logger, "service.user")).WithContext(enginecontext.NewCreatedBy(ctx, "bob")).Info("created")
+
+	require.Len(t, rec.entries, 3)
+	assert.Equal(t, []any{RequestIDKey, "req-1", UserIDKey, "alice", TenantIDKey, "acme", "msg", "handled"}, rec.entries[0])
+	assert.Equal(t, []any{"msg", "background"}, rec.entries[1], "missing fields are omitted")
+	assert.Equal(t, "bob", value(rec.entries[2], UserIDKey))
+	assert.Equal(t, "service.user", value(rec.entries[2], ModuleKey))
+}
+
+func TestContextFieldsKeys(t *testing.T) {
+	RegisterExtractor("test.region", func(ctx context.Context) any {
+		return ctx.Value(regionKey{})
+	})
+	assert.Contains(t, ExtractorKeys(), "test.region")
+
+	rec := &keyvalsLogger{}
+	logger := WithContextFields(rec, "test.region", TenantIDKey, "unknown")
+	ctx := enginecontext.NewTenant(context.WithValue(context.Background(), regionKey{}, "eu"), "acme")
+	_ = kratoslog.WithContext(ctx, logger).Log(LevelInfo, "msg", "x")
+	require.Len(t, rec.entries, 1)
+	assert.Equal(t, []any{"test.region", "eu", TenantIDKey, "acme", "msg", "x"}, rec.entries[0])
+}
+
+func TestGlobalContext(t *testing.T) {
+	prev := kratoslog.GetLogger()
+	defer kratoslog.SetLogger(prev)
+	rec := &keyvalsLogger{}
+	kratoslog.SetLogger(WithContextFields(rec, RequestIDKey))
+
+	Context(enginecontext.NewTrace(context.Background(), "req-2")).Info("global")
+	require.Len(t, rec.entries, 1)
+	assert.Equal(t, "req-2", value(rec.entries[0], RequestIDKey))
+}
+
+func TestTokenSubject(t *testing.T) {
+	assert.Equal(t, "", tokenSubject(""))
+	assert.Equal(t, "", tokenSubject("opaque-token"))
+	assert.Equal(t, "", tokenSubject("a.!!.c"))
+}
+
+type regionKey struct{}
@@ -0,0 +1,138 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// levelRequest is the body of a request changing the level of a module. The fields may
+// also be given as query parameters.
+type levelRequest struct {
+	Module string `json:"module"`
+	Level  string `json:"level"`
+	// TTL is a duration such as "10m" after which the level reverts.
+	TTL string `json:"ttl"`
+}
+
+// LevelHandler returns an admin HTTP handler managing the module levels of registry:
+//
+//	GET                                           lists the module levels
+//	PUT or POST {"module", "level", "ttl"}        overrides the level of a module
+//	DELETE ?module=<name>                         removes the override of a module
+//
+// The handler is not protected in any way, mount it on an internal address or behind
+// an authenticating middleware.
+func LevelHandler(registry *LevelRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+		case http.MethodPut, http.MethodPost:
+			req := levelRequest{
+				Module: r.URL.Query().Get("module"),
+				Level:  r.URL.Query().Get("level"),
+				TTL:    r.URL.Query().Get("ttl"),
+			}
+			if r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					http.Error(w, "invalid request body: "+err.Error(), http.StatusBadRequest)
+					return
+				}
+			}
+			level, err := parseLevel(req.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			var ttl time.Duration
+			if req.TTL != "" {
+				if ttl, err = time.ParseDuration(req.TTL); err != nil || ttl < 0 {
+					http.Error(w, "invalid ttl: "+req.TTL, http.StatusBadRequest)
+					return
+				}
+			}
+			if req.Module == "" {
+				http.Error(w, "missing module", http.StatusBadRequest)
+				return
+			}
+			registry.SetLevel(req.Module, level, ttl)
+		case http.MethodDelete:
+			module := r.URL.Query().Get("module")
+			if module == "" {
+				http.Error(w, "missing module", http.StatusBadRequest)
+				return
+			}
+			registry.Reset(module)
+		default:
+			w.Header().Set("Allow", "GET, PUT, POST, DELETE")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(registry.Levels())
+	})
+}
+
+// BufferHandler returns an admin HTTP handler querying the records of buffer as JSON,
+// the oldest first. The query parameters filter them:
+//
+//	level=<name>                  minimum level, all levels by default
+//	module=<name>                 records of a module and of its submodules
+//	since=<time>, until=<time>    RFC 3339 times, or durations such as "15m" before now
+//	trace_id=<id>                 records of a trace
+//	limit=<n>                     latest n records
+//
+// The handler is not protected in any way, mount it on an internal address or behind
+// an authenticating middleware.
+func BufferHandler(buffer *RingBuffer) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			w.Header().Set("Allow", "GET")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+		query := r.URL.Query()
+		filter := RecordFilter{Level: LevelDebug, Module: query.Get("module"), TraceID: query.Get("trace_id")}
+		var err error
+		if level := query.Get("level"); level != "" {
+			if filter.Level, err = parseLevel(level); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		now := buffer.now()
+		for name, t := range map[string]*time.Time{"since": &filter.Since, "until": &filter.Until} {
+			if *t, err = parseQueryTime(query.Get(name), now); err != nil {
+				http.Error(w, "invalid "+name+": "+query.Get(name), http.StatusBadRequest)
+				return
+			}
+		}
+		if limit := query.Get("limit"); limit != "" {
+			if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 0 {
+				http.Error(w, "invalid limit: "+limit, http.StatusBadRequest)
+				return
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Records []Record `json:"records"`
+			Evicted uint64   `json:"evicted"`
+		}{buffer.Records(filter), buffer.Evicted()})
+	})
+}
+
+// parseQueryTime parses an RFC 3339 time, or a duration before now.
+func parseQueryTime(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if d, err := time.ParseDuration(s); err == nil {
+		return now.Add(-d), nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
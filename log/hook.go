@@ -0,0 +1,322 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+const (
+	// DefaultHookBufferSize is the number of messages a Hook buffers by default.
+	DefaultHookBufferSize = 1024
+	// DefaultHookTimeout is the default timeout of the delivery of a message to a sink.
+	DefaultHookTimeout = 5 * time.Second
+)
+
+// Sink receives the messages of a Hook.
+type Sink interface {
+	Send(ctx context.Context, msg *loggerv1.LoggerHookMessage) error
+}
+
+// SinkFunc is a function implementing Sink.
+type SinkFunc func(ctx context.Context, msg *loggerv1.LoggerHookMessage) error
+
+// Send calls f.
+func (f SinkFunc) Send(ctx context.Context, msg *loggerv1.LoggerHookMessage) error {
+	return f(ctx, msg)
+}
+
+type hookOptions struct {
+	level      Level
+	bufferSize int
+	timeout    time.Duration
+	stacktrace bool
+	redactor   *Redactor
+	onError    func(error)
+}
+
+// WithHookLevel sets the minimum level of the entries a Hook ships, error by default.
+func WithHookLevel(level Level) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.level = level
+	})
+}
+
+// WithHookBufferSize sets the number of messages a Hook buffers before dropping new ones.
+func WithHookBufferSize(size int) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.bufferSize = size
+	})
+}
+
+// WithHookTimeout sets the timeout of the delivery of a message to a sink.
+func WithHookTimeout(timeout time.Duration) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.timeout = timeout
+	})
+}
+
+// WithHookStacktrace sets whether the messages carry the stack trace of the code logging
+// the entries, true by default.
+func WithHookStacktrace(enabled bool) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.stacktrace = enabled
+	})
+}
+
+// WithHookRedactor masks the sensitive values of the messages with redactor.
+func WithHookRedactor(redactor *Redactor) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.redactor = redactor
+	})
+}
+
+// WithHookErrorHandler sets the function called with the errors of the sinks. They are
+// written to the standard error by default, a hook must not log through the logger it
+// is attached to.
+func WithHookErrorHandler(fn func(error)) options.Option {
+	return optionutil.Update(func(o *hookOptions) {
+		o.onError = fn
+	})
+}
+
+// Hook turns the log entries at or above its level into LoggerHookMessages and
+// dispatches them asynchronously to its sinks. Messages are dropped when its buffer is
+// full, so that logging never blocks on a slow sink.
+type Hook struct {
+	opts    *hookOptions
+	sinks   []Sink
+	queue   chan *loggerv1.LoggerHookMessage
+	dropped atomic.Uint64
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// NewHook creates a Hook dispatching its messages to sinks and starts its dispatcher.
+// Close stops it.
+func NewHook(sinks []Sink, opts ...options.Option) *Hook {
+	o := optionutil.NewT[hookOptions](append([]options.Option{
+		WithHookLevel(LevelError),
+		WithHookBufferSize(DefaultHookBufferSize),
+		WithHookTimeout(DefaultHookTimeout),
+		WithHookStacktrace(true),
+		WithHookErrorHandler(func(err error) {
+			fmt.Fprintf(os.Stderr, "log: hook: %v\n", err)
+		}),
+	}, opts...)...)
+	if o.bufferSize <= 0 {
+		o.bufferSize = DefaultHookBufferSize
+	}
+	h := &Hook{
+		opts:  o,
+		sinks: sinks,
+		queue: make(chan *loggerv1.LoggerHookMessage, o.bufferSize),
+		done:  make(chan struct{}),
+	}
+	go h.dispatch()
+	return h
+}
+
+// Level returns the minimum level of the entries shipped by h.
+func (h *Hook) Level() Level {
+	return h.opts.level
+}
+
+// Dropped returns the number of messages dropped because the buffer was full.
+func (h *Hook) Dropped() uint64 {
+	return h.dropped.Load()
+}
+
+// Fire queues the message of an entry if its level is at or above the level of h.
+func (h *Hook) Fire(level Level, keyvals ...any) {
+	if level < h.opts.level {
+		return
+	}
+	msg := h.message(level, keyvals)
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	if h.closed {
+		return
+	}
+	select {
+	case h.queue <- msg:
+	default:
+		h.dropped.Add(1)
+	}
+}
+
+// Close delivers the queued messages, stops the dispatcher and closes the sinks
+// implementing io.Closer.
+func (h *Hook) Close() error {
+	h.mu.Lock()
+	if h.closed {
+		h.mu.Unlock()
+		return nil
+	}
+	h.closed = true
+	close(h.queue)
+	h.mu.Unlock()
+	<-h.done
+
+	var errs []error
+	for _, sink := range h.sinks {
+		if c, ok := sink.(io.Closer); ok {
+			if err := c.Close(); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (h *Hook) dispatch() {
+	defer close(h.done)
+	for msg := range h.queue {
+		for _, sink := range h.sinks {
+			ctx, cancel := context.WithTimeout(context.Background(), h.opts.timeout)
+			if err := sink.Send(ctx, msg); err != nil {
+				h.opts.onError(err)
+			}
+			cancel()
+		}
+	}
+}
+
+// message builds the message of an entry. The "msg" key holds its message, the "error"
+// or "err" key its error, the other keys are kept as fields.
+func (h *Hook) message(level Level, keyvals []any) *loggerv1.LoggerHookMessage {
+	if r := h.opts.redactor; r != nil {
+		keyvals = r.RedactKeyvals(keyvals)
+	}
+	msg := &loggerv1.LoggerHookMessage{
+		Level:  levelName(level),
+		Fields: make(map[string]string),
+	}
+	for i := 0; i < len(keyvals); i += 2 {
+		key := fmt.Sprint(keyvals[i])
+		var value any
+		if i+1 < len(keyvals) {
+			value = keyvals[i+1]
+		}
+		if v, ok := value.(Valuer); ok {
+			// Valuers are bound by the kratos loggers with a context, a hook has none.
+			value = v(context.Background())
+		}
+		switch key {
+		case DefaultMessageKey:
+			msg.Message = fmt.Sprint(value)
+		case "error", "err":
+			msg.Error = fmt.Sprint(value)
+		default:
+			msg.Fields[key] = fmt.Sprint(value)
+		}
+	}
+	if h.opts.stacktrace {
+		msg.Stacktrace = stacktrace()
+	}
+	return msg
+}
+
+// stacktrace returns the stack of the code logging an entry, without the frames of the
+// logging packages.
+func stacktrace() string {
+	pcs := make([]uintptr, 64)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	var b strings.Builder
+	for {
+		frame, more := frames.Next()
+		if !isLogFrame(frame) && frame.Function != "" {
+			b.WriteString(frame.Function)
+			b.WriteString("\n\t")
+			b.WriteString(frame.File)
+			b.WriteByte(':')
+			b.WriteString(strconv.Itoa(frame.Line))
+			b.WriteByte('\n')
+		}
+		if !more {
+			return b.String()
+		}
+	}
+}
+
+// hookLogger fires its hooks with the entries of a logger.
+type hookLogger struct {
+	logger Logger
+	hooks  []*Hook
+}
+
+// NewHookLogger returns a logger firing hooks with the entries it writes to logger.
+func NewHookLogger(logger Logger, hooks ...*Hook) Logger {
+	if len(hooks) == 0 {
+		return logger
+	}
+	return &hookLogger{logger: logger, hooks: hooks}
+}
+
+func (l *hookLogger) Log(level Level, keyvals ...any) error {
+	for _, hook := range l.hooks {
+		hook.Fire(level, keyvals...)
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// newConfigHooks creates the hooks of the logger configuration.
+func newConfigHooks(cfg *loggerv1.Logger, redactor *Redactor) ([]*Hook, error) {
+	var hooks []*Hook
+	for _, hc := range cfg.GetHooks() {
+		var sinks []Sink
+		if webhook := hc.GetWebhook(); webhook != nil {
+			sinks = append(sinks, NewWebhookSink(webhook.GetUrl(),
+				WithWebhookHeaders(webhook.GetHeaders()),
+				WithWebhookTimeout(webhook.GetTimeout().AsDuration())))
+		}
+		if hc.GetFile() != "" {
+			sink, err := NewFileSink(hc.GetFile())
+			if err != nil {
+				closeHooks(hooks)
+				return nil, err
+			}
+			sinks = append(sinks, sink)
+		}
+		if len(sinks) == 0 {
+			continue
+		}
+		level := LevelError
+		if hc.GetLevel() != "" {
+			var err error
+			if level, err = parseLevel(hc.GetLevel()); err != nil {
+				closeHooks(hooks)
+				return nil, fmt.Errorf("log: invalid hook level: %w", err)
+			}
+		}
+		hooks = append(hooks, NewHook(sinks,
+			WithHookLevel(level),
+			WithHookBufferSize(int(hc.GetBufferSize())),
+			WithHookRedactor(redactor)))
+	}
+	return hooks, nil
+}
+
+func closeHooks(hooks []*Hook) {
+	for _, hook := range hooks {
+		_ = hook.Close()
+	}
+}
@@ -0,0 +1,162 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	"github.com/origadmin/runtime/contracts/broker"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+type webhookOptions struct {
+	client  *http.Client
+	headers map[string]string
+	timeout time.Duration
+}
+
+// WithWebhookClient sets the HTTP client of a webhook sink.
+func WithWebhookClient(client *http.Client) options.Option {
+	return optionutil.Update(func(o *webhookOptions) {
+		o.client = client
+	})
+}
+
+// WithWebhookHeaders sets headers added to the requests of a webhook sink.
+func WithWebhookHeaders(headers map[string]string) options.Option {
+	return optionutil.Update(func(o *webhookOptions) {
+		o.headers = headers
+	})
+}
+
+// WithWebhookTimeout sets the timeout of the requests of a webhook sink, in addition to
+// the delivery timeout of the hook.
+func WithWebhookTimeout(timeout time.Duration) options.Option {
+	return optionutil.Update(func(o *webhookOptions) {
+		o.timeout = timeout
+	})
+}
+
+// webhookSink posts the messages to a URL as JSON.
+type webhookSink struct {
+	url  string
+	opts *webhookOptions
+}
+
+// NewWebhookSink returns a Sink posting the messages to url as JSON. Responses with a
+// status other than 2xx are errors.
+func NewWebhookSink(url string, opts ...options.Option) Sink {
+	o := optionutil.NewT[webhookOptions](opts...)
+	if o.client == nil {
+		o.client = http.DefaultClient
+	}
+	return &webhookSink{url: url, opts: o}
+}
+
+func (s *webhookSink) Send(ctx context.Context, msg *loggerv1.LoggerHookMessage) error {
+	body, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if s.opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.opts.timeout)
+		defer cancel()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range s.opts.headers {
+		req.Header.Set(key, value)
+	}
+	resp, err := s.opts.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook %s responded %s", s.url, resp.Status)
+	}
+	return nil
+}
+
+// fileSink appends the messages to a file as JSON lines.
+type fileSink struct {
+	mu   sync.Mutex
+	file *os.File
+}
+
+// NewFileSink returns a Sink appending the messages to the file at path as JSON lines.
+// The file is closed with the hook.
+func NewFileSink(path string) (Sink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("log: failed to open hook file: %w", err)
+	}
+	return &fileSink{file: f}, nil
+}
+
+func (s *fileSink) Send(_ context.Context, msg *loggerv1.LoggerHookMessage) error {
+	line, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, err = s.file.Write(append(line, '\n'))
+	return err
+}
+
+func (s *fileSink) Close() error {
+	return s.file.Close()
+}
+
+// publisherSink publishes the messages to a broker topic.
+type publisherSink struct {
+	publisher broker.Publisher
+	topic     string
+}
+
+// NewPublisherSink returns a Sink publishing the messages to topic as JSON. The
+// publisher is owned by the caller and is not closed with the hook.
+func NewPublisherSink(publisher broker.Publisher, topic string) Sink {
+	return &publisherSink{publisher: publisher, topic: topic}
+}
+
+func (s *publisherSink) Send(ctx context.Context, msg *loggerv1.LoggerHookMessage) error {
+	payload, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	return s.publisher.Publish(ctx, s.topic, &hookMessage{
+		id:       uuid.NewString(),
+		metadata: map[string]string{"content-type": "application/json", "level": msg.GetLevel()},
+		payload:  payload,
+	})
+}
+
+// hookMessage is the broker message of a LoggerHookMessage.
+type hookMessage struct {
+	id       string
+	metadata map[string]string
+	payload  []byte
+}
+
+func (m *hookMessage) GetId() string                  { return m.id }
+func (m *hookMessage) GetMetadata() map[string]string { return m.metadata }
+func (m *hookMessage) GetPayload() []byte             { return m.payload }
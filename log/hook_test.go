@@ -0,0 +1,223 @@
+package log
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	"github.com/origadmin/runtime/contracts/broker"
+	enginecontext "github.com/origadmin/runtime/engine/context"
+)
+
+// collectSink records the messages it receives.
+type collectSink struct {
+	mu       sync.Mutex
+	messages []*loggerv1.LoggerHookMessage
+}
+
+func (s *collectSink) Send(_ context.Context, msg *loggerv1.LoggerHookMessage) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	return nil
+}
+
+func TestHook(t *testing.T) {
+	sink := &collectSink{}
+	r, err := NewRedactor(nil)
+	require.NoError(t, err)
+	hook := NewHook([]Sink{sink}, WithHookLevel(LevelWarn), WithHookRedactor(r))
+	helper := kratoslog.NewHelper(NewHookLogger(&keyvalsLogger{}, hook))
+
+	helper.Infow("msg", "ignored")
+	helper.Warnw("msg", "slow query", "took", "3s")
+	helper.Errorw("msg", "query failed", "error", errors.New("timeout"), "password", "x")
+	require.NoError(t, hook.Close())
+
+	require.Len(t, sink.messages, 2)
+	warn := sink.messages[0]
+	assert.Equal(t, "warn", warn.GetLevel())
+	assert.Equal(t, "slow query", warn.GetMessage())
+	assert.Equal(t, map[string]string{"took": "3s"}, warn.GetFields())
+	assert.Contains(t, warn.GetStacktrace(), "log.TestHook")
+	assert.NotContains(t, warn.GetStacktrace(), "kratos/v2/log")
+
+	failed := sink.messages[1]
+	assert.Equal(t, "error", failed.GetLevel())
+	assert.Equal(t, "timeout", failed.GetError())
+	assert.Equal(t, DefaultMask, failed.GetFields()["password"])
+
+	// Entries fired after Close are ignored.
+	helper.Error("late")
+	assert.Len(t, sink.messages, 2)
+}
+
+func TestHookDrop(t *testing.T) {
+	release := make(chan struct{})
+	var sent int
+	hook := NewHook([]Sink{SinkFunc(func(context.Context, *loggerv1.LoggerHookMessage) error {
+		<-release
+		sent++
+		return nil
+	})}, WithHookBufferSize(2), WithHookStacktrace(false))
+
+	for i := 0; i < 10; i++ {
+		hook.Fire(LevelError, "msg", "failed")
+	}
+	// One message is held by the blocked sink at most, two are buffered.
+	assert.GreaterOrEqual(t, hook.Dropped(), uint64(7))
+	close(release)
+	require.NoError(t, hook.Close())
+	assert.Equal(t, uint64(10), hook.Dropped()+uint64(sent))
+}
+
+func TestHookSinkError(t *testing.T) {
+	var errs []error
+	hook := NewHook([]Sink{SinkFunc(func(context.Context, *loggerv1.LoggerHookMessage) error {
+		return errors.New("unavailable")
+	})}, WithHookErrorHandler(func(err error) { errs = append(errs, err) }))
+	hook.Fire(LevelFatal, "msg", "down")
+	require.NoError(t, hook.Close())
+	require.Len(t, errs, 1)
+	assert.EqualError(t, errs[0], "unavailable")
+}
+
+func TestWebhookSink(t *testing.T) {
+	var got *loggerv1.LoggerHookMessage
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		body, _ := io.ReadAll(r.Body)
+		got = &loggerv1.LoggerHookMessage{}
+		if err := protojson.Unmarshal(body, got); err != nil || r.Header.Get("Content-Type") != "application/json" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	sink := NewWebhookSink(srv.URL, WithWebhookHeaders(map[string]string{"Authorization": "Bearer t"}))
+	require.NoError(t, sink.Send(context.Background(), &loggerv1.LoggerHookMessage{Level: "error", Message: "failed"}))
+	assert.Equal(t, "failed", got.GetMessage())
+	assert.Equal(t, "Bearer t", auth)
+
+	assert.Error(t, NewWebhookSink(srv.URL+"/%zz").Send(context.Background(), got))
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer failing.Close()
+	assert.ErrorContains(t, NewWebhookSink(failing.URL).Send(context.Background(), got), "502")
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.log")
+	sink, err := NewFileSink(path)
+	require.NoError(t, err)
+	hook := NewHook([]Sink{sink}, WithHookStacktrace(false))
+	hook.Fire(LevelError, "msg", "first")
+	hook.Fire(LevelError, "msg", "second")
+	require.NoError(t, hook.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, "second", entry["message"])
+
+	_, err = NewFileSink(filepath.Join(t.TempDir(), "missing", "hooks.log"))
+	assert.Error(t, err)
+}
+
+type recordPublisher struct {
+	topic    string
+	messages []broker.Message
+}
+
+func (p *recordPublisher) Publish(_ context.Context, topic string, messages ...broker.Message) error {
+	p.topic = topic
+	p.messages = append(p.messages, messages...)
+	return nil
+}
+
+func (p *recordPublisher) Close() error { return nil }
+
+func TestPublisherSink(t *testing.T) {
+	pub := &recordPublisher{}
+	sink := NewPublisherSink(pub, "log.errors")
+	require.NoError(t, sink.Send(context.Background(), &loggerv1.LoggerHookMessage{Level: "error", Message: "failed"}))
+
+	assert.Equal(t, "log.errors", pub.topic)
+	require.Len(t, pub.messages, 1)
+	msg := pub.messages[0]
+	assert.NotEmpty(t, msg.GetId())
+	assert.Equal(t, "error", msg.GetMetadata()["level"])
+	got := &loggerv1.LoggerHookMessage{}
+	require.NoError(t, protojson.Unmarshal(msg.GetPayload(), got))
+	assert.Equal(t, "failed", got.GetMessage())
+}
+
+func TestConfigHooks(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.log")
+	hooks, err := newConfigHooks(&loggerv1.Logger{Hooks: []*loggerv1.Logger_Hook{
+		{Level: "warn", File: path},
+		{Level: "error"},
+	}}, nil)
+	require.NoError(t, err)
+	require.Len(t, hooks, 1, "hooks without sinks are skipped")
+	assert.Equal(t, LevelWarn, hooks[0].Level())
+	closeHooks(hooks)
+
+	_, err = newConfigHooks(&loggerv1.Logger{Hooks: []*loggerv1.Logger_Hook{{Level: "loud", File: path}}}, nil)
+	assert.Error(t, err)
+}
+
+func TestLoggerClose(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.log")
+	logger := NewLogger(&loggerv1.Logger{
+		Outputs: []*loggerv1.Logger_Output{{Level: "fatal"}},
+		Hooks:   []*loggerv1.Logger_Hook{{Level: "error", File: path}},
+	})
+	_ = logger.Log(LevelError, "msg", "queued")
+
+	// Close delivers the queued messages before closing the file of the sink.
+	require.NoError(t, Close(logger))
+	require.NoError(t, Close(logger))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "queued")
+
+	assert.NoError(t, Close(DefaultLogger))
+}
+
+func TestLoggerContextWithHook(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hooks.log")
+	logger := NewLogger(&loggerv1.Logger{
+		Outputs:  []*loggerv1.Logger_Output{{Level: "fatal"}},
+		Hooks:    []*loggerv1.Logger_Hook{{Level: "error", File: path}},
+		Sampling: &loggerv1.Logger_Sampling{},
+	})
+	defer Close(logger)
+
+	// The context fields are bound to the context of the entries of a logger with hooks.
+	ctx := enginecontext.NewTrace(context.Background(), "req-hook")
+	_ = kratoslog.WithContext(ctx, logger).Log(LevelError, "msg", "failed")
+	require.NoError(t, Close(logger))
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "req-hook")
+}
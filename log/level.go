@@ -0,0 +1,476 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+// ModuleKey is the key carrying the module name of a log entry, as added by WithModule.
+const ModuleKey = "module"
+
+// DefaultLevelPath is the path of the admin endpoint of the module levels on the HTTP
+// servers.
+const DefaultLevelPath = "/debug/log/levels"
+
+// Keys of the logger configurations in the configuration of an application.
+const (
+	LoggerConfigKey  = "logger"
+	LoggersConfigKey = "loggers"
+)
+
+// ModuleLevel is the level set for a module.
+type ModuleLevel struct {
+	// Logger is the name of the logger configuring the level, empty for the levels
+	// shared by the loggers without levels of their own.
+	Logger string `json:"logger,omitempty"`
+	Module string `json:"module"`
+	Level  string `json:"level"`
+	// Override is set for levels set at runtime, which take precedence over the
+	// levels of the configuration.
+	Override bool `json:"override"`
+	// Expires is when a temporary override reverts, zero for permanent levels.
+	Expires time.Time `json:"expires,omitzero"`
+}
+
+type override struct {
+	level   Level
+	expires time.Time
+}
+
+// LevelRegistry holds the log levels of modules. The level of a module applies to its
+// sub-modules too, e.g. the level of "middleware" applies to "middleware.jwt" unless the
+// latter has a level of its own.
+//
+// Levels come from two layers: the module_levels of the logger configurations, replaced
+// as a whole on every reload, and the overrides set at runtime, which win over the
+// configuration and may revert by themselves after a TTL. The configured levels of a
+// logger are kept under its name, a logger without any applies the shared ones, those
+// of the default logger.
+type LevelRegistry struct {
+	mu        sync.RWMutex
+	config    map[string]Level
+	loggers   map[string]map[string]Level
+	overrides map[string]override
+	now       func() time.Time
+}
+
+// NewLevelRegistry returns an empty LevelRegistry.
+func NewLevelRegistry() *LevelRegistry {
+	return &LevelRegistry{
+		config:    make(map[string]Level),
+		loggers:   make(map[string]map[string]Level),
+		overrides: make(map[string]override),
+		now:       time.Now,
+	}
+}
+
+// SetLevel overrides the level of module. A positive ttl reverts the override once
+// elapsed, the module then gets back its configured level.
+func (r *LevelRegistry) SetLevel(module string, level Level, ttl time.Duration) {
+	o := override{level: level}
+	if ttl > 0 {
+		o.expires = r.now().Add(ttl)
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.overrides[module] = o
+}
+
+// Reset removes the override of module.
+func (r *LevelRegistry) Reset(module string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.overrides, module)
+}
+
+// ApplyLevels replaces the shared configured levels with levels, a map of module names
+// to level names. Overrides set at runtime are kept.
+func (r *LevelRegistry) ApplyLevels(levels map[string]string) error {
+	config, err := parseModuleLevels(levels)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.config = config
+	return nil
+}
+
+// ApplyLoggerLevels replaces the configured levels of the logger named name with levels,
+// the logger applies the shared levels again when levels is empty. Overrides set at
+// runtime are kept.
+func (r *LevelRegistry) ApplyLoggerLevels(name string, levels map[string]string) error {
+	config, err := parseModuleLevels(levels)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if len(config) == 0 {
+		delete(r.loggers, name)
+	} else {
+		r.loggers[name] = config
+	}
+	return nil
+}
+
+// applyConfig applies the module levels of the logger configuration cfg to the logger of
+// its name, and as the shared levels when it is the default logger.
+func (r *LevelRegistry) applyConfig(cfg *loggerv1.Logger) error {
+	if cfg.GetDefault() {
+		if err := r.ApplyLevels(cfg.GetModuleLevels()); err != nil {
+			return err
+		}
+	}
+	return r.ApplyLoggerLevels(cfg.GetName(), cfg.GetModuleLevels())
+}
+
+// Level returns the level of module, looking up its parent modules when it has no
+// level of its own.
+func (r *LevelRegistry) Level(module string) (Level, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.levelIn(module, r.config)
+}
+
+// LoggerLevel returns the level of module like Level, for the logger named name.
+func (r *LevelRegistry) LoggerLevel(name, module string) (Level, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.levelIn(module, r.configOf(name))
+}
+
+// configOf returns the configured levels of the logger named name, the shared ones when
+// it has none. r.mu must be held.
+func (r *LevelRegistry) configOf(name string) map[string]Level {
+	if config, ok := r.loggers[name]; ok {
+		return config
+	}
+	return r.config
+}
+
+// levelIn returns the level of module with the configured levels of config. The overrides
+// of r apply in any case. r.mu must be held.
+func (r *LevelRegistry) levelIn(module string, config map[string]Level) (Level, bool) {
+	if len(config) == 0 && len(r.overrides) == 0 {
+		return 0, false
+	}
+	now := r.now()
+	for name := module; ; {
+		if o, ok := r.overrides[name]; ok && (o.expires.IsZero() || now.Before(o.expires)) {
+			return o.level, true
+		}
+		if level, ok := config[name]; ok {
+			return level, true
+		}
+		i := strings.LastIndexByte(name, '.')
+		if i < 0 {
+			return 0, false
+		}
+		name = name[:i]
+	}
+}
+
+// Levels returns the levels set for modules, sorted by logger and module name. An
+// override hides the configured levels of its module.
+func (r *LevelRegistry) Levels() []ModuleLevel {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := r.now()
+	levels := make([]ModuleLevel, 0, len(r.config)+len(r.loggers)+len(r.overrides))
+	for module, o := range r.overrides {
+		if !o.expires.IsZero() && !now.Before(o.expires) {
+			// Drop expired overrides while we hold the write lock.
+			delete(r.overrides, module)
+			continue
+		}
+		levels = append(levels, ModuleLevel{Module: module, Level: levelName(o.level), Override: true, Expires: o.expires})
+	}
+	for module, level := range r.config {
+		if _, ok := r.overrides[module]; ok {
+			continue
+		}
+		levels = append(levels, ModuleLevel{Module: module, Level: levelName(level)})
+	}
+	for name, config := range r.loggers {
+		for module, level := range config {
+			if _, ok := r.overrides[module]; ok {
+				continue
+			}
+			levels = append(levels, ModuleLevel{Logger: name, Module: module, Level: levelName(level)})
+		}
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if levels[i].Logger != levels[j].Logger {
+			return levels[i].Logger < levels[j].Logger
+		}
+		return levels[i].Module < levels[j].Module
+	})
+	return levels
+}
+
+// Watch applies the module_levels of the logger configuration found at key of c to the
+// logger of its name, and as the shared levels when it is the default logger, now and
+// whenever the configuration changes.
+func (r *LevelRegistry) Watch(c kratosconfig.Config, key string) error {
+	return r.watch(c, key, func(value kratosconfig.Value) error {
+		var cfg loggerv1.Logger
+		if err := value.Scan(&cfg); err != nil {
+			return err
+		}
+		return r.applyConfig(&cfg)
+	})
+}
+
+// WatchLoggers applies the module_levels of the logger configurations of the loggers
+// configuration found at key of c like Watch, now and whenever the configuration changes.
+func (r *LevelRegistry) WatchLoggers(c kratosconfig.Config, key string) error {
+	return r.watch(c, key, func(value kratosconfig.Value) error {
+		var cfg loggerv1.Loggers
+		if err := value.Scan(&cfg); err != nil {
+			return err
+		}
+		configs := cfg.GetConfigs()
+		if cfg.GetDefault() != nil {
+			configs = append(configs[:len(configs):len(configs)], cfg.GetDefault())
+		}
+		var errs []error
+		for _, logger := range configs {
+			if err := r.applyConfig(logger); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		return errors.Join(errs...)
+	})
+}
+
+// watch calls apply with the value at key of c, now and whenever it changes.
+func (r *LevelRegistry) watch(c kratosconfig.Config, key string, apply func(kratosconfig.Value) error) error {
+	if err := apply(c.Value(key)); err != nil {
+		return err
+	}
+	return c.Watch(key, func(key string, value kratosconfig.Value) {
+		if err := apply(value); err != nil {
+			kratoslog.Errorf("log: failed to reload module levels from %s: %v", key, err)
+		}
+	})
+}
+
+// levels is the package-level registry consulted by the loggers of NewLogger.
+var levels = NewLevelRegistry()
+
+// ModuleLevels returns the package-level registry.
+func ModuleLevels() *LevelRegistry {
+	return levels
+}
+
+// WatchModuleLevels applies the module levels of the logger configurations of c, under
+// the logger and loggers keys, to the package-level registry, now and whenever the
+// configuration changes. The keys missing from c are skipped.
+func WatchModuleLevels(c kratosconfig.Config) error {
+	if c.Value(LoggerConfigKey).Load() != nil {
+		if err := levels.Watch(c, LoggerConfigKey); err != nil {
+			return err
+		}
+	}
+	if c.Value(LoggersConfigKey).Load() != nil {
+		return levels.WatchLoggers(c, LoggersConfigKey)
+	}
+	return nil
+}
+
+// SetModuleLevel overrides the level of module in the package-level registry.
+func SetModuleLevel(module string, level Level, ttl time.Duration) {
+	levels.SetLevel(module, level, ttl)
+}
+
+// ResetModuleLevel removes the override of module in the package-level registry.
+func ResetModuleLevel(module string) {
+	levels.Reset(module)
+}
+
+// WithModule returns a logger adding the module name to its entries, so that the level
+// of the module applies to them.
+func WithModule(logger Logger, module string) Logger {
+	return kratoslog.With(logger, ModuleKey, module)
+}
+
+// hasLevelBelow reports whether the level of a module, configured for the logger named
+// name or overridden, is below level.
+func (r *LevelRegistry) hasLevelBelow(name string, level Level) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, l := range r.configOf(name) {
+		if l < level {
+			return true
+		}
+	}
+	now := r.now()
+	for _, o := range r.overrides {
+		if o.level < level && (o.expires.IsZero() || now.Before(o.expires)) {
+			return true
+		}
+	}
+	return false
+}
+
+// levelLogger drops the entries below the level of their module, or below level for
+// entries of modules without a level of their own. The levels of the modules are those
+// of the logger named name in the registry. It adds the caller to the entries when
+// caller is set and they have none yet.
+type levelLogger struct {
+	logger     Logger
+	level      Level
+	registry   *LevelRegistry
+	name       string
+	caller     bool
+	callerSkip int
+}
+
+// threshold returns the level below which the entries of module are dropped, of the
+// entries without module when ok is false.
+func (l *levelLogger) threshold(module string, ok bool) Level {
+	if ok {
+		if moduleLevel, ok := l.registry.LoggerLevel(l.name, module); ok {
+			return moduleLevel
+		}
+	}
+	return l.level
+}
+
+func (l *levelLogger) Log(level Level, keyvals ...any) error {
+	if level < l.threshold(moduleOf(keyvals)) {
+		return nil
+	}
+	if l.caller && !hasKey(keyvals, callerKey) {
+		// Copy keyvals, the caller may hold on to its array.
+		keyvals = append(keyvals[:len(keyvals):len(keyvals)], callerKey, caller(l.callerSkip))
+	}
+	return l.logger.Log(level, keyvals...)
+}
+
+// moduleOf returns the innermost module of keyvals. kratos With prepends the keyvals of
+// the outer loggers, so the last module key wins.
+func moduleOf(keyvals []any) (string, bool) {
+	for i := len(keyvals)&^1 - 2; i >= 0; i -= 2 {
+		if key, ok := keyvals[i].(string); ok && key == ModuleKey {
+			module, ok := keyvals[i+1].(string)
+			return module, ok
+		}
+	}
+	return "", false
+}
+
+// parseModuleLevels parses levels, a map of module names to level names.
+func parseModuleLevels(levels map[string]string) (map[string]Level, error) {
+	config := make(map[string]Level, len(levels))
+	for module, name := range levels {
+		level, err := parseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("log: invalid level of module %q: %w", module, err)
+		}
+		config[module] = level
+	}
+	return config, nil
+}
+
+// slogLevelHandler is a slog handler dropping the records below the level of the level
+// logger of NewLogger, so that the slog logger installed globally applies the same
+// levels as the kratos one.
+type slogLevelHandler struct {
+	slog.Handler
+	levels *levelLogger
+	// module is the module of the attributes of the handler, if any.
+	module    string
+	hasModule bool
+}
+
+func (h *slogLevelHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	l := fromSlogLevel(level)
+	if h.hasModule {
+		return l >= h.levels.threshold(h.module, true) && h.Handler.Enabled(ctx, level)
+	}
+	// The record may still carry a module with a lower level.
+	enabled := l >= h.levels.level || h.levels.registry.hasLevelBelow(h.levels.name, h.levels.level)
+	return enabled && h.Handler.Enabled(ctx, level)
+}
+
+func (h *slogLevelHandler) Handle(ctx context.Context, record slog.Record) error {
+	module, ok := h.module, h.hasModule
+	record.Attrs(func(a slog.Attr) bool {
+		if a.Key == ModuleKey {
+			module, ok = a.Value.String(), true
+		}
+		return true
+	})
+	if fromSlogLevel(record.Level) < h.levels.threshold(module, ok) {
+		return nil
+	}
+	return h.Handler.Handle(ctx, record)
+}
+
+func (h *slogLevelHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithAttrs(attrs)
+	for _, a := range attrs {
+		if a.Key == ModuleKey {
+			clone.module, clone.hasModule = a.Value.String(), true
+		}
+	}
+	return &clone
+}
+
+func (h *slogLevelHandler) WithGroup(name string) slog.Handler {
+	clone := *h
+	clone.Handler = h.Handler.WithGroup(name)
+	return &clone
+}
+
+// fromSlogLevel returns the level of a slog level.
+func fromSlogLevel(level slog.Level) Level {
+	switch {
+	case level < slog.LevelInfo:
+		return LevelDebug
+	case level < slog.LevelWarn:
+		return LevelInfo
+	case level < slog.LevelError:
+		return LevelWarn
+	}
+	return LevelError
+}
+
+// parseLevel parses a level name, unlike ParseLevel unknown names are an error.
+func parseLevel(s string) (Level, error) {
+	switch strings.ToLower(s) {
+	case "debug":
+		return LevelDebug, nil
+	case "info":
+		return LevelInfo, nil
+	case "warn", "warning":
+		return LevelWarn, nil
+	case "error":
+		return LevelError, nil
+	case "fatal":
+		return LevelFatal, nil
+	}
+	return 0, fmt.Errorf("unknown level %q", s)
+}
+
+func levelName(level Level) string {
+	return strings.ToLower(level.String())
+}
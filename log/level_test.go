@@ -0,0 +1,205 @@
+package log
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	kratosconfig "github.com/go-kratos/kratos/v2/config"
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+type recordLogger struct {
+	levels []Level
+}
+
+func (r *recordLogger) Log(level kratoslog.Level, keyvals ...any) error {
+	r.levels = append(r.levels, level)
+	return nil
+}
+
+func TestLevelRegistry(t *testing.T) {
+	r := NewLevelRegistry()
+	now := time.Unix(1000, 0)
+	r.now = func() time.Time { return now }
+
+	_, ok := r.Level("middleware.jwt")
+	assert.False(t, ok)
+
+	require.NoError(t, r.ApplyLevels(map[string]string{"middleware": "warn"}))
+	level, ok := r.Level("middleware.jwt")
+	assert.True(t, ok)
+	assert.Equal(t, LevelWarn, level)
+
+	r.SetLevel("middleware.jwt", LevelDebug, time.Minute)
+	level, _ = r.Level("middleware.jwt")
+	assert.Equal(t, LevelDebug, level)
+	level, _ = r.Level("middleware.cors")
+	assert.Equal(t, LevelWarn, level)
+
+	// Reloading the configuration keeps the override.
+	require.NoError(t, r.ApplyLevels(map[string]string{"middleware": "error"}))
+	level, _ = r.Level("middleware.jwt")
+	assert.Equal(t, LevelDebug, level)
+
+	now = now.Add(time.Minute)
+	level, _ = r.Level("middleware.jwt")
+	assert.Equal(t, LevelError, level)
+	assert.Equal(t, []ModuleLevel{{Module: "middleware", Level: "error"}}, r.Levels())
+
+	r.SetLevel("middleware", LevelInfo, 0)
+	r.Reset("middleware")
+	level, _ = r.Level("middleware")
+	assert.Equal(t, LevelError, level)
+
+	assert.Error(t, r.ApplyLevels(map[string]string{"x": "verbose"}))
+}
+
+func TestLevelLogger(t *testing.T) {
+	r := NewLevelRegistry()
+	rec := &recordLogger{}
+	logger := &levelLogger{logger: rec, level: LevelInfo, registry: r}
+
+	jwt := WithModule(WithModule(logger, "middleware"), "middleware.jwt")
+	_ = jwt.Log(LevelDebug, "msg", "dropped")
+	r.SetLevel("middleware.jwt", LevelDebug, 0)
+	_ = jwt.Log(LevelDebug, "msg", "kept")
+	_ = WithModule(logger, "middleware").Log(LevelDebug, "msg", "dropped")
+	_ = logger.Log(LevelInfo, "msg", "kept")
+	assert.Equal(t, []Level{LevelDebug, LevelInfo}, rec.levels)
+}
+
+func TestLevelLoggerModules(t *testing.T) {
+	r := NewLevelRegistry()
+	require.NoError(t, r.ApplyLevels(map[string]string{"middleware": "debug"}))
+	rec := &recordLogger{}
+	require.NoError(t, r.ApplyLoggerLevels("audit", map[string]string{"server": "error"}))
+	// The levels of a logger are its own, the shared levels of the registry are not.
+	logger := &levelLogger{logger: rec, level: LevelInfo, registry: r, name: "audit"}
+
+	_ = WithModule(logger, "middleware").Log(LevelDebug, "msg", "dropped")
+	_ = WithModule(logger, "server").Log(LevelWarn, "msg", "dropped")
+	r.SetLevel("server", LevelDebug, 0)
+	_ = WithModule(logger, "server").Log(LevelDebug, "msg", "kept")
+	assert.Equal(t, []Level{LevelDebug}, rec.levels)
+}
+
+func TestSlogLevelHandler(t *testing.T) {
+	r := NewLevelRegistry()
+	var buf strings.Builder
+	handler := slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})
+	logger := slog.New(&slogLevelHandler{Handler: handler, levels: &levelLogger{level: LevelWarn, registry: r}})
+
+	logger.Info("dropped")
+	logger.Warn("kept warn")
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+
+	r.SetLevel("db", LevelDebug, 0)
+	logger.Debug("kept db", ModuleKey, "db")
+	logger.With(ModuleKey, "db").Debug("kept with")
+	logger.Debug("dropped", ModuleKey, "cache")
+	assert.Equal(t, 3, strings.Count(buf.String(), "kept"))
+	assert.NotContains(t, buf.String(), "dropped")
+}
+
+func TestNewLoggerModuleLevels(t *testing.T) {
+	defer func() { _ = ModuleLevels().ApplyLoggerLevels("audit", nil) }()
+	_ = NewLogger(&loggerv1.Logger{Name: "audit", Level: "info", ModuleLevels: map[string]string{"audit": "debug"}})
+	// A named logger does not replace the levels of the others.
+	_, ok := ModuleLevels().Level("audit")
+	assert.False(t, ok)
+	assert.Equal(t, []ModuleLevel{{Logger: "audit", Module: "audit", Level: "debug"}}, ModuleLevels().Levels())
+}
+
+func TestLevelRegistryWatch(t *testing.T) {
+	defer func() {
+		_ = ModuleLevels().ApplyLevels(nil)
+		_ = ModuleLevels().ApplyLoggerLevels("app", nil)
+	}()
+	source := &levelSource{changes: make(chan []byte, 1)}
+	source.data = []byte(`{"logger":{"name":"app","default":true,"level":"info","module_levels":{"db":"error"}}}`)
+	c := kratosconfig.New(kratosconfig.WithSource(source))
+	require.NoError(t, c.Load())
+	defer c.Close()
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := NewLogger(&loggerv1.Logger{
+		Name: "app", Level: "info", ModuleLevels: map[string]string{"db": "error"},
+		Outputs: []*loggerv1.Logger_Output{{File: &loggerv1.Logger_File{Path: path}}},
+	})
+	defer Close(logger)
+	require.NoError(t, WatchModuleLevels(c))
+
+	_ = WithModule(logger, "db").Log(LevelInfo, "msg", "dropped")
+	// Reloading the module levels reaches the logger configuring them.
+	source.changes <- []byte(`{"logger":{"name":"app","default":true,"level":"info","module_levels":{"db":"debug"}}}`)
+	require.Eventually(t, func() bool {
+		level, _ := ModuleLevels().LoggerLevel("app", "db")
+		return level == LevelDebug
+	}, time.Second, 10*time.Millisecond)
+	_ = WithModule(logger, "db").Log(LevelDebug, "msg", "kept")
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "dropped")
+	assert.Contains(t, string(data), "kept")
+	level, _ := ModuleLevels().Level("db")
+	assert.Equal(t, LevelDebug, level)
+}
+
+// levelSource is a configuration source of JSON data, changed by sending to changes.
+type levelSource struct {
+	data    []byte
+	changes chan []byte
+}
+
+func (s *levelSource) Load() ([]*kratosconfig.KeyValue, error) {
+	return []*kratosconfig.KeyValue{{Key: "config", Value: s.data, Format: "json"}}, nil
+}
+
+func (s *levelSource) Watch() (kratosconfig.Watcher, error) {
+	return s, nil
+}
+
+func (s *levelSource) Next() ([]*kratosconfig.KeyValue, error) {
+	data, ok := <-s.changes
+	if !ok {
+		return nil, context.Canceled
+	}
+	return []*kratosconfig.KeyValue{{Key: "config", Value: data, Format: "json"}}, nil
+}
+
+func (s *levelSource) Stop() error {
+	close(s.changes)
+	return nil
+}
+
+func TestLevelHandler(t *testing.T) {
+	r := NewLevelRegistry()
+	h := LevelHandler(r)
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/", strings.NewReader(`{"module":"server","level":"debug","ttl":"5m"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"module":"server","level":"debug","override":true,"expires"`)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/?module=server&level=loud", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/?module=server", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]\n", w.Body.String())
+	_, ok := r.Level("server")
+	assert.False(t, ok)
+}
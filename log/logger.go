@@ -6,6 +6,10 @@
 package log
 
 import (
+	"errors"
+	"io"
+	"log/slog"
+	"reflect"
 	"strings"
 	"sync"
 
@@ -59,6 +63,8 @@ func GetSlogLogger() *slogx.Logger {
 
 // NewLogger creates a new kratos logger based on the provided configuration.
 // It uses slog as the underlying logging library and slog-kratos as an adapter.
+// Entries are written to every output of the configuration, or to the output made of
+// its stdout, file and format fields when it has none. Close releases the logger.
 func NewLogger(cfg *loggerv1.Logger) Logger {
 	if cfg == nil {
 		return DefaultLogger
@@ -67,63 +73,161 @@ func NewLogger(cfg *loggerv1.Logger) Logger {
 		return NewDiscard()
 	}
 
-	var options []slogx.Option
+	outputs := cfg.GetOutputs()
+	if len(outputs) == 0 {
+		outputs = []*loggerv1.Logger_Output{{
+			Format: cfg.GetFormat(),
+			Stdout: cfg.GetStdout(),
+			File:   cfg.GetFile(),
+		}}
+	}
 
-	// Configure output writers
-	if cfg.GetStdout() {
-		options = append(options, slogx.WithConsole(true))
+	redactor, err := NewRedactor(cfg.GetRedaction())
+	if err != nil {
+		kratoslog.Errorf("logger: %v, using the default redaction", err)
+		redactor = defaultRedactor
 	}
 
-	if fileConfig := cfg.GetFile(); fileConfig != nil {
-		if fileConfig.GetLumberjack() {
-			options = append(options, slogx.WithLumberjack(&slogx.LumberjackLogger{
-				Filename:   fileConfig.GetPath(),
-				MaxSize:    int(fileConfig.GetMaxSize()),
-				MaxAge:     int(fileConfig.GetMaxAge()),
-				MaxBackups: int(fileConfig.GetMaxBackups()),
-				LocalTime:  fileConfig.GetLocalTime(),
-				Compress:   fileConfig.GetCompress(),
-			}))
-		} else {
-			// Use the path from fileConfig, not the logger's name
-			options = append(options, slogx.WithOutputFile(fileConfig.GetPath()))
-		}
+	sampler, err := NewSampler(cfg.GetSampling())
+	if err != nil {
+		kratoslog.Errorf("logger: %v, entries are not sampled", err)
 	}
 
-	// If no output is configured, default to console output
-	if len(options) == 0 {
-		options = append(options, slogx.WithConsole(true))
+	var slogLogger *slogx.Logger
+	var samplers []*Sampler
+	var closers []io.Closer
+	loggers := make([]Logger, 0, len(outputs))
+	for _, output := range outputs {
+		format := output.GetFormat()
+		if format == "" {
+			format = cfg.GetFormat()
+		}
+		var outputSampler *Sampler
+		if sampler != nil {
+			// Each output counts its entries with a sampler of its own.
+			outputSampler = sampler.fork()
+			samplers = append(samplers, outputSampler)
+		}
+		sl, files := newSlogLogger(cfg, output, format, redactor, outputSampler)
+		closers = append(closers, files...)
+		if slogLogger == nil {
+			slogLogger = sl
+		}
+		// Adapt the slog logger to the kratos logger interface
+		var logger Logger = kslog.NewLogger(kslog.WithLogger(sl))
+		if output.GetLevel() != "" {
+			logger = NewFilter(logger, FilterLevel(ParseLevel(output.GetLevel())))
+		}
+		loggers = append(loggers, logger)
 	}
 
-	// Configure log format
-	switch cfg.GetFormat() {
-	case "dev":
-		options = append(options, slogx.WithFormat(slogx.FormatDev))
-	case "json":
-		options = append(options, slogx.WithFormat(slogx.FormatJSON))
-	case "tint":
-		options = append(options, slogx.WithFormat(slogx.FormatTint))
-	default:
-		options = append(options, slogx.WithFormat(slogx.FormatText))
+	// The module levels are kept in the package-level registry, under the name of the
+	// logger, so that reloading them reaches the logger. A logger without any applies
+	// those of the default logger. The overrides of the registry apply to all of them.
+	if err = levels.applyConfig(cfg); err != nil {
+		kratoslog.Errorf("logger: %v", err)
 	}
 
-	// Configure log level
-	options = append(options, LevelOption(cfg.GetLevel()))
+	hooks, err := newConfigHooks(cfg, redactor)
+	if err != nil {
+		kratoslog.Errorf("logger: %v, entries are not shipped to hooks", err)
+	}
 
-	// Create the underlying slog logger
-	slogLogger := slogx.New(options...)
+	if cfg.GetBuffer().GetEnabled() {
+		buffer := NewRingBuffer(cfg.GetBuffer(), redactor)
+		loggers = append(loggers, buffer)
+		if cfg.GetDefault() || Buffer() == nil {
+			SetBuffer(buffer)
+		}
+	}
 
-	// Adapt the slog logger to the kratos logger interface
-	kratosLogger := kslog.NewLogger(kslog.WithLogger(slogLogger))
+	ll := &levelLogger{
+		logger:     NewHookLogger(NewMultiLogger(loggers...), hooks...),
+		level:      ParseLevel(cfg.GetLevel()),
+		registry:   levels,
+		name:       cfg.GetName(),
+		caller:     !cfg.GetDisableCaller(),
+		callerSkip: int(cfg.GetCallerSkip()),
+	}
+	var kratosLogger Logger = ll
+	if !cfg.GetContext().GetDisabled() {
+		kratosLogger = WithContextFields(kratosLogger, cfg.GetContext().GetKeys()...)
+	}
+	if len(hooks) > 0 || len(samplers) > 0 || len(closers) > 0 {
+		// The logger is not wrapped, the kratos loggers bind the contexts of their
+		// entries to the valuers of the context fields only when they are outermost.
+		registerResources(kratosLogger, &loggerResources{hooks: hooks, samplers: samplers, closers: closers})
+	}
 
 	if cfg.GetDefault() {
-		SetSlogLogger(slogLogger)
+		// The slog handlers log at any level, the levels are those of the level logger.
+		SetSlogLogger(slog.New(&slogLevelHandler{Handler: slogLogger.Handler(), levels: ll}))
 		kratoslog.SetLogger(kratosLogger)
 	}
 
 	return kratosLogger
 }
 
+// loggerResources are the hooks, the samplers and the files of a logger built by
+// NewLogger, released by Close.
+type loggerResources struct {
+	hooks    []*Hook
+	samplers []*Sampler
+	closers  []io.Closer
+}
+
+// close reports the entries dropped by the samplers, delivers the messages queued by the
+// hooks, closes their sinks and closes the files of the outputs.
+func (r *loggerResources) close() error {
+	for _, s := range r.samplers {
+		s.Flush()
+	}
+	var errs []error
+	for _, hook := range r.hooks {
+		if err := hook.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, c := range r.closers {
+		if err := c.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resources holds the resources of the loggers built by NewLogger until they are closed.
+var resources = struct {
+	mu      sync.Mutex
+	loggers map[Logger]*loggerResources
+}{loggers: make(map[Logger]*loggerResources)}
+
+func registerResources(logger Logger, r *loggerResources) {
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	resources.loggers[logger] = r
+}
+
+// Close releases the resources of a logger returned by NewLogger, such as its hooks and
+// its files, or closes logger when it implements io.Closer. The entries logged afterwards
+// are not shipped to the hooks anymore. Close may be called more than once.
+func Close(logger Logger) error {
+	if t := reflect.TypeOf(logger); t == nil || !t.Comparable() {
+		return nil
+	}
+	resources.mu.Lock()
+	r, ok := resources.loggers[logger]
+	delete(resources.loggers, logger)
+	resources.mu.Unlock()
+	if ok {
+		return r.close()
+	}
+	if c, ok := logger.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
 // LevelOption converts a string level to a slogx.Option.
 func LevelOption(level string) slogx.Option {
 	var ll slogx.Level
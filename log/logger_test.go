@@ -0,0 +1,68 @@
+package log
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+type loggersSource struct {
+	logger  *loggerv1.Logger
+	loggers *loggerv1.Loggers
+}
+
+func (s loggersSource) GetLogger() *loggerv1.Logger   { return s.logger }
+func (s loggersSource) GetLoggers() *loggerv1.Loggers { return s.loggers }
+
+func TestResolveLoggers(t *testing.T) {
+	t.Run("single logger", func(t *testing.T) {
+		res, err := Resolve(context.Background(), loggersSource{logger: &loggerv1.Logger{}}, nil)
+		require.NoError(t, err)
+		require.Len(t, res.Entries, 1)
+		assert.Equal(t, "logger", res.Active)
+	})
+
+	t.Run("named loggers", func(t *testing.T) {
+		res, err := Resolve(context.Background(), loggersSource{
+			logger: &loggerv1.Logger{Level: "info"},
+			loggers: &loggerv1.Loggers{Configs: []*loggerv1.Logger{
+				{Name: "access"},
+				{Name: "audit"},
+			}},
+		}, nil)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range res.Entries {
+			names = append(names, entry.Name)
+		}
+		assert.Equal(t, []string{"access", "audit", "logger"}, names)
+		assert.Equal(t, "logger", res.Active)
+	})
+
+	t.Run("active logger", func(t *testing.T) {
+		active := "audit"
+		res, err := Resolve(context.Background(), loggersSource{
+			loggers: &loggerv1.Loggers{Active: &active, Configs: []*loggerv1.Logger{
+				{Name: "access"},
+				{Name: "audit"},
+			}},
+		}, nil)
+		require.NoError(t, err)
+		assert.Len(t, res.Entries, 2)
+		assert.Equal(t, "audit", res.Active)
+	})
+}
+
+func TestMultiLogger(t *testing.T) {
+	all, errs := &recordLogger{}, &recordLogger{}
+	logger := NewMultiLogger(all, NewFilter(errs, FilterLevel(LevelError)))
+	require.NoError(t, logger.Log(LevelInfo, "msg", "started"))
+	require.NoError(t, logger.Log(LevelError, "msg", "failed"))
+	assert.Equal(t, []Level{LevelInfo, LevelError}, all.levels)
+	assert.Equal(t, []Level{LevelError}, errs.levels)
+	assert.Same(t, all, NewMultiLogger(all))
+}
@@ -0,0 +1,31 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"errors"
+)
+
+// multiLogger writes every entry to all of its loggers.
+type multiLogger []Logger
+
+// NewMultiLogger returns a logger writing every entry to all of loggers. An entry is
+// written to the remaining loggers when one of them fails, the errors are joined.
+func NewMultiLogger(loggers ...Logger) Logger {
+	if len(loggers) == 1 {
+		return loggers[0]
+	}
+	return multiLogger(loggers)
+}
+
+func (m multiLogger) Log(level Level, keyvals ...any) error {
+	var errs []error
+	for _, logger := range m {
+		if err := logger.Log(level, keyvals...); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
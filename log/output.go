@@ -0,0 +1,211 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"io"
+	"log/slog"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+	"github.com/golang-cz/devslog"
+	"github.com/lmittmann/tint"
+	"gopkg.in/natefinch/lumberjack.v2"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	"github.com/origadmin/toolkits/slogx"
+)
+
+// callerKey is the key of the caller added to the entries of the loggers of NewLogger.
+const callerKey = "caller"
+
+// timeLayouts maps the names of the time layouts of the time package to their layout,
+// so that time_format may be given either way.
+var timeLayouts = map[string]string{
+	"ANSIC":       time.ANSIC,
+	"UnixDate":    time.UnixDate,
+	"RFC822":      time.RFC822,
+	"RFC822Z":     time.RFC822Z,
+	"RFC850":      time.RFC850,
+	"RFC1123":     time.RFC1123,
+	"RFC1123Z":    time.RFC1123Z,
+	"RFC3339":     time.RFC3339,
+	"RFC3339Nano": time.RFC3339Nano,
+	"Kitchen":     time.Kitchen,
+	"Stamp":       time.Stamp,
+	"StampMilli":  time.StampMilli,
+	"StampMicro":  time.StampMicro,
+	"StampNano":   time.StampNano,
+	"DateTime":    time.DateTime,
+	"DateOnly":    time.DateOnly,
+	"TimeOnly":    time.TimeOnly,
+}
+
+// newSlogLogger creates the slog logger writing to output in format, masking the
+// sensitive values with redactor and sampling the entries with sampler, unless they are
+// nil. It returns the files of output along with it, to be closed with the logger.
+func newSlogLogger(cfg *loggerv1.Logger, output *loggerv1.Logger_Output, format string, redactor *Redactor, sampler *Sampler) (*slogx.Logger, []io.Closer) {
+	w, closers := outputWriter(output)
+	handler := newHandler(w, format, cfg)
+	if redactor != nil {
+		handler = redactor.Handler(handler)
+	}
+	if sampler != nil {
+		handler = sampler.Handler(handler)
+	}
+	return slog.New(handler), closers
+}
+
+// outputWriter returns the writer of output, standard output when it has none, and the
+// closers of its file. Its file is rotated by time when a rotation is set, by size when
+// lumberjack is.
+func outputWriter(output *loggerv1.Logger_Output) (io.Writer, []io.Closer) {
+	var writers []io.Writer
+	var closers []io.Closer
+	if output.GetStdout() {
+		writers = append(writers, os.Stdout)
+	}
+	if fileConfig := output.GetFile(); fileConfig != nil {
+		if fileConfig.GetRotation() != "" {
+			if rw, err := NewRotateWriter(fileConfig); err != nil {
+				kratoslog.Errorf("logger: %v", err)
+			} else {
+				writers = append(writers, rw)
+				closers = append(closers, rw)
+			}
+		} else if fileConfig.GetLumberjack() {
+			lj := &lumberjack.Logger{
+				Filename:   fileConfig.GetPath(),
+				MaxSize:    int(fileConfig.GetMaxSize()),
+				MaxAge:     int(fileConfig.GetMaxAge()),
+				MaxBackups: int(fileConfig.GetMaxBackups()),
+				LocalTime:  fileConfig.GetLocalTime(),
+				Compress:   fileConfig.GetCompress(),
+			}
+			writers = append(writers, lj)
+			closers = append(closers, lj)
+		} else if f, err := os.OpenFile(fileConfig.GetPath(), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644); err != nil {
+			kratoslog.Errorf("logger: failed to open log file %s: %v", fileConfig.GetPath(), err)
+		} else {
+			writers = append(writers, f)
+			closers = append(closers, f)
+		}
+	}
+	switch len(writers) {
+	case 0:
+		// If no output is configured, default to console output
+		return os.Stdout, nil
+	case 1:
+		return writers[0], closers
+	}
+	return io.MultiWriter(writers...), closers
+}
+
+// newHandler creates the slog handler writing to w in format. The level is applied by
+// the level logger of NewLogger, so that the level of a module can be lowered at
+// runtime below the configured one.
+func newHandler(w io.Writer, format string, cfg *loggerv1.Logger) slog.Handler {
+	layout := timeLayout(cfg.GetTimeFormat())
+	if format == "" && cfg.GetDevelop() {
+		format = "dev"
+	}
+	switch format {
+	case "dev":
+		dev := cfg.GetDevLogger()
+		return devslog.NewHandler(w, &devslog.Options{
+			HandlerOptions:     &slog.HandlerOptions{Level: slog.LevelDebug},
+			MaxSlicePrintSize:  uint(dev.GetMaxSlice()),
+			SortKeys:           dev.GetSortKeys(),
+			TimeFormat:         layout,
+			NewLineAfterLog:    dev.GetNewline(),
+			StringIndentation:  dev.GetIndent(),
+			DebugColor:         devslog.Color(dev.GetDebugColor()),
+			InfoColor:          devslog.Color(dev.GetInfoColor()),
+			WarnColor:          devslog.Color(dev.GetWarnColor()),
+			ErrorColor:         devslog.Color(dev.GetErrorColor()),
+			MaxErrorStackTrace: uint(dev.GetMaxTrace()),
+			StringerFormatter:  dev.GetFormatter(),
+		})
+	case "tint":
+		if layout == "" {
+			layout = time.StampMilli
+		}
+		return tint.NewHandler(w, &tint.Options{Level: slog.LevelDebug, TimeFormat: layout})
+	}
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if layout != "" {
+		opts.ReplaceAttr = func(groups []string, a slog.Attr) slog.Attr {
+			if len(groups) == 0 && a.Key == slog.TimeKey && a.Value.Kind() == slog.KindTime {
+				a.Value = slog.StringValue(a.Value.Time().Format(layout))
+			}
+			return a
+		}
+	}
+	if format == "json" {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+func timeLayout(format string) string {
+	if layout, ok := timeLayouts[format]; ok {
+		return layout
+	}
+	return format
+}
+
+// caller returns the location of the code logging an entry, skipping the frames of the
+// logging packages and skip more frames.
+func caller(skip int) string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !isLogFrame(frame) {
+			if skip == 0 {
+				return shortFile(frame.File) + ":" + strconv.Itoa(frame.Line)
+			}
+			skip--
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+// isLogFrame reports whether frame belongs to the kratos log package or this one, the
+// tests of this package excepted.
+func isLogFrame(frame runtime.Frame) bool {
+	for _, pkg := range []string{"github.com/go-kratos/kratos/v2/log", "github.com/origadmin/runtime/log"} {
+		if rest, ok := strings.CutPrefix(frame.Function, pkg); ok && (strings.HasPrefix(rest, ".") || strings.HasPrefix(rest, "/")) {
+			return !strings.HasSuffix(frame.File, "_test.go")
+		}
+	}
+	return false
+}
+
+// shortFile keeps the directory and name of file, like the kratos DefaultCaller.
+func shortFile(file string) string {
+	idx := strings.LastIndexByte(file, '/')
+	if idx < 0 {
+		return file
+	}
+	idx = strings.LastIndexByte(file[:idx], '/')
+	return file[idx+1:]
+}
+
+// hasKey reports whether key is one of the keys of keyvals.
+func hasKey(keyvals []any, key string) bool {
+	for i := 0; i < len(keyvals); i += 2 {
+		if k, ok := keyvals[i].(string); ok && k == key {
+			return true
+		}
+	}
+	return false
+}
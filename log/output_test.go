@@ -0,0 +1,153 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"regexp"
+	"testing"
+
+	kratoslog "github.com/go-kratos/kratos/v2/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+// render writes an entry with the handler of cfg in format and returns the output.
+func render(t *testing.T, format string, cfg *loggerv1.Logger) string {
+	t.Helper()
+	var buf bytes.Buffer
+	logger := slog.New(newHandler(&buf, format, cfg))
+	logger.Debug("hello", "b", 2, "a", []int{1, 2, 3, 4}, "text", "line1\nline2")
+	return buf.String()
+}
+
+func TestHandlerFormats(t *testing.T) {
+	t.Run("text time format", func(t *testing.T) {
+		out := render(t, "text", &loggerv1.Logger{TimeFormat: "DateOnly"})
+		assert.Regexp(t, `^time=\d{4}-\d{2}-\d{2} level=DEBUG msg=hello`, out)
+	})
+
+	t.Run("json time format", func(t *testing.T) {
+		out := render(t, "json", &loggerv1.Logger{TimeFormat: "15:04"})
+		assert.Regexp(t, `^\{"time":"\d{2}:\d{2}","level":"DEBUG","msg":"hello"`, out)
+	})
+
+	t.Run("default time format", func(t *testing.T) {
+		out := render(t, "json", &loggerv1.Logger{})
+		assert.Regexp(t, `"time":"\d{4}-\d{2}-\d{2}T`, out)
+	})
+
+	t.Run("tint time format", func(t *testing.T) {
+		out := render(t, "tint", &loggerv1.Logger{TimeFormat: "Kitchen"})
+		assert.Regexp(t, `\d{1,2}:\d{2}(AM|PM)`, out)
+		assert.Contains(t, out, "hello")
+	})
+
+	t.Run("develop", func(t *testing.T) {
+		out := render(t, "", &loggerv1.Logger{Develop: true, TimeFormat: "[15:04]"})
+		assert.Regexp(t, `\[\d{2}:\d{2}\]`, out)
+		// devslog prints the attributes on lines of their own.
+		assert.Regexp(t, `(?m)^.*b.*: .*2`, out)
+	})
+
+	t.Run("dev logger options", func(t *testing.T) {
+		out := render(t, "dev", &loggerv1.Logger{DevLogger: &loggerv1.Logger_DevLogger{
+			MaxSlice:   2,
+			SortKeys:   true,
+			Newline:    true,
+			DebugColor: uint32(6),
+		}})
+		// The debug color is magenta.
+		assert.Contains(t, out, "\x1b[45m")
+		assert.True(t, bytes.HasSuffix([]byte(out), []byte("\n\n")), "missing blank line: %q", out)
+		plain := regexp.MustCompile(`\x1b\[[0-9;]*m`).ReplaceAllString(out, "")
+		assert.Less(t, bytes.Index([]byte(plain), []byte(" a")), bytes.Index([]byte(plain), []byte(" b")), "keys not sorted: %q", plain)
+		assert.NotContains(t, plain, "2: 3")
+	})
+}
+
+func TestOutputWriter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	w, closers := outputWriter(&loggerv1.Logger_Output{File: &loggerv1.Logger_File{Path: path}})
+	slog.New(newHandler(w, "json", &loggerv1.Logger{})).Info("to file")
+	require.Len(t, closers, 1)
+	require.NoError(t, closers[0].Close())
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(content), `"msg":"to file"`)
+}
+
+func TestLoggerCloseFiles(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	logger := NewLogger(&loggerv1.Logger{
+		Outputs: []*loggerv1.Logger_Output{{File: &loggerv1.Logger_File{Path: path}}},
+	})
+	resources.mu.Lock()
+	r := resources.loggers[logger]
+	resources.mu.Unlock()
+	require.NotNil(t, r)
+	require.Len(t, r.closers, 1)
+
+	// Close closes the files of the outputs, so that rebuilding a logger leaks none.
+	require.NoError(t, Close(logger))
+	assert.ErrorIs(t, r.closers[0].Close(), os.ErrClosed)
+}
+
+func TestCaller(t *testing.T) {
+	rec := &keyvalsLogger{}
+	logger := &levelLogger{logger: rec, level: LevelDebug, registry: NewLevelRegistry(), caller: true}
+
+	kratoslog.NewHelper(logger).Info("helper")
+	require.Len(t, rec.entries, 1)
+	assert.Regexp(t, `^log/output_test\.go:\d+$`, value(rec.entries[0], callerKey))
+
+	logVia(logger)
+	logger.callerSkip = 1
+	logVia(logger)
+	assert.NotEqual(t, value(rec.entries[1], callerKey), value(rec.entries[2], callerKey))
+	assert.Equal(t, value(rec.entries[0], callerKey)[:len("log/output_test.go")], value(rec.entries[2], callerKey)[:len("log/output_test.go")])
+
+	_ = WithDecorate(logger, nil).Log(LevelInfo, "msg", "decorated")
+	assert.Equal(t, 1, count(rec.entries[3], callerKey))
+
+	logger.caller = false
+	_ = logger.Log(LevelInfo, "msg", "no caller")
+	assert.Equal(t, 0, count(rec.entries[4], callerKey))
+}
+
+// logVia logs through a wrapper, which a caller skip of one hides.
+func logVia(logger Logger) {
+	_ = logger.Log(LevelInfo, "msg", "via")
+}
+
+type keyvalsLogger struct {
+	entries [][]any
+}
+
+func (l *keyvalsLogger) Log(level Level, keyvals ...any) error {
+	l.entries = append(l.entries, keyvals)
+	return nil
+}
+
+func value(keyvals []any, key string) string {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == key {
+			s, _ := keyvals[i+1].(string)
+			return s
+		}
+	}
+	return ""
+}
+
+func count(keyvals []any, key string) int {
+	n := 0
+	for i := 0; i < len(keyvals); i += 2 {
+		if keyvals[i] == key {
+			n++
+		}
+	}
+	return n
+}
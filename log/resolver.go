@@ -8,6 +8,7 @@ import (
 	"context"
 
 	"github.com/go-kratos/kratos/v2/log"
+	"google.golang.org/protobuf/proto"
 
 	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
 	"github.com/origadmin/runtime/contracts"
@@ -16,24 +17,49 @@ import (
 	"github.com/origadmin/runtime/helpers/configutil"
 )
 
-// Resolve resolves the logger configuration.
+// Resolve resolves the logger configuration. The named loggers of a LoggersConfig are
+// resolved as entries of their own, a single logger of a LoggerConfig joins them as the
+// default one when the loggers have none.
 func Resolve(ctx context.Context, source any, opts *component.LoadOptions) (*component.ModuleConfig, error) {
+	var logger *loggerv1.Logger
 	if c, ok := source.(contracts.LoggerConfig); ok {
-		logger := c.GetLogger()
+		logger = c.GetLogger()
+	}
+	// Priority: Name -> Type
+	name := configutil.ExtractName(logger)
+	if name == "" {
+		name = "logger"
+	}
+	var loggers *loggerv1.Loggers
+	if c, ok := source.(contracts.LoggersConfig); ok {
+		loggers = c.GetLoggers()
+	}
+	if loggers == nil {
 		if logger == nil {
 			return nil, nil
 		}
-		// Priority: Name -> Type
-		name := configutil.ExtractName(logger)
-		if name == "" {
-			name = "logger"
-		}
 		return &component.ModuleConfig{
 			Entries: []component.ConfigEntry{{Name: name, Value: logger}},
 			Active:  name,
 		}, nil
 	}
-	return nil, nil
+
+	def := loggers.GetDefault()
+	if def == nil && logger != nil {
+		def = proto.CloneOf(logger)
+		def.Name = name
+	}
+	def, configs, err := configutil.Normalize(loggers.GetActive(), def, loggers.GetConfigs())
+	if err != nil {
+		return nil, err
+	}
+	res := &component.ModuleConfig{Active: configutil.ExtractName(def)}
+	for _, cfg := range configs {
+		if name := configutil.ExtractName(cfg); name != "" {
+			res.Entries = append(res.Entries, component.ConfigEntry{Name: name, Value: cfg})
+		}
+	}
+	return res, nil
 }
 
 // DefaultProvider is the engine-compatible provider for logger components.
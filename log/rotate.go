@@ -0,0 +1,324 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+// Rotation periods of the time-rotated files.
+const (
+	RotationHourly = "hourly"
+	RotationDaily  = "daily"
+)
+
+// compressSuffix is the suffix of the compressed rotated files.
+const compressSuffix = ".gz"
+
+// patternGlob replaces the verbs of a filename pattern with wildcards.
+var patternGlob = strings.NewReplacer("%Y", "*", "%m", "*", "%d", "*", "%H", "*", "%M", "*", "%%", "%")
+
+// patternVerbs are the expressions of the values of the verbs of a filename pattern.
+var patternVerbs = map[byte]string{'Y': `\d{4}`, 'm': `\d{2}`, 'd': `\d{2}`, 'H': `\d{2}`, 'M': `\d{2}`}
+
+// patternRegexp returns the expression matching the names of the files of pattern, and
+// of their compressed copies. Unlike its glob, it tells apart the files of the patterns
+// with other verbs, such as those of a daily and an hourly rotation in a directory.
+func patternRegexp(pattern string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteByte('^')
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		if c != '%' || i+1 == len(pattern) {
+			b.WriteString(regexp.QuoteMeta(string(c)))
+			continue
+		}
+		i++
+		if expr, ok := patternVerbs[pattern[i]]; ok {
+			b.WriteString(expr)
+		} else if pattern[i] == '%' {
+			b.WriteByte('%')
+		} else {
+			b.WriteString(regexp.QuoteMeta(pattern[i-1 : i+1]))
+		}
+	}
+	b.WriteString(`(?:` + regexp.QuoteMeta(compressSuffix) + `)?$`)
+	return regexp.MustCompile(b.String())
+}
+
+// RotateWriter writes to a file named after the current period, a new file is opened
+// when the period changes. The rotated files are compressed and removed by age, count
+// and total size as configured, in the background. It is safe for concurrent use.
+type RotateWriter struct {
+	pattern    string
+	glob       string
+	match      *regexp.Regexp
+	period     string
+	location   *time.Location
+	compress   bool
+	maxAge     time.Duration
+	maxBackups int
+	maxTotal   int64
+	now        func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	next time.Time
+
+	millMu sync.Mutex
+	wg     sync.WaitGroup
+}
+
+// NewRotateWriter creates a RotateWriter from a file configuration with a rotation period.
+func NewRotateWriter(cfg *loggerv1.Logger_File) (*RotateWriter, error) {
+	period := strings.ToLower(cfg.GetRotation())
+	if period != RotationHourly && period != RotationDaily {
+		return nil, fmt.Errorf("log: invalid rotation %q, want %s or %s", cfg.GetRotation(), RotationHourly, RotationDaily)
+	}
+	pattern := cfg.GetPattern()
+	if pattern == "" {
+		if cfg.GetPath() == "" {
+			return nil, errors.New("log: a rotated file needs a path or a pattern")
+		}
+		ext := filepath.Ext(cfg.GetPath())
+		pattern = strings.TrimSuffix(cfg.GetPath(), ext) + "-%Y-%m-%d"
+		if period == RotationHourly {
+			pattern += "-%H"
+		}
+		pattern += ext
+	} else if !filepath.IsAbs(pattern) && filepath.Dir(pattern) == "." && cfg.GetPath() != "" {
+		// A bare pattern names the files in the directory of the path.
+		pattern = filepath.Join(filepath.Dir(cfg.GetPath()), pattern)
+	}
+	w := &RotateWriter{
+		pattern:    pattern,
+		glob:       patternGlob.Replace(pattern),
+		match:      patternRegexp(pattern),
+		period:     period,
+		location:   time.UTC,
+		compress:   cfg.GetCompress(),
+		maxAge:     time.Duration(cfg.GetMaxAge()) * 24 * time.Hour,
+		maxBackups: int(cfg.GetMaxBackups()),
+		maxTotal:   cfg.GetMaxTotalSize() * 1024 * 1024,
+		now:        time.Now,
+	}
+	if cfg.GetLocalTime() {
+		w.location = time.Local
+	}
+	return w, nil
+}
+
+// Filename returns the name of the file of the period of t.
+func (w *RotateWriter) Filename(t time.Time) string {
+	t = t.In(w.location)
+	var b strings.Builder
+	for i := 0; i < len(w.pattern); i++ {
+		c := w.pattern[i]
+		if c != '%' || i+1 == len(w.pattern) {
+			b.WriteByte(c)
+			continue
+		}
+		i++
+		switch w.pattern[i] {
+		case 'Y':
+			fmt.Fprintf(&b, "%04d", t.Year())
+		case 'm':
+			fmt.Fprintf(&b, "%02d", int(t.Month()))
+		case 'd':
+			fmt.Fprintf(&b, "%02d", t.Day())
+		case 'H':
+			fmt.Fprintf(&b, "%02d", t.Hour())
+		case 'M':
+			fmt.Fprintf(&b, "%02d", t.Minute())
+		case '%':
+			b.WriteByte('%')
+		default:
+			b.WriteByte(c)
+			b.WriteByte(w.pattern[i])
+		}
+	}
+	return b.String()
+}
+
+// Write writes p to the file of the current period.
+func (w *RotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	now := w.now()
+	if w.file == nil || !now.Before(w.next) {
+		if err := w.rotate(now); err != nil {
+			return 0, err
+		}
+	}
+	return w.file.Write(p)
+}
+
+// rotate opens the file of the period of now, and starts the removal of the old files.
+func (w *RotateWriter) rotate(now time.Time) error {
+	if w.file != nil {
+		if err := w.file.Close(); err != nil {
+			return err
+		}
+		w.file = nil
+	}
+	name := w.Filename(now)
+	if err := os.MkdirAll(filepath.Dir(name), 0o755); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.next = w.periodStart(now)
+	if w.period == RotationHourly {
+		w.next = w.next.Add(time.Hour)
+	} else {
+		w.next = w.next.AddDate(0, 0, 1)
+	}
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		w.mill(name, now)
+	}()
+	return nil
+}
+
+// periodStart returns the start of the period of t.
+func (w *RotateWriter) periodStart(t time.Time) time.Time {
+	t = t.In(w.location)
+	hour := 0
+	if w.period == RotationHourly {
+		hour = t.Hour()
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), hour, 0, 0, 0, w.location)
+}
+
+// rotatedFile is a file of a previous period.
+type rotatedFile struct {
+	path    string
+	size    int64
+	modTime time.Time
+}
+
+// mill compresses the files of the previous periods and removes those exceeding the
+// retention, current being the file of the current period.
+func (w *RotateWriter) mill(current string, now time.Time) {
+	w.millMu.Lock()
+	defer w.millMu.Unlock()
+
+	files, err := w.rotatedFiles(current)
+	if err != nil {
+		return
+	}
+	if w.compress {
+		for i, f := range files {
+			if strings.HasSuffix(f.path, compressSuffix) {
+				continue
+			}
+			if compressed, err := compressFile(f.path); err == nil {
+				files[i] = compressed
+			}
+		}
+	}
+
+	// The newest files come first, the oldest are removed first.
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.After(files[j].modTime) })
+	var total int64
+	if info, err := os.Stat(current); err == nil {
+		total = info.Size()
+	}
+	for i, f := range files {
+		total += f.size
+		switch {
+		case w.maxAge > 0 && now.Sub(f.modTime) > w.maxAge,
+			w.maxBackups > 0 && i >= w.maxBackups,
+			w.maxTotal > 0 && total > w.maxTotal:
+			_ = os.Remove(f.path)
+			total -= f.size
+		}
+	}
+}
+
+// rotatedFiles returns the files of the pattern other than current.
+func (w *RotateWriter) rotatedFiles(current string) ([]rotatedFile, error) {
+	plain, err := filepath.Glob(w.glob)
+	if err != nil {
+		return nil, err
+	}
+	compressed, err := filepath.Glob(w.glob + compressSuffix)
+	if err != nil {
+		return nil, err
+	}
+	var files []rotatedFile
+	for _, path := range append(plain, compressed...) {
+		if path == current || !w.match.MatchString(path) {
+			continue
+		}
+		info, err := os.Stat(path)
+		if err != nil || !info.Mode().IsRegular() {
+			continue
+		}
+		files = append(files, rotatedFile{path: path, size: info.Size(), modTime: info.ModTime()})
+	}
+	return files, nil
+}
+
+// compressFile replaces the file at path with its gzip compressed copy.
+func compressFile(path string) (rotatedFile, error) {
+	src, err := os.Open(path)
+	if err != nil {
+		return rotatedFile{}, err
+	}
+	defer src.Close()
+	info, err := src.Stat()
+	if err != nil {
+		return rotatedFile{}, err
+	}
+	dst, err := os.OpenFile(path+compressSuffix, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, info.Mode())
+	if err != nil {
+		return rotatedFile{}, err
+	}
+	zw := gzip.NewWriter(dst)
+	_, err = io.Copy(zw, src)
+	err = errors.Join(err, zw.Close(), dst.Close())
+	if err != nil {
+		_ = os.Remove(path + compressSuffix)
+		return rotatedFile{}, err
+	}
+	// Keep the modification time, the retention orders the files by it.
+	_ = os.Chtimes(path+compressSuffix, info.ModTime(), info.ModTime())
+	_ = os.Remove(path)
+	compressed, err := os.Stat(path + compressSuffix)
+	if err != nil {
+		return rotatedFile{}, err
+	}
+	return rotatedFile{path: path + compressSuffix, size: compressed.Size(), modTime: info.ModTime()}, nil
+}
+
+// Close closes the current file and waits for the removal of the old files.
+func (w *RotateWriter) Close() error {
+	w.mu.Lock()
+	var err error
+	if w.file != nil {
+		err = w.file.Close()
+		w.file = nil
+	}
+	w.mu.Unlock()
+	w.wg.Wait()
+	return err
+}
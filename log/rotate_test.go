@@ -0,0 +1,150 @@
+package log
+
+import (
+	"compress/gzip"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+func newTestRotateWriter(t *testing.T, cfg *loggerv1.Logger_File) (*RotateWriter, *time.Time) {
+	t.Helper()
+	w, err := NewRotateWriter(cfg)
+	require.NoError(t, err)
+	now := time.Date(2026, 10, 16, 23, 30, 0, 0, time.UTC)
+	w.now = func() time.Time { return now }
+	return w, &now
+}
+
+func dirFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotateWriterFilename(t *testing.T) {
+	at := time.Date(2026, 10, 16, 9, 5, 0, 0, time.UTC)
+	tests := []struct {
+		cfg  *loggerv1.Logger_File
+		want string
+	}{
+		{&loggerv1.Logger_File{Path: "logs/app.log", Rotation: "daily"}, "logs/app-2026-10-16.log"},
+		{&loggerv1.Logger_File{Path: "logs/app.log", Rotation: "hourly"}, "logs/app-2026-10-16-09.log"},
+		{&loggerv1.Logger_File{Path: "logs/app.log", Rotation: "Daily", Pattern: "app1_%Y%m%d_%H%M.100%%.log"}, "logs/app1_20261016_0905.100%.log"},
+		{&loggerv1.Logger_File{Rotation: "daily", Pattern: "/var/log/%Y/%m/%d.log"}, "/var/log/2026/10/16.log"},
+	}
+	for _, tt := range tests {
+		w, err := NewRotateWriter(tt.cfg)
+		require.NoError(t, err)
+		assert.Equal(t, tt.want, w.Filename(at))
+	}
+
+	_, err := NewRotateWriter(&loggerv1.Logger_File{Path: "app.log", Rotation: "weekly"})
+	assert.Error(t, err)
+	_, err = NewRotateWriter(&loggerv1.Logger_File{Rotation: "daily"})
+	assert.Error(t, err)
+}
+
+func TestRotateWriter(t *testing.T) {
+	dir := t.TempDir()
+	w, now := newTestRotateWriter(t, &loggerv1.Logger_File{
+		Path:     filepath.Join(dir, "app.log"),
+		Rotation: "daily",
+		Compress: true,
+	})
+
+	_, err := w.Write([]byte("first\n"))
+	require.NoError(t, err)
+	*now = now.Add(20 * time.Minute)
+	_, err = w.Write([]byte("second\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"app-2026-10-16.log"}, dirFiles(t, dir))
+
+	*now = now.Add(time.Hour)
+	_, err = w.Write([]byte("third\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"app-2026-10-16.log.gz", "app-2026-10-17.log"}, dirFiles(t, dir))
+
+	f, err := os.Open(filepath.Join(dir, "app-2026-10-16.log.gz"))
+	require.NoError(t, err)
+	defer f.Close()
+	zr, err := gzip.NewReader(f)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	assert.Equal(t, "first\nsecond\n", string(data))
+}
+
+func TestRotateWriterRetention(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	for day := 1; day <= 6; day++ {
+		name := filepath.Join(dir, time.Date(2026, 10, 15-day, 0, 0, 0, 0, time.UTC).Format("app-2006-01-02.log"))
+		require.NoError(t, os.WriteFile(name, make([]byte, 512*1024), 0o644))
+		modTime := now.AddDate(0, 0, -day)
+		require.NoError(t, os.Chtimes(name, modTime, modTime))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "other.log"), nil, 0o644))
+
+	t.Run("max age", func(t *testing.T) {
+		w, _ := newTestRotateWriter(t, &loggerv1.Logger_File{Path: filepath.Join(dir, "app.log"), Rotation: "daily", MaxAge: 5})
+		w.now = func() time.Time { return now }
+		_, err := w.Write([]byte("x\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.NotContains(t, dirFiles(t, dir), "app-2026-10-09.log")
+		assert.Len(t, dirFiles(t, dir), 7)
+	})
+
+	t.Run("max total size", func(t *testing.T) {
+		w, _ := newTestRotateWriter(t, &loggerv1.Logger_File{Path: filepath.Join(dir, "app.log"), Rotation: "daily", MaxTotalSize: 2})
+		w.now = func() time.Time { return now }
+		_, err := w.Write([]byte("x\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		// The current file and three of the old ones fit in 2MB.
+		assert.Equal(t, []string{"app-2026-10-12.log", "app-2026-10-13.log", "app-2026-10-14.log", "app-2026-10-16.log", "other.log"}, dirFiles(t, dir))
+	})
+
+	t.Run("max backups", func(t *testing.T) {
+		w, _ := newTestRotateWriter(t, &loggerv1.Logger_File{Path: filepath.Join(dir, "app.log"), Rotation: "daily", MaxBackups: 1})
+		w.now = func() time.Time { return now }
+		_, err := w.Write([]byte("x\n"))
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+		assert.Equal(t, []string{"app-2026-10-14.log", "app-2026-10-16.log", "other.log"}, dirFiles(t, dir))
+	})
+}
+
+func TestRotateWriterPatterns(t *testing.T) {
+	dir := t.TempDir()
+	for i, name := range []string{"app-2026-10-13.log", "app-2026-10-13-05.log", "app-2026-10-14.log.gz", "app-2026-10-14-05.log"} {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, nil, 0o644))
+		modTime := time.Date(2026, 10, 13, 0, 0, 0, 0, time.UTC).Add(time.Duration(i) * time.Hour)
+		require.NoError(t, os.Chtimes(path, modTime, modTime))
+	}
+
+	// The retention of the daily files leaves those of an hourly rotation alone.
+	w, _ := newTestRotateWriter(t, &loggerv1.Logger_File{Path: filepath.Join(dir, "app.log"), Rotation: "daily", MaxBackups: 1})
+	_, err := w.Write([]byte("x\n"))
+	require.NoError(t, err)
+	require.NoError(t, w.Close())
+	assert.Equal(t, []string{"app-2026-10-13-05.log", "app-2026-10-14-05.log", "app-2026-10-14.log.gz", "app-2026-10-16.log"}, dirFiles(t, dir))
+}
@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package log
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+	"github.com/origadmin/toolkits/slogx"
+)
+
+// DefaultSamplingInterval is the interval of the sampling when none is configured.
+const DefaultSamplingInterval = time.Second
+
+// SamplingRule limits the identical entries of a level logged per interval.
+type SamplingRule struct {
+	// Initial is the number of identical entries logged per interval before sampling.
+	Initial uint64
+	// Thereafter logs every Thereafter-th identical entry after the initial ones, zero
+	// drops them all. A zero Initial and Thereafter do not sample the entries.
+	Thereafter uint64
+}
+
+// allow reports whether the n-th identical entry of an interval is logged.
+func (r SamplingRule) allow(n uint64) bool {
+	if n <= r.Initial || r.Initial == 0 && r.Thereafter == 0 {
+		return true
+	}
+	return r.Thereafter > 0 && (n-r.Initial)%r.Thereafter == 0
+}
+
+// Sampler counts the identical entries, those with the same level and message, logged
+// per interval and drops those exceeding the rule of their level. The number of entries
+// dropped during an interval is reported by a summary entry at its end, or by Flush.
+// A Sampler is safe for concurrent use.
+type Sampler struct {
+	interval time.Duration
+	rule     SamplingRule
+	levels   map[slog.Level]SamplingRule
+	now      func() time.Time
+
+	mu      sync.Mutex
+	resetAt time.Time
+	counts  map[sampleKey]*sampleCount
+	// root writes the summaries, without the attributes and groups of the handlers.
+	root slog.Handler
+	// timer reports the entries dropped during the interval at its end.
+	timer *time.Timer
+}
+
+type sampleKey struct {
+	level slog.Level
+	msg   string
+}
+
+type sampleCount struct {
+	n          uint64
+	suppressed uint64
+}
+
+// NewSampler creates a Sampler from the sampling configuration. It returns nil when cfg
+// is nil, entries are not sampled then.
+func NewSampler(cfg *loggerv1.Logger_Sampling) (*Sampler, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	s := &Sampler{
+		interval: cfg.GetInterval().AsDuration(),
+		rule:     SamplingRule{Initial: uint64(cfg.GetInitial()), Thereafter: uint64(cfg.GetThereafter())},
+		levels:   make(map[slog.Level]SamplingRule, len(cfg.GetLevels())),
+		now:      time.Now,
+		counts:   make(map[sampleKey]*sampleCount),
+	}
+	if s.interval <= 0 {
+		s.interval = DefaultSamplingInterval
+	}
+	for name, rule := range cfg.GetLevels() {
+		level, err := parseLevel(name)
+		if err != nil {
+			return nil, fmt.Errorf("log: invalid sampling level: %w", err)
+		}
+		s.levels[slogLevel(level)] = SamplingRule{Initial: uint64(rule.GetInitial()), Thereafter: uint64(rule.GetThereafter())}
+	}
+	return s, nil
+}
+
+// fork returns a Sampler with the rules of s and counts of its own.
+func (s *Sampler) fork() *Sampler {
+	return &Sampler{
+		interval: s.interval,
+		rule:     s.rule,
+		levels:   s.levels,
+		now:      s.now,
+		counts:   make(map[sampleKey]*sampleCount),
+	}
+}
+
+// suppressed is the number of entries dropped for a key during the last interval.
+type suppressed struct {
+	sampleKey
+	count uint64
+}
+
+// sample reports whether an entry is logged, and returns the entries dropped during
+// the previous interval when a new one starts.
+func (s *Sampler) sample(level slog.Level, msg string) (bool, []suppressed) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var summary []suppressed
+	if now := s.now(); !now.Before(s.resetAt) {
+		summary = s.takeSuppressed()
+		clear(s.counts)
+		s.resetAt = now.Add(s.interval)
+		if s.timer != nil {
+			s.timer.Stop()
+			s.timer = nil
+		}
+	}
+	key := sampleKey{level: level, msg: msg}
+	count, ok := s.counts[key]
+	if !ok {
+		count = &sampleCount{}
+		s.counts[key] = count
+	}
+	count.n++
+	rule, ok := s.levels[level]
+	if !ok {
+		rule = s.rule
+	}
+	if rule.allow(count.n) {
+		return true, summary
+	}
+	count.suppressed++
+	if s.timer == nil && s.root != nil {
+		s.timer = time.AfterFunc(s.resetAt.Sub(s.now()), s.Flush)
+	}
+	return false, summary
+}
+
+// takeSuppressed returns the entries dropped since the last summary and resets their
+// count. s.mu must be held.
+func (s *Sampler) takeSuppressed() []suppressed {
+	var summary []suppressed
+	for key, count := range s.counts {
+		if count.suppressed > 0 {
+			summary = append(summary, suppressed{sampleKey: key, count: count.suppressed})
+			count.suppressed = 0
+		}
+	}
+	return summary
+}
+
+// Flush reports the entries dropped since the last summary, such as before the logger is
+// closed.
+func (s *Sampler) Flush() {
+	s.mu.Lock()
+	summary := s.takeSuppressed()
+	if s.timer != nil {
+		s.timer.Stop()
+		s.timer = nil
+	}
+	root := s.root
+	s.mu.Unlock()
+	if root != nil {
+		s.report(context.Background(), s.now(), summary)
+	}
+}
+
+// report writes the summaries of the dropped entries to the root handler.
+func (s *Sampler) report(ctx context.Context, t time.Time, summary []suppressed) {
+	for _, sup := range summary {
+		r := slog.NewRecord(t, sup.level, fmt.Sprintf("suppressed %d messages", sup.count), 0)
+		r.AddAttrs(slog.String("sampled_msg", sup.msg), slog.Uint64("suppressed", sup.count))
+		_ = s.root.Handle(ctx, r)
+	}
+}
+
+// Handler returns a slog handler dropping the records of h exceeding the rules of the
+// sampler, and writing their summaries to h. Each handler should have its own Sampler,
+// the records are counted per Sampler.
+func (s *Sampler) Handler(h slog.Handler) slog.Handler {
+	s.mu.Lock()
+	s.root = h
+	s.mu.Unlock()
+	return &samplingHandler{handler: h, sampler: s}
+}
+
+// samplingHandler drops the records exceeding the rules of its sampler.
+type samplingHandler struct {
+	handler slog.Handler
+	sampler *Sampler
+}
+
+func (h *samplingHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.handler.Enabled(ctx, level)
+}
+
+func (h *samplingHandler) Handle(ctx context.Context, record slog.Record) error {
+	ok, summary := h.sampler.sample(record.Level, record.Message)
+	h.sampler.report(ctx, record.Time, summary)
+	if !ok {
+		return nil
+	}
+	return h.handler.Handle(ctx, record)
+}
+
+func (h *samplingHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &samplingHandler{handler: h.handler.WithAttrs(attrs), sampler: h.sampler}
+}
+
+func (h *samplingHandler) WithGroup(name string) slog.Handler {
+	return &samplingHandler{handler: h.handler.WithGroup(name), sampler: h.sampler}
+}
+
+// slogLevel converts a kratos level to a slog level. slog-kratos logs the fatal entries
+// of kratos loggers at the error level, fatal only applies to slog loggers.
+func slogLevel(level Level) slog.Level {
+	switch level {
+	case LevelDebug:
+		return slog.LevelDebug
+	case LevelInfo:
+		return slog.LevelInfo
+	case LevelWarn:
+		return slog.LevelWarn
+	case LevelError:
+		return slog.LevelError
+	}
+	return slogx.LevelFatal
+}
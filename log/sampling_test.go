@@ -0,0 +1,161 @@
+package log
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	loggerv1 "github.com/origadmin/runtime/api/gen/go/config/logger/v1"
+)
+
+func newTestSampler(t *testing.T, cfg *loggerv1.Logger_Sampling) (*Sampler, *time.Time) {
+	t.Helper()
+	s, err := NewSampler(cfg)
+	require.NoError(t, err)
+	now := time.Unix(1000, 0)
+	s.now = func() time.Time { return now }
+	// Stop the timer reporting the dropped entries.
+	t.Cleanup(s.Flush)
+	return s, &now
+}
+
+func lines(buf *bytes.Buffer) []string {
+	return strings.Split(strings.TrimSpace(buf.String()), "\n")
+}
+
+func TestSampler(t *testing.T) {
+	s, now := newTestSampler(t, &loggerv1.Logger_Sampling{
+		Interval:   durationpb.New(time.Minute),
+		Initial:    2,
+		Thereafter: 3,
+		Levels: map[string]*loggerv1.Logger_Sampling_Rule{
+			"error": {Initial: 1},
+		},
+	})
+	var buf bytes.Buffer
+	logger := slog.New(s.Handler(slog.NewTextHandler(&buf, nil)))
+
+	for i := 0; i < 10; i++ {
+		logger.Info("hot", "i", i)
+		logger.Error("failed")
+	}
+	logger.Info("other")
+	out := lines(&buf)
+	require.Len(t, out, 6)
+	var hot []string
+	for _, line := range out {
+		if strings.Contains(line, "msg=hot") {
+			hot = append(hot, line[strings.LastIndex(line, " ")+1:])
+		}
+	}
+	assert.Equal(t, []string{"i=0", "i=1", "i=4", "i=7"}, hot)
+	assert.Contains(t, out[1], "msg=failed")
+	assert.Contains(t, out[5], "msg=other")
+
+	buf.Reset()
+	*now = now.Add(time.Minute)
+	logger.Info("hot", "i", 10)
+	out = lines(&buf)
+	require.Len(t, out, 3)
+	summaries := strings.Join(out[:2], "\n")
+	assert.Contains(t, summaries, `level=INFO msg="suppressed 6 messages" sampled_msg=hot suppressed=6`)
+	assert.Contains(t, summaries, `level=ERROR msg="suppressed 9 messages" sampled_msg=failed suppressed=9`)
+	assert.Contains(t, out[2], "i=10")
+}
+
+func TestSamplerConfig(t *testing.T) {
+	s, err := NewSampler(nil)
+	assert.NoError(t, err)
+	assert.Nil(t, s)
+
+	_, err = NewSampler(&loggerv1.Logger_Sampling{Levels: map[string]*loggerv1.Logger_Sampling_Rule{"loud": {}}})
+	assert.Error(t, err)
+
+	s, err = NewSampler(&loggerv1.Logger_Sampling{})
+	require.NoError(t, err)
+	assert.Equal(t, DefaultSamplingInterval, s.interval)
+}
+
+func TestSamplerZeroRule(t *testing.T) {
+	s, _ := newTestSampler(t, &loggerv1.Logger_Sampling{
+		Levels: map[string]*loggerv1.Logger_Sampling_Rule{"error": {Initial: 1}},
+	})
+	var buf bytes.Buffer
+	logger := slog.New(s.Handler(slog.NewTextHandler(&buf, nil)))
+	for i := 0; i < 5; i++ {
+		logger.Info("hot")
+		logger.Error("failed")
+	}
+	out := lines(&buf)
+	assert.Len(t, out, 6)
+	assert.Equal(t, 5, strings.Count(buf.String(), "msg=hot"))
+}
+
+func TestSamplerFlush(t *testing.T) {
+	s, _ := newTestSampler(t, &loggerv1.Logger_Sampling{Initial: 1})
+	var buf bytes.Buffer
+	logger := slog.New(s.Handler(slog.NewTextHandler(&buf, nil)))
+	for i := 0; i < 4; i++ {
+		logger.Info("hot")
+	}
+	s.Flush()
+	out := lines(&buf)
+	require.Len(t, out, 2)
+	assert.Contains(t, out[1], `msg="suppressed 3 messages" sampled_msg=hot suppressed=3`)
+
+	// Nothing is reported twice.
+	buf.Reset()
+	s.Flush()
+	assert.Empty(t, buf.String())
+}
+
+func TestSamplerTimer(t *testing.T) {
+	s, err := NewSampler(&loggerv1.Logger_Sampling{Interval: durationpb.New(10 * time.Millisecond), Initial: 1})
+	require.NoError(t, err)
+	var mu sync.Mutex
+	var buf bytes.Buffer
+	logger := slog.New(s.Handler(slog.NewTextHandler(writerFunc(func(p []byte) (int, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		return buf.Write(p)
+	}), nil)))
+	logger.Info("hot")
+	logger.Info("hot")
+
+	// The entries dropped during the last interval are reported without a later entry.
+	assert.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return strings.Contains(buf.String(), "suppressed 1 messages")
+	}, time.Second, 5*time.Millisecond)
+}
+
+type writerFunc func(p []byte) (int, error)
+
+func (f writerFunc) Write(p []byte) (int, error) { return f(p) }
+
+func TestSamplerConcurrent(t *testing.T) {
+	s, _ := newTestSampler(t, &loggerv1.Logger_Sampling{Initial: 10})
+	var buf bytes.Buffer
+	logger := slog.New(s.Handler(slog.NewTextHandler(&buf, nil))).With("worker", true)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				logger.Warn("busy")
+			}
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, lines(&buf), 10)
+}
@@ -6,9 +6,8 @@
 package middleware
 
 import (
-	"github.com/go-kratos/kratos/v2/middleware/circuitbreaker"
-
 	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/middleware/circuitbreaker"
 )
 
 type circuitBreakerFactory struct {
@@ -16,12 +15,26 @@ type circuitBreakerFactory struct {
 
 func (c circuitBreakerFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
 	mwOpts := FromOptions(opts...)
-	mwOpts.GetLogger("middleware.circuit_breaker").Debug("enabling circuit_breaker client middleware")
-	return circuitbreaker.Client(), true
+	logger := mwOpts.GetLogger("middleware.circuit_breaker")
+	logger.Debug("enabling circuit_breaker client middleware")
+
+	breakerOpts, err := circuitbreaker.FromConfig(cfg.GetCircuitBreaker())
+	if err != nil {
+		logger.Errorf("failed to create circuit_breaker client middleware: %v", err)
+		return nil, false
+	}
+	return circuitbreaker.Client(breakerOpts...), true
 }
 
 func (c circuitBreakerFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
 	mwOpts := FromOptions(opts...)
-	mwOpts.GetLogger("middleware.circuit_breaker").Debug("enabling circuit_breaker server middleware, not supported yet")
-	return nil, false
+	logger := mwOpts.GetLogger("middleware.circuit_breaker")
+	logger.Debug("enabling circuit_breaker server middleware")
+
+	breakerOpts, err := circuitbreaker.FromConfig(cfg.GetCircuitBreaker())
+	if err != nil {
+		logger.Errorf("failed to create circuit_breaker server middleware: %v", err)
+		return nil, false
+	}
+	return circuitbreaker.Server(breakerOpts...), true
 }
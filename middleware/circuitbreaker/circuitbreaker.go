@@ -0,0 +1,238 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package circuitbreaker implements the circuit_breaker middleware: a breaker per
+// operation, counting the failures by configurable conditions, with static or backup
+// service fallbacks for the requests it rejects.
+package circuitbreaker
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-kratos/aegis/circuitbreaker"
+	"github.com/go-kratos/aegis/circuitbreaker/sre"
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	kratoscircuitbreaker "github.com/go-kratos/kratos/v2/middleware/circuitbreaker"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	circuitbreakerv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/circuitbreaker/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Reason is the reason of the errors of the rejected requests.
+const Reason = "CIRCUITBREAKER"
+
+// ErrNotAllowed is returned for the requests rejected by an open breaker without a
+// fallback.
+var ErrNotAllowed = kratoscircuitbreaker.ErrNotAllowed
+
+// Breaker decides whether the requests of an operation may proceed.
+type Breaker = circuitbreaker.CircuitBreaker
+
+// Fallback returns the response of a request of operation rejected by an open breaker.
+type Fallback func(ctx context.Context, operation string, req any) (any, error)
+
+// Group keeps a Breaker per operation, created on first use. It is safe for concurrent
+// use.
+type Group struct {
+	newBreaker func() Breaker
+
+	mu       sync.RWMutex
+	breakers map[string]Breaker
+}
+
+// NewGroup creates a Group creating its breakers with newBreaker.
+func NewGroup(newBreaker func() Breaker) *Group {
+	return &Group{newBreaker: newBreaker, breakers: make(map[string]Breaker)}
+}
+
+// Get returns the Breaker of operation.
+func (g *Group) Get(operation string) Breaker {
+	g.mu.RLock()
+	breaker, ok := g.breakers[operation]
+	g.mu.RUnlock()
+	if ok {
+		return breaker
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if breaker, ok = g.breakers[operation]; !ok {
+		breaker = g.newBreaker()
+		g.breakers[operation] = breaker
+	}
+	return breaker
+}
+
+// NewBreakerFunc returns the constructor of the SRE breakers of cfg, configured by its
+// success_ratio or its ratio, with the defaults of the breaker otherwise or when cfg is
+// nil.
+func NewBreakerFunc(cfg *circuitbreakerv1.CircuitBreaker) (func() Breaker, error) {
+	var opts []sre.Option
+	hasRatio := cfg != nil && cfg.Ratio != nil
+	if cfg.GetSuccessRatio() != nil && hasRatio {
+		return nil, fmt.Errorf("circuitbreaker: only one of success_ratio and ratio can be set")
+	}
+	if ratio := cfg.GetSuccessRatio(); ratio != nil {
+		if success := ratio.GetSuccess(); success != 0 {
+			if success < 0 || success > 1 {
+				return nil, fmt.Errorf("circuitbreaker: success must be within (0, 1], got %v", success)
+			}
+			opts = append(opts, sre.WithSuccess(success))
+		}
+		if request := ratio.GetRequest(); request > 0 {
+			opts = append(opts, sre.WithRequest(int64(request)))
+		}
+		if bucket := ratio.GetBucket(); bucket > 0 {
+			opts = append(opts, sre.WithBucket(int(bucket)))
+		}
+		if window := ratio.GetWindow(); window > 0 {
+			opts = append(opts, sre.WithWindow(time.Duration(window)*time.Millisecond))
+		}
+	}
+	if hasRatio {
+		ratio := cfg.GetRatio()
+		if ratio <= 0 || ratio > 100 {
+			return nil, fmt.Errorf("circuitbreaker: ratio must be within (0, 100], got %d", ratio)
+		}
+		opts = append(opts, sre.WithSuccess(float64(ratio)/100))
+	}
+	return func() Breaker {
+		return sre.NewBreaker(opts...)
+	}, nil
+}
+
+// NewFallback returns the Fallback of cfg, its static response or its backup service,
+// nil when it has none or cfg is nil. The backup service is called with client.
+func NewFallback(cfg *circuitbreakerv1.CircuitBreaker, client *http.Client) (Fallback, error) {
+	switch {
+	case cfg.GetResponseData() != nil && cfg.GetBackupService() != nil:
+		return nil, fmt.Errorf("circuitbreaker: only one of response_data and backup_service can be set")
+	case cfg.GetResponseData() != nil:
+		return ResponseFallback(cfg.GetResponseData()), nil
+	case cfg.GetBackupService() != nil:
+		return BackupFallback(cfg.GetBackupService().GetEndpoint(), client)
+	}
+	return nil, nil
+}
+
+// FromConfig returns the options of the middleware configured by cfg: its breakers, its
+// conditions and its fallback.
+func FromConfig(cfg *circuitbreakerv1.CircuitBreaker) ([]options.Option, error) {
+	newBreaker, err := NewBreakerFunc(cfg)
+	if err != nil {
+		return nil, err
+	}
+	conditions, err := NewConditions(cfg.GetAssertCondtions())
+	if err != nil {
+		return nil, err
+	}
+	fallback, err := NewFallback(cfg, http.DefaultClient)
+	if err != nil {
+		return nil, err
+	}
+	return []options.Option{
+		WithBreaker(newBreaker),
+		WithConditions(conditions...),
+		WithFallback(fallback),
+	}, nil
+}
+
+type breakerOptions struct {
+	group      *Group
+	newBreaker func() Breaker
+	conditions []Condition
+	fallback   Fallback
+}
+
+// WithGroup sets the Group of the breakers of the middleware, to share them between
+// middlewares.
+func WithGroup(group *Group) options.Option {
+	return optionutil.Update(func(o *breakerOptions) {
+		o.group = group
+	})
+}
+
+// WithBreaker sets the constructor of the breakers of the operations, SRE breakers with
+// their defaults by default.
+func WithBreaker(newBreaker func() Breaker) options.Option {
+	return optionutil.Update(func(o *breakerOptions) {
+		o.newBreaker = newBreaker
+	})
+}
+
+// WithConditions sets the conditions counting a request as failed, any of them
+// matching. DefaultCondition is used when none is set.
+func WithConditions(conditions ...Condition) options.Option {
+	return optionutil.Update(func(o *breakerOptions) {
+		o.conditions = conditions
+	})
+}
+
+// WithFallback sets the Fallback of the rejected requests, which fail with ErrNotAllowed
+// by default.
+func WithFallback(fallback Fallback) options.Option {
+	return optionutil.Update(func(o *breakerOptions) {
+		o.fallback = fallback
+	})
+}
+
+// Client returns a client middleware breaking the calls of the operations that fail.
+func Client(opts ...options.Option) kratosmiddleware.Middleware {
+	return newMiddleware(transport.FromClientContext, opts)
+}
+
+// Server returns a server middleware breaking the operations that fail, shedding the
+// load of a struggling service.
+func Server(opts ...options.Option) kratosmiddleware.Middleware {
+	return newMiddleware(transport.FromServerContext, opts)
+}
+
+func newMiddleware(fromContext func(context.Context) (transport.Transporter, bool), opts []options.Option) kratosmiddleware.Middleware {
+	o := optionutil.NewT[breakerOptions](opts...)
+	if o.group == nil {
+		newBreaker := o.newBreaker
+		if newBreaker == nil {
+			newBreaker = func() Breaker { return sre.NewBreaker() }
+		}
+		o.group = NewGroup(newBreaker)
+	}
+	if len(o.conditions) == 0 {
+		o.conditions = []Condition{DefaultCondition}
+	}
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			var operation string
+			tr, ok := fromContext(ctx)
+			if ok {
+				operation = tr.Operation()
+			}
+			breaker := o.group.Get(operation)
+			if err := breaker.Allow(); err != nil {
+				// The rejected requests are counted as failures, for the drop ratio to
+				// keep up with the load.
+				breaker.MarkFailed()
+				if o.fallback != nil {
+					return o.fallback(ctx, operation, req)
+				}
+				return nil, ErrNotAllowed
+			}
+			reply, err := handler(ctx, req)
+			var header transport.Header
+			if ok {
+				header = tr.ReplyHeader()
+			}
+			if failed(o.conditions, header, err) {
+				breaker.MarkFailed()
+			} else {
+				breaker.MarkSuccess()
+			}
+			return reply, err
+		}
+	}
+}
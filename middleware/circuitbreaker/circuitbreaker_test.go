@@ -0,0 +1,210 @@
+package circuitbreaker
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/protobuf/proto"
+
+	circuitbreakerv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/circuitbreaker/v1"
+)
+
+const healthCheck = "/grpc.health.v1.Health/Check"
+
+// testBreaker is a Breaker opened by hand, recording the marks.
+type testBreaker struct {
+	open            bool
+	success, failed int
+}
+
+func (b *testBreaker) Allow() error {
+	if b.open {
+		return ErrNotAllowed
+	}
+	return nil
+}
+func (b *testBreaker) MarkSuccess() { b.success++ }
+func (b *testBreaker) MarkFailed()  { b.failed++ }
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	operation string
+	reply     headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return headerCarrier{} }
+func (tr *testTransport) ReplyHeader() transport.Header   { return tr.reply }
+
+func serverContext(operation string) (context.Context, *testTransport) {
+	tr := &testTransport{operation: operation, reply: headerCarrier{}}
+	return transport.NewServerContext(context.Background(), tr), tr
+}
+
+func TestServer(t *testing.T) {
+	group := NewGroup(func() Breaker { return &testBreaker{} })
+	var fail error
+	handler := Server(WithGroup(group))(func(ctx context.Context, req any) (any, error) {
+		return "ok", fail
+	})
+	call := func(operation string) (any, error) {
+		ctx, _ := serverContext(operation)
+		return handler(ctx, nil)
+	}
+
+	_, _ = call("/a")
+	fail = errors.BadRequest("BAD", "")
+	_, _ = call("/a")
+	fail = errors.ServiceUnavailable("DOWN", "")
+	_, _ = call("/a")
+	_, _ = call("/b")
+	a, b := group.Get("/a").(*testBreaker), group.Get("/b").(*testBreaker)
+	assert.Equal(t, 2, a.success, "client errors are not failures by default")
+	assert.Equal(t, 1, a.failed)
+	assert.Equal(t, 1, b.failed, "operations have their own breaker")
+
+	a.open = true
+	reply, err := call("/a")
+	assert.Nil(t, reply)
+	assert.True(t, errors.Is(err, ErrNotAllowed))
+	assert.Equal(t, 2, a.failed, "rejected requests count as failures")
+}
+
+func TestConditions(t *testing.T) {
+	conditions, err := NewConditions([]*circuitbreakerv1.Condition{
+		{ByStatusCode: proto.String("429, 500-599")},
+		{ByHeader: &circuitbreakerv1.Condition_Header{Name: "x-overloaded", Value: "1"}},
+		{ByHeader: &circuitbreakerv1.Condition_Header{Name: HeaderGRPCStatus, Value: "14"}},
+	})
+	require.NoError(t, err)
+	require.Len(t, conditions, 3)
+
+	for _, c := range []struct {
+		header headerCarrier
+		err    error
+		failed bool
+	}{
+		{headerCarrier{}, nil, false},
+		{headerCarrier{}, errors.New(429, "LIMIT", ""), true},
+		{headerCarrier{}, errors.New(502, "BAD_GATEWAY", ""), true},
+		{headerCarrier{}, errors.NotFound("NOT_FOUND", ""), false},
+		{headerCarrier{"X-Overloaded": {"1"}}, nil, true},
+		{nil, errors.BadRequest("BAD", "").WithMetadata(map[string]string{"x-overloaded": "1"}), true},
+		{nil, errors.FromError(errors.New(503, "", "")), true},
+	} {
+		assert.Equal(t, c.failed, failed(conditions, c.header, c.err), "%v %v", c.header, c.err)
+	}
+	assert.True(t, Header(HeaderGRPCStatus, "14")(nil, errors.ServiceUnavailable("DOWN", "")))
+
+	for _, pattern := range []string{"", "5xx", "599-500", "500-"} {
+		_, err := StatusCode(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
+
+func TestResponseFallback(t *testing.T) {
+	fallback := ResponseFallback(&circuitbreakerv1.ResponseData{
+		Header:     []*circuitbreakerv1.Header{{Key: "X-Fallback", Value: []string{"static"}}},
+		Body:       []byte(`{"status":"NOT_SERVING","unknown":1}`),
+		StatusCode: http.StatusOK,
+	})
+	ctx, tr := serverContext(healthCheck)
+	reply, err := fallback(ctx, healthCheck, nil)
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_NOT_SERVING, reply.(*grpc_health_v1.HealthCheckResponse).GetStatus())
+	assert.Equal(t, "static", tr.reply.Get("X-Fallback"))
+
+	_, err = fallback(ctx, "/unknown.Service/Method", nil)
+	assert.Error(t, err, "replies of unregistered operations cannot be built")
+
+	fallback = ResponseFallback(&circuitbreakerv1.ResponseData{Body: []byte("busy")})
+	_, err = fallback(context.Background(), healthCheck, nil)
+	e := errors.FromError(err)
+	assert.EqualValues(t, http.StatusServiceUnavailable, e.Code)
+	assert.Equal(t, Reason, e.Reason)
+	assert.Equal(t, "busy", e.Message)
+}
+
+func TestBackupFallback(t *testing.T) {
+	var body string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = r.Method + " " + r.URL.Path + " " + string(data)
+		if r.URL.Query().Get("fail") != "" {
+			w.WriteHeader(http.StatusBadGateway)
+			_, _ = w.Write([]byte(`{"code":502,"reason":"BACKUP_DOWN","message":"down"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"status":"SERVING"}`))
+	}))
+	defer srv.Close()
+
+	fallback, err := BackupFallback(&circuitbreakerv1.Endpoint{Host: srv.URL, Path: "/health"}, srv.Client())
+	require.NoError(t, err)
+	reply, err := fallback(context.Background(), healthCheck, &grpc_health_v1.HealthCheckRequest{Service: "svc"})
+	require.NoError(t, err)
+	assert.Equal(t, grpc_health_v1.HealthCheckResponse_SERVING, reply.(*grpc_health_v1.HealthCheckResponse).GetStatus())
+	assert.Equal(t, `POST /health {"service":"svc"}`, body)
+
+	fallback, err = BackupFallback(&circuitbreakerv1.Endpoint{Host: srv.URL, Path: "/health?fail=1", Method: "get"}, nil)
+	require.NoError(t, err)
+	_, err = fallback(context.Background(), healthCheck, &grpc_health_v1.HealthCheckRequest{})
+	assert.Equal(t, "BACKUP_DOWN", errors.Reason(err))
+	assert.Equal(t, "GET /health ", body)
+
+	_, err = BackupFallback(&circuitbreakerv1.Endpoint{Path: "/health"}, nil)
+	assert.Error(t, err)
+}
+
+func TestFromConfig(t *testing.T) {
+	opts, err := FromConfig(&circuitbreakerv1.CircuitBreaker{
+		Ratio:        proto.Int64(80),
+		ResponseData: &circuitbreakerv1.ResponseData{StatusCode: http.StatusTooManyRequests},
+	})
+	require.NoError(t, err)
+	group := NewGroup(func() Breaker { return &testBreaker{open: true} })
+	handler := Client(append(opts, WithGroup(group))...)(func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	_, err = handler(context.Background(), nil)
+	assert.EqualValues(t, http.StatusTooManyRequests, errors.FromError(err).Code, "rejected requests fall back")
+
+	for _, cfg := range []*circuitbreakerv1.CircuitBreaker{
+		{Ratio: proto.Int64(80), SuccessRatio: &circuitbreakerv1.SuccessRatio{}},
+		{Ratio: proto.Int64(0)},
+		{SuccessRatio: &circuitbreakerv1.SuccessRatio{Success: 1.5}},
+		{ResponseData: &circuitbreakerv1.ResponseData{}, BackupService: &circuitbreakerv1.BackupService{}},
+		{AssertCondtions: []*circuitbreakerv1.Condition{{ByStatusCode: proto.String("x")}}},
+	} {
+		_, err := FromConfig(cfg)
+		assert.Error(t, err, "%v", cfg)
+	}
+}
+
+func TestFromConfig_Nil(t *testing.T) {
+	// A middleware without configuration uses the default breakers, without fallback.
+	opts, err := FromConfig(nil)
+	require.NoError(t, err)
+	reply, err := Server(opts...)(func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+}
@@ -0,0 +1,98 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package circuitbreaker
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+
+	circuitbreakerv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/circuitbreaker/v1"
+	"github.com/origadmin/runtime/middleware/matcher"
+)
+
+// HeaderGRPCStatus is the header of the gRPC status code of a reply.
+const HeaderGRPCStatus = "grpc-status"
+
+// Condition reports whether a request failed, from the header and the error of its
+// reply. The header is nil outside of a transport.
+type Condition func(header transport.Header, err error) bool
+
+// DefaultCondition counts the server errors, those with a 5xx code, as failures.
+func DefaultCondition(_ transport.Header, err error) bool {
+	return err != nil && errors.FromError(err).Code >= http.StatusInternalServerError
+}
+
+// NewConditions returns the conditions of the configuration of a breaker.
+func NewConditions(cfgs []*circuitbreakerv1.Condition) ([]Condition, error) {
+	var conditions []Condition
+	for _, cfg := range cfgs {
+		if cfg.ByStatusCode != nil {
+			condition, err := StatusCode(cfg.GetByStatusCode())
+			if err != nil {
+				return nil, err
+			}
+			conditions = append(conditions, condition)
+		}
+		if h := cfg.GetByHeader(); h != nil {
+			if h.GetName() == "" {
+				return nil, fmt.Errorf("circuitbreaker: header condition without name")
+			}
+			conditions = append(conditions, Header(h.GetName(), h.GetValue()))
+		}
+	}
+	return conditions, nil
+}
+
+// StatusCode returns a Condition matching the status codes of pattern, a code such as
+// "429" or a range such as "500-599", or a comma-separated list of them. The code of a
+// reply is that of its error, 200 without error.
+func StatusCode(pattern string) (Condition, error) {
+	match, err := matcher.StatusCode(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("circuitbreaker: %w", err)
+	}
+	return func(_ transport.Header, err error) bool {
+		code := http.StatusOK
+		if err != nil {
+			code = int(errors.FromError(err).Code)
+		}
+		return match(code)
+	}, nil
+}
+
+// Header returns a Condition matching the value of a header of the reply, or of the
+// metadata of its error. The value of HeaderGRPCStatus defaults to the gRPC code of the
+// error, for the transports not exposing it as a header.
+func Header(name, value string) Condition {
+	return func(header transport.Header, err error) bool {
+		var v string
+		if header != nil {
+			v = header.Get(name)
+		}
+		if v == "" && err != nil {
+			e := errors.FromError(err)
+			v = e.Metadata[name]
+			if v == "" && strings.EqualFold(name, HeaderGRPCStatus) {
+				v = strconv.Itoa(int(e.GRPCStatus().Code()))
+			}
+		}
+		return v == value
+	}
+}
+
+// failed reports whether any of conditions matches a reply.
+func failed(conditions []Condition, header transport.Header, err error) bool {
+	for _, condition := range conditions {
+		if condition(header, err) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,154 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package circuitbreaker
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	circuitbreakerv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/circuitbreaker/v1"
+)
+
+// ResponseFallback returns a Fallback responding with data. Its headers are set on the
+// reply of the servers. Error status codes fail the request with an error of that code,
+// with the body as message and the headers as metadata. The body of the other codes is
+// decoded as JSON into the reply of the operation; the Kratos clients keep their own
+// reply, they only see the request succeed.
+func ResponseFallback(data *circuitbreakerv1.ResponseData) Fallback {
+	code := int(data.GetStatusCode())
+	if code == 0 {
+		code = http.StatusServiceUnavailable
+	}
+	header := make(http.Header)
+	for _, h := range data.GetHeader() {
+		for _, v := range h.GetValue() {
+			header.Add(h.GetKey(), v)
+		}
+	}
+	body := data.GetBody()
+	return func(ctx context.Context, operation string, _ any) (any, error) {
+		if tr, ok := transport.FromServerContext(ctx); ok {
+			for key, values := range header {
+				for _, v := range values {
+					tr.ReplyHeader().Add(key, v)
+				}
+			}
+		}
+		return decodeResponse(operation, code, header, body)
+	}
+}
+
+// BackupFallback returns a Fallback sending the requests to the HTTP endpoint of a
+// backup service with client, as JSON. The endpoint is POST at host and path by default,
+// host defaulting to the http scheme. Its response is handled as a static response.
+func BackupFallback(endpoint *circuitbreakerv1.Endpoint, client *http.Client) (Fallback, error) {
+	host := endpoint.GetHost()
+	if host == "" {
+		return nil, fmt.Errorf("circuitbreaker: backup service without host")
+	}
+	if !strings.Contains(host, "://") {
+		host = "http://" + host
+	}
+	url := strings.TrimSuffix(host, "/") + "/" + strings.TrimPrefix(endpoint.GetPath(), "/")
+	method := strings.ToUpper(endpoint.GetMethod())
+	if method == "" {
+		method = http.MethodPost
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context, operation string, req any) (any, error) {
+		var body io.Reader
+		if method != http.MethodGet && method != http.MethodHead && req != nil {
+			data, err := marshalRequest(req)
+			if err != nil {
+				return nil, ErrNotAllowed.WithCause(err)
+			}
+			body = bytes.NewReader(data)
+		}
+		r, err := http.NewRequestWithContext(ctx, method, url, body)
+		if err != nil {
+			return nil, ErrNotAllowed.WithCause(err)
+		}
+		r.Header.Set("Accept", "application/json")
+		if body != nil {
+			r.Header.Set("Content-Type", "application/json")
+		}
+		res, err := client.Do(r)
+		if err != nil {
+			return nil, ErrNotAllowed.WithCause(err)
+		}
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		if err != nil {
+			return nil, ErrNotAllowed.WithCause(err)
+		}
+		return decodeResponse(operation, res.StatusCode, res.Header, data)
+	}, nil
+}
+
+// decodeResponse returns the reply or the error of operation for a response.
+func decodeResponse(operation string, code int, header http.Header, body []byte) (any, error) {
+	if code < http.StatusOK || code >= http.StatusMultipleChoices {
+		e := new(errors.Error)
+		if err := json.Unmarshal(body, e); err == nil && e.Code != 0 {
+			return nil, e
+		}
+		md := make(map[string]string, len(header))
+		for key := range header {
+			md[key] = header.Get(key)
+		}
+		return nil, errors.New(code, Reason, string(body)).WithMetadata(md)
+	}
+	reply, err := newReply(operation)
+	if err != nil {
+		return nil, ErrNotAllowed.WithCause(err)
+	}
+	if len(body) > 0 {
+		if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(body, reply); err != nil {
+			return nil, ErrNotAllowed.WithCause(err)
+		}
+	}
+	return reply, nil
+}
+
+// newReply returns a new reply of operation, a method such as "/pkg.Service/Method"
+// registered by its generated code.
+func newReply(operation string) (proto.Message, error) {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(operation, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return nil, fmt.Errorf("circuitbreaker: unknown operation %q: %w", operation, err)
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return nil, fmt.Errorf("circuitbreaker: %q is not a method", operation)
+	}
+	typ, err := protoregistry.GlobalTypes.FindMessageByName(method.Output().FullName())
+	if err != nil {
+		return nil, err
+	}
+	return typ.New().Interface(), nil
+}
+
+// marshalRequest encodes a request as JSON.
+func marshalRequest(req any) ([]byte, error) {
+	if m, ok := req.(proto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	return json.Marshal(req)
+}
@@ -0,0 +1,34 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package middleware implements the functions, types, and contracts for the module.
+package middleware
+
+import (
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/middleware/security"
+)
+
+type declarativeSecurityFactory struct {
+}
+
+// NewMiddlewareClient returns no middleware, the policies are enforced by the servers.
+func (d declarativeSecurityFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	mwOpts.GetLogger("middleware.declarative_security").Debug("declarative security has no client middleware")
+	return nil, false
+}
+
+// NewMiddlewareServer creates a new server-side declarative security middleware.
+func (d declarativeSecurityFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.declarative_security")
+	logger.Debug("enabling declarative security server middleware")
+
+	securityOpts := security.FromConfig(cfg.GetSecurity())
+	if mwOpts.PolicyEvaluator != nil {
+		securityOpts = append(securityOpts, security.WithEvaluator(mwOpts.PolicyEvaluator))
+	}
+	return security.Server(securityOpts...), true
+}
@@ -21,8 +21,12 @@ func init() {
 	RegisterFactory(CircuitBreaker, &circuitBreakerFactory{})
 	RegisterFactory(Logging, &loggingFactory{})
 	RegisterFactory(RateLimiter, &rateLimitFactory{})
+	RegisterFactory(Retry, &retryFactory{})
+	RegisterFactory(Timeout, &timeoutFactory{})
 	RegisterFactory(Metadata, &metadataFactory{})
+	RegisterFactory(Metrics, &metricsFactory{})
 	RegisterFactory(Selector, &selectorFactory{})
+	RegisterFactory(DeclarativeSecurity, &declarativeSecurityFactory{})
 	RegisterFactory(Tracing, &tracingFactory{})
 	RegisterFactory(Validator, &validatorFactory{})
 }
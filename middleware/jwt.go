@@ -6,6 +6,7 @@
 package middleware
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/x509"
 	"encoding/pem"
@@ -19,6 +20,7 @@ import (
 	jwtv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/jwt/v1"
 	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
 	"github.com/origadmin/runtime/log"
+	"github.com/origadmin/runtime/middleware/jwtauth"
 )
 
 type jwtFactory struct{}
@@ -48,18 +50,16 @@ func (f jwtFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Op
 }
 
 // JwtServer creates a Kratos server middleware for JWT authentication.
-// It uses the provided JWT configuration to validate incoming tokens.
+// It uses the provided JWT configuration to validate incoming tokens: their signature with
+// the signing key, the secondary signing key and the keys of the JWKS URL, selected by the
+// kid of the token, and their issuer and audience when configured, tolerating the
+// configured clock skew.
 func JwtServer(cfg *jwtv1.JWT, opts *Options) (KMiddleware, bool) {
 	config := cfg.GetConfig()
 	if config == nil {
 		return nil, false
 	}
 
-	var options []authjwt.Option
-	if cfg.GetTokenHeader() != nil {
-		// TokenHeader sets additional header fields for the JWT token itself (e.g., kid, typ)
-		options = append(options, authjwt.WithTokenHeader(cfg.GetTokenHeader().AsMap()))
-	}
 	// Use the provided SigningMethod from options if available, otherwise get from config
 	var signMethod jwt.SigningMethod
 	if opts != nil && opts.SigningMethod != nil {
@@ -67,13 +67,26 @@ func JwtServer(cfg *jwtv1.JWT, opts *Options) (KMiddleware, bool) {
 	} else {
 		signMethod = getSigningMethod(config.SigningMethod)
 	}
-	options = append(options, authjwt.WithSigningMethod(signMethod))
 	// Check for insecure signing method
 	if signMethod == jwt.SigningMethodNone {
 		log.Warn("Using insecure signing method 'none'. This should only be used for testing.")
 	}
 
-	kf := getKeyFunc(config.SigningKey, signMethod.Alg())
+	keySetOpts := []Option{jwtauth.WithKeys(jwtKeys(config, signMethod.Alg())...)}
+	methods := []string{signMethod.Alg()}
+	if url := config.GetJwksUrl(); url != "" {
+		keySetOpts = append(keySetOpts, jwtauth.WithJWKS(url))
+		if interval := config.GetJwksRefreshInterval(); interval > 0 {
+			keySetOpts = append(keySetOpts, jwtauth.WithRefreshInterval(time.Duration(interval)*time.Second))
+		}
+		if signMethod == jwt.SigningMethodNone {
+			// The signing method of the tokens is given by the keys of the JWKS
+			methods = jwksSigningMethods
+		}
+	}
+	// The key set fetches the JWKS when the first token is verified and holds no goroutine,
+	// it is released along with the middleware
+	keySet := jwtauth.NewKeySet(context.Background(), keySetOpts...)
 
 	// For server middleware, we need to validate tokens, not generate them
 	// Use a claims factory that creates appropriate claims for parsing
@@ -89,9 +102,56 @@ func JwtServer(cfg *jwtv1.JWT, opts *Options) (KMiddleware, bool) {
 		}
 		claimsFactory = getClaimsFactory(claimType)
 	}
-	options = append(options, authjwt.WithClaims(claimsFactory))
 
-	return authjwt.Server(kf, options...), true
+	return jwtauth.Server(keySet.Keyfunc,
+		jwtauth.WithClaims(claimsFactory),
+		jwtauth.WithSigningMethods(methods...),
+		jwtauth.WithIssuer(config.GetIssuer()),
+		jwtauth.WithAudience(config.GetAudience()...),
+		jwtauth.WithClockSkew(time.Duration(config.GetClockSkew())*time.Second),
+	), true
+}
+
+// jwksSigningMethods are the signing methods of the tokens verified with the keys of a JWKS
+// when the configuration has no signing method.
+var jwksSigningMethods = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
+}
+
+// jwtKeys returns the signing key and the secondary signing key of config verifying the
+// tokens of the signing method alg. The keys failing to parse are logged and skipped.
+func jwtKeys(config *jwtv1.AuthConfig, alg string) []jwtauth.Key {
+	var keys []jwtauth.Key
+	for _, k := range []struct{ id, data string }{
+		{config.GetKeyId(), config.GetSigningKey()},
+		{config.GetSecondaryKeyId(), config.GetSecondarySigningKey()},
+	} {
+		if k.data == "" {
+			continue
+		}
+		var key any
+		var err error
+		switch alg {
+		case "HS256", "HS384", "HS512":
+			// HMAC methods use the same key for signing and verification
+			key = []byte(k.data)
+		case "RS256", "RS384", "RS512":
+			key, err = parseRSAPublicKey(k.data)
+		case "ES256", "ES384", "ES512":
+			key, err = parseECDSAPublicKey(k.data)
+		default:
+			continue
+		}
+		if err != nil {
+			log.Errorf("Failed to parse the JWT key %q: %v", k.id, err)
+			continue
+		}
+		keys = append(keys, jwtauth.Key{ID: k.id, Algorithm: alg, Key: key})
+	}
+	return keys
 }
 
 // JwtClient creates a Kratos client middleware for JWT token generation and injection.
@@ -104,9 +164,14 @@ func JwtClient(cfg *jwtv1.JWT, opts *Options) (KMiddleware, bool) {
 	}
 
 	var options []authjwt.Option
-	if cfg.GetTokenHeader() != nil {
-		// TokenHeader sets additional header fields for the JWT token itself (e.g., kid, typ)
-		options = append(options, authjwt.WithTokenHeader(cfg.GetTokenHeader().AsMap()))
+	// TokenHeader sets additional header fields for the JWT token itself (e.g., kid, typ)
+	tokenHeader := cfg.GetTokenHeader().AsMap()
+	if _, ok := tokenHeader["kid"]; !ok && config.GetKeyId() != "" {
+		// Identify the signing key for the servers verifying with several keys
+		tokenHeader["kid"] = config.GetKeyId()
+	}
+	if len(tokenHeader) > 0 {
+		options = append(options, authjwt.WithTokenHeader(tokenHeader))
 	}
 	// Use the provided SigningMethod from options if available, otherwise get from config
 	var signMethod jwt.SigningMethod
@@ -0,0 +1,303 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package jwtauth implements the verification of the JWT tokens of the jwt middleware: a
+// key set of the configured keys and of a JWKS URL, selected by the kid of the tokens, and
+// the validation of their issuer, audience and times with a tolerated clock skew.
+package jwtauth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultRefreshInterval is the age of a JWKS after which it is refreshed.
+const DefaultRefreshInterval = 5 * time.Minute
+
+// DefaultMinRefreshInterval is the minimum interval between the refreshes of a JWKS
+// caused by the tokens of unknown keys.
+const DefaultMinRefreshInterval = 10 * time.Second
+
+// DefaultFetchTimeout is the timeout of the fetches of a JWKS.
+const DefaultFetchTimeout = 10 * time.Second
+
+// ErrKeyNotFound is returned when no key of the set verifies a token.
+var ErrKeyNotFound = errors.New("jwtauth: no key found for the token")
+
+// Key is a key verifying tokens: a []byte secret for HMAC, or an RSA, ECDSA or Ed25519
+// public key.
+type Key struct {
+	// ID is the kid of the tokens the key verifies, those of any kid when empty.
+	ID string
+	// Algorithm restricts the key to the tokens of this signing method when not empty.
+	Algorithm string
+	Key       any
+}
+
+type keySetOptions struct {
+	keys               []Key
+	url                string
+	client             *http.Client
+	refreshInterval    time.Duration
+	minRefreshInterval time.Duration
+}
+
+// WithKeys adds keys to the set, such as a primary key and the secondary key of the
+// tokens signed before its rotation.
+func WithKeys(keys ...Key) options.Option {
+	return optionutil.Update(func(o *keySetOptions) {
+		o.keys = append(o.keys, keys...)
+	})
+}
+
+// WithJWKS sets the URL of a JWKS whose keys are added to the set.
+func WithJWKS(url string) options.Option {
+	return optionutil.Update(func(o *keySetOptions) {
+		o.url = url
+	})
+}
+
+// WithHTTPClient sets the HTTP client fetching the JWKS, a client with a timeout of
+// DefaultFetchTimeout by default.
+func WithHTTPClient(client *http.Client) options.Option {
+	return optionutil.Update(func(o *keySetOptions) {
+		o.client = client
+	})
+}
+
+// WithRefreshInterval sets the age of the JWKS after which it is refreshed in the
+// background when a token is verified, DefaultRefreshInterval by default.
+func WithRefreshInterval(interval time.Duration) options.Option {
+	return optionutil.Update(func(o *keySetOptions) {
+		o.refreshInterval = interval
+	})
+}
+
+// WithMinRefreshInterval sets the minimum interval between the refreshes of the JWKS
+// caused by the tokens of unknown keys, DefaultMinRefreshInterval by default.
+func WithMinRefreshInterval(interval time.Duration) options.Option {
+	return optionutil.Update(func(o *keySetOptions) {
+		o.minRefreshInterval = interval
+	})
+}
+
+// KeySet is the set of the keys verifying the tokens: the configured keys and those of a
+// JWKS. The JWKS is fetched when the first token is verified, refreshed in the background
+// when it gets older than the refresh interval, and when a token has an unknown kid. The
+// set holds no goroutine between the refreshes. It is safe for concurrent use.
+type KeySet struct {
+	ctx  context.Context
+	keys []Key
+	opts *keySetOptions
+
+	// refreshMu serializes the fetches of the JWKS.
+	refreshMu   sync.Mutex
+	refreshing  atomic.Bool
+	mu          sync.RWMutex
+	remote      []Key
+	etag        string
+	lastAttempt time.Time
+	lastErr     error
+}
+
+// NewKeySet creates a KeySet. Its JWKS is fetched with ctx, no more once ctx is done.
+func NewKeySet(ctx context.Context, opts ...options.Option) *KeySet {
+	o := optionutil.NewT[keySetOptions](opts...)
+	if o.client == nil {
+		o.client = &http.Client{Timeout: DefaultFetchTimeout}
+	}
+	if o.refreshInterval <= 0 {
+		o.refreshInterval = DefaultRefreshInterval
+	}
+	if o.minRefreshInterval <= 0 {
+		o.minRefreshInterval = DefaultMinRefreshInterval
+	}
+	return &KeySet{ctx: ctx, keys: o.keys, opts: o}
+}
+
+// Refresh fetches the JWKS again, keeping the current keys on failure or when it has
+// not changed.
+func (s *KeySet) Refresh(ctx context.Context) error {
+	if s.opts.url == "" {
+		return nil
+	}
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	return s.refresh(ctx)
+}
+
+// refreshIfOlder refreshes the JWKS when its last fetch is older than age. The callers
+// waiting for a fetch in progress do not fetch it again once it is done.
+func (s *KeySet) refreshIfOlder(age time.Duration) {
+	s.refreshMu.Lock()
+	defer s.refreshMu.Unlock()
+	if s.olderThan(age) {
+		_ = s.refresh(s.ctx)
+	}
+}
+
+// fetched reports whether the JWKS has been fetched once, successfully or not.
+func (s *KeySet) fetched() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return !s.lastAttempt.IsZero()
+}
+
+// refresh fetches the JWKS and records the attempt. s.refreshMu must be held.
+func (s *KeySet) refresh(ctx context.Context) error {
+	err := s.fetch(ctx)
+	s.mu.Lock()
+	s.lastAttempt, s.lastErr = time.Now(), err
+	s.mu.Unlock()
+	return err
+}
+
+// olderThan reports whether the last fetch of the JWKS is older than age, true before
+// the first one.
+func (s *KeySet) olderThan(age time.Duration) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastAttempt.IsZero() || time.Since(s.lastAttempt) >= age
+}
+
+// fetch fetches the JWKS, conditionally on its entity tag.
+func (s *KeySet) fetch(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.opts.url, nil)
+	if err != nil {
+		return fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	s.mu.RLock()
+	if s.etag != "" {
+		req.Header.Set("If-None-Match", s.etag)
+	}
+	s.mu.RUnlock()
+	resp, err := s.opts.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotModified:
+		return nil
+	default:
+		return fmt.Errorf("jwtauth: fetch JWKS: %s", resp.Status)
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("jwtauth: fetch JWKS: %w", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	s.remote, s.etag = keys, resp.Header.Get("ETag")
+	s.mu.Unlock()
+	return nil
+}
+
+// Keys returns the keys of the set, the configured ones first.
+func (s *KeySet) Keys() []Key {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append(append([]Key(nil), s.keys...), s.remote...)
+}
+
+// Keyfunc is a jwt.Keyfunc returning the keys of s verifying token, compatible with its
+// signing method: those of its kid, or the keys without ID when none has it, all of them
+// for a token without kid. The first token, and a token of an unknown kid, wait for the
+// JWKS to be fetched, at most once per minimum refresh interval.
+func (s *KeySet) Keyfunc(token *jwt.Token) (any, error) {
+	kid, _ := token.Header["kid"].(string)
+	alg := token.Method.Alg()
+	keys, found := s.candidates(kid, alg)
+	if s.opts.url != "" {
+		switch {
+		case !found && (kid != "" || !s.fetched()) && s.olderThan(s.opts.minRefreshInterval):
+			s.refreshIfOlder(s.opts.minRefreshInterval)
+			keys, _ = s.candidates(kid, alg)
+		case s.olderThan(s.opts.refreshInterval) && s.refreshing.CompareAndSwap(false, true):
+			go func() {
+				defer s.refreshing.Store(false)
+				s.refreshIfOlder(s.opts.refreshInterval)
+			}()
+		}
+	}
+	switch len(keys) {
+	case 0:
+		s.mu.RLock()
+		err := s.lastErr
+		s.mu.RUnlock()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrKeyNotFound, err)
+		}
+		return nil, ErrKeyNotFound
+	case 1:
+		return keys[0], nil
+	}
+	return jwt.VerificationKeySet{Keys: keys}, nil
+}
+
+// candidates returns the keys of kid compatible with alg, and whether a key has this ID.
+func (s *KeySet) candidates(kid, alg string) ([]jwt.VerificationKey, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var exact, unnamed []jwt.VerificationKey
+	for _, group := range [][]Key{s.keys, s.remote} {
+		for _, k := range group {
+			if !compatible(k, alg) {
+				continue
+			}
+			switch {
+			case kid == "" || k.ID == "":
+				unnamed = append(unnamed, k.Key)
+			case k.ID == kid:
+				exact = append(exact, k.Key)
+			}
+		}
+	}
+	if len(exact) > 0 {
+		return exact, true
+	}
+	return unnamed, false
+}
+
+// compatible reports whether k may verify a token of the signing method alg.
+func compatible(k Key, alg string) bool {
+	if k.Algorithm != "" && k.Algorithm != alg {
+		return false
+	}
+	switch {
+	case strings.HasPrefix(alg, "HS"):
+		_, ok := k.Key.([]byte)
+		return ok
+	case strings.HasPrefix(alg, "RS"), strings.HasPrefix(alg, "PS"):
+		_, ok := k.Key.(*rsa.PublicKey)
+		return ok
+	case strings.HasPrefix(alg, "ES"):
+		_, ok := k.Key.(*ecdsa.PublicKey)
+		return ok
+	case alg == "EdDSA":
+		_, ok := k.Key.(ed25519.PublicKey)
+		return ok
+	}
+	return false
+}
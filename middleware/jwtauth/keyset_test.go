@@ -0,0 +1,318 @@
+package jwtauth
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signer is a private key signing tokens, published in the JWKS as kid.
+type signer struct {
+	kid    string
+	method jwt.SigningMethod
+	key    crypto.Signer
+}
+
+func newRSASigner(t *testing.T, kid string) *signer {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return &signer{kid: kid, method: jwt.SigningMethodRS256, key: key}
+}
+
+func newECSigner(t *testing.T, kid string) *signer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return &signer{kid: kid, method: jwt.SigningMethodES256, key: key}
+}
+
+func newEdSigner(t *testing.T, kid string) *signer {
+	t.Helper()
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+	require.NoError(t, err)
+	return &signer{kid: kid, method: jwt.SigningMethodEdDSA, key: key}
+}
+
+// sign returns a token of claims signed by s, with its kid unless it is empty.
+func (s *signer) sign(t *testing.T, claims jwt.Claims) string {
+	t.Helper()
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.kid != "" {
+		token.Header["kid"] = s.kid
+	}
+	signed, err := token.SignedString(s.key)
+	require.NoError(t, err)
+	return signed
+}
+
+// jwk returns the public JWK of s.
+func (s *signer) jwk() map[string]string {
+	enc := base64.RawURLEncoding.EncodeToString
+	k := map[string]string{"kid": s.kid, "use": "sig", "alg": s.method.Alg()}
+	switch pub := s.key.Public().(type) {
+	case *rsa.PublicKey:
+		k["kty"], k["n"], k["e"] = "RSA", enc(pub.N.Bytes()), enc(big.NewInt(int64(pub.E)).Bytes())
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		k["kty"], k["crv"] = "EC", pub.Curve.Params().Name
+		k["x"], k["y"] = enc(pub.X.FillBytes(make([]byte, size))), enc(pub.Y.FillBytes(make([]byte, size)))
+	case ed25519.PublicKey:
+		k["kty"], k["crv"], k["x"] = "OKP", "Ed25519", enc(pub)
+	}
+	return k
+}
+
+// jwksServer is a JWKS endpoint publishing the keys of its signers.
+type jwksServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	keys     []map[string]string
+	version  int
+	requests int
+	notMod   int
+	delay    time.Duration
+}
+
+func newJWKSServer(t *testing.T, signers ...*signer) *jwksServer {
+	t.Helper()
+	s := &jwksServer{}
+	s.publish(signers...)
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// publish replaces the keys of the JWKS by those of signers.
+func (s *jwksServer) publish(signers ...*signer) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys = s.keys[:0]
+	for _, sg := range signers {
+		s.keys = append(s.keys, sg.jwk())
+	}
+	s.version++
+}
+
+func (s *jwksServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	time.Sleep(s.delay)
+	s.requests++
+	etag := fmt.Sprintf("%q", fmt.Sprint(s.version))
+	if r.Header.Get("If-None-Match") == etag {
+		s.notMod++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": s.keys})
+}
+
+func (s *jwksServer) stats() (requests, notModified int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests, s.notMod
+}
+
+func validClaims() *jwt.RegisteredClaims {
+	return &jwt.RegisteredClaims{Subject: "alice", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))}
+}
+
+// verify parses token with the keys of s.
+func verify(s *KeySet, token string) error {
+	_, err := jwt.ParseWithClaims(token, &jwt.RegisteredClaims{}, s.Keyfunc)
+	return err
+}
+
+func TestKeySet_JWKS(t *testing.T) {
+	rsaSigner, ecSigner, edSigner := newRSASigner(t, "rsa-1"), newECSigner(t, "ec-1"), newEdSigner(t, "ed-1")
+	srv := newJWKSServer(t, rsaSigner, ecSigner, edSigner)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// The JWKS is fetched when the first token is verified.
+	s := NewKeySet(ctx, WithJWKS(srv.URL))
+	assert.Empty(t, s.Keys())
+	for _, sg := range []*signer{rsaSigner, ecSigner, edSigner} {
+		assert.NoError(t, verify(s, sg.sign(t, validClaims())), sg.kid)
+	}
+	require.Len(t, s.Keys(), 3)
+	requests, _ := srv.stats()
+	assert.Equal(t, 1, requests)
+
+	// A key of the JWKS verifies the tokens of its kid only.
+	other := newRSASigner(t, "rsa-2")
+	forged := &signer{kid: "rsa-1", method: other.method, key: other.key}
+	assert.ErrorIs(t, verify(s, forged.sign(t, validClaims())), jwt.ErrTokenSignatureInvalid)
+}
+
+func TestKeySet_StaticKeys(t *testing.T) {
+	primary, secondary := []byte("primary-secret"), []byte("secondary-secret")
+	hs := func(key []byte, kid string) string {
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, validClaims())
+		if kid != "" {
+			token.Header["kid"] = kid
+		}
+		signed, err := token.SignedString(key)
+		require.NoError(t, err)
+		return signed
+	}
+
+	t.Run("Rotation", func(t *testing.T) {
+		s := NewKeySet(context.Background(), WithKeys(
+			Key{Algorithm: "HS256", Key: primary},
+			Key{Algorithm: "HS256", Key: secondary},
+		))
+		assert.NoError(t, verify(s, hs(primary, "")))
+		assert.NoError(t, verify(s, hs(secondary, "")))
+		assert.Error(t, verify(s, hs([]byte("unknown-secret"), "")))
+	})
+
+	t.Run("KeyID", func(t *testing.T) {
+		s := NewKeySet(context.Background(), WithKeys(
+			Key{ID: "v2", Algorithm: "HS256", Key: primary},
+			Key{ID: "v1", Algorithm: "HS256", Key: secondary},
+		))
+		assert.NoError(t, verify(s, hs(primary, "v2")))
+		assert.NoError(t, verify(s, hs(secondary, "v1")))
+		assert.NoError(t, verify(s, hs(secondary, "")))
+		assert.ErrorIs(t, verify(s, hs(secondary, "v2")), jwt.ErrTokenSignatureInvalid)
+		assert.ErrorIs(t, verify(s, hs(primary, "v3")), ErrKeyNotFound)
+	})
+
+	t.Run("Algorithm", func(t *testing.T) {
+		s := NewKeySet(context.Background(), WithKeys(Key{Algorithm: "HS256", Key: primary}))
+		token := jwt.NewWithClaims(jwt.SigningMethodHS512, validClaims())
+		signed, err := token.SignedString(primary)
+		require.NoError(t, err)
+		assert.ErrorIs(t, verify(s, signed), ErrKeyNotFound)
+	})
+}
+
+func TestKeySet_RefreshUnknownKey(t *testing.T) {
+	oldSigner, newSigner := newRSASigner(t, "old"), newRSASigner(t, "new")
+	srv := newJWKSServer(t, oldSigner)
+	s := NewKeySet(context.Background(), WithJWKS(srv.URL), WithMinRefreshInterval(time.Hour))
+	require.NoError(t, verify(s, oldSigner.sign(t, validClaims())))
+
+	// The token of a key published after the fetch refreshes the JWKS.
+	srv.publish(oldSigner, newSigner)
+	s.lastAttempt = time.Time{}
+	assert.NoError(t, verify(s, newSigner.sign(t, validClaims())))
+	requests, _ := srv.stats()
+	assert.Equal(t, 2, requests)
+
+	// The tokens of unknown keys refresh it once per minimum refresh interval.
+	unknown := newRSASigner(t, "unknown")
+	for i := 0; i < 3; i++ {
+		assert.ErrorIs(t, verify(s, unknown.sign(t, validClaims())), ErrKeyNotFound)
+	}
+	requests, _ = srv.stats()
+	assert.Equal(t, 2, requests)
+}
+
+func TestKeySet_BackgroundRefresh(t *testing.T) {
+	oldSigner, newSigner := newECSigner(t, "old"), newECSigner(t, "new")
+	srv := newJWKSServer(t, oldSigner)
+	s := NewKeySet(context.Background(), WithJWKS(srv.URL), WithRefreshInterval(10*time.Millisecond), WithMinRefreshInterval(time.Hour))
+	require.NoError(t, verify(s, oldSigner.sign(t, validClaims())))
+
+	// A JWKS older than the refresh interval is refreshed in the background when a token
+	// is verified, an unchanged one is not downloaded again.
+	require.Eventually(t, func() bool {
+		_ = verify(s, oldSigner.sign(t, validClaims()))
+		_, notModified := srv.stats()
+		return notModified > 0
+	}, time.Second, 5*time.Millisecond)
+	assert.Len(t, s.Keys(), 1)
+
+	// The rotated keys are fetched in the background.
+	srv.publish(newSigner)
+	newToken := newSigner.sign(t, validClaims())
+	require.Eventually(t, func() bool {
+		_ = verify(s, newToken)
+		keys := s.Keys()
+		return len(keys) == 1 && keys[0].ID == "new"
+	}, time.Second, 5*time.Millisecond)
+	assert.NoError(t, verify(s, newToken))
+}
+
+func TestKeySet_ConcurrentUnknownKeys(t *testing.T) {
+	srv := newJWKSServer(t, newRSASigner(t, "rsa-1"))
+	srv.delay = 20 * time.Millisecond
+	s := NewKeySet(context.Background(), WithJWKS(srv.URL), WithMinRefreshInterval(time.Hour))
+	unknown := newRSASigner(t, "unknown").sign(t, validClaims())
+
+	// The tokens of unknown keys verified together wait for a single fetch.
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.ErrorIs(t, verify(s, unknown), ErrKeyNotFound)
+		}()
+	}
+	wg.Wait()
+	requests, _ := srv.stats()
+	assert.Equal(t, 1, requests)
+}
+
+func TestKeySet_FetchFailure(t *testing.T) {
+	sg := newRSASigner(t, "rsa-1")
+	srv := newJWKSServer(t, sg)
+	s := NewKeySet(context.Background(), WithJWKS(srv.URL))
+	require.NoError(t, verify(s, sg.sign(t, validClaims())))
+	require.Len(t, s.Keys(), 1)
+
+	// The keys are kept when a refresh fails.
+	srv.Close()
+	assert.Error(t, s.Refresh(context.Background()))
+	assert.NoError(t, verify(s, sg.sign(t, validClaims())))
+
+	// The error of the fetch is reported with the tokens of unknown keys.
+	unknown := newRSASigner(t, "unknown")
+	s.lastAttempt = time.Time{}
+	err := verify(s, unknown.sign(t, validClaims()))
+	assert.ErrorIs(t, err, ErrKeyNotFound)
+	assert.ErrorContains(t, err, "fetch JWKS")
+}
+
+func TestParseJWKS(t *testing.T) {
+	keys, err := parseJWKS([]byte(`{"keys": [
+		{"kty": "oct", "kid": "hmac", "k": "c2VjcmV0"},
+		{"kty": "RSA", "kid": "enc", "use": "enc", "n": "AQAB", "e": "AQAB"},
+		{"kty": "OKP", "kid": "ed", "crv": "Ed25519", "x": "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"},
+		{"kty": "OKP", "kid": "x25519", "crv": "X25519", "x": "hSDwCYkwp1R0i33ctD73Wg2_Og0mOBr066SpjqqbTmo"},
+		{"kty": "EC", "kid": "k1", "crv": "secp256k1", "x": "AQ", "y": "AQ"}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, "ed", keys[0].ID)
+	assert.IsType(t, ed25519.PublicKey{}, keys[0].Key)
+
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "EC", "kid": "bad", "crv": "P-256", "x": "AQ", "y": "AQ"}]}`))
+	assert.ErrorContains(t, err, "not on curve")
+	_, err = parseJWKS([]byte(`{"keys": [{"kty": "RSA", "kid": "bad", "n": "AQAB"}]}`))
+	assert.ErrorContains(t, err, "exponent")
+	_, err = parseJWKS([]byte(`not json`))
+	assert.Error(t, err)
+}
@@ -0,0 +1,130 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package jwtauth
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// The errors of the tokens of unexpected claims, along with those of authjwt.
+var (
+	ErrInvalidIssuer   = kerrors.Unauthorized("UNAUTHORIZED", "JWT token has an invalid issuer")
+	ErrInvalidAudience = kerrors.Unauthorized("UNAUTHORIZED", "JWT token has an invalid audience")
+)
+
+type serverOptions struct {
+	claims   func() jwt.Claims
+	methods  []string
+	issuer   string
+	audience []string
+	leeway   time.Duration
+}
+
+// WithClaims sets the function returning the claims a token is parsed into, a new
+// jwt.RegisteredClaims by default. It must return a new object on each call.
+func WithClaims(claims func() jwt.Claims) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.claims = claims
+	})
+}
+
+// WithSigningMethods sets the signing methods of the accepted tokens, those of the keys
+// by default.
+func WithSigningMethods(methods ...string) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.methods = methods
+	})
+}
+
+// WithIssuer requires the tokens to be issued by issuer.
+func WithIssuer(issuer string) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.issuer = issuer
+	})
+}
+
+// WithAudience requires the tokens to be intended for one of audience.
+func WithAudience(audience ...string) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.audience = audience
+	})
+}
+
+// WithClockSkew sets the tolerated difference between the clocks of the issuer and the
+// server when validating the expiration, not before and issued at times.
+func WithClockSkew(skew time.Duration) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.leeway = skew
+	})
+}
+
+// Server returns a server middleware verifying the bearer token of the requests with
+// keyFunc and validating its claims, put in the context for authjwt.FromContext. The
+// rejected requests fail with the errors of authjwt, ErrInvalidIssuer or
+// ErrInvalidAudience.
+func Server(keyFunc jwt.Keyfunc, opts ...options.Option) kratosmiddleware.Middleware {
+	o := optionutil.NewT[serverOptions](opts...)
+	if o.claims == nil {
+		o.claims = func() jwt.Claims { return &jwt.RegisteredClaims{} }
+	}
+	parserOpts := []jwt.ParserOption{jwt.WithLeeway(o.leeway), jwt.WithIssuedAt()}
+	if len(o.methods) > 0 {
+		parserOpts = append(parserOpts, jwt.WithValidMethods(o.methods))
+	}
+	if o.issuer != "" {
+		parserOpts = append(parserOpts, jwt.WithIssuer(o.issuer))
+	}
+	if len(o.audience) > 0 {
+		parserOpts = append(parserOpts, jwt.WithAudience(o.audience...))
+	}
+	parser := jwt.NewParser(parserOpts...)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromServerContext(ctx)
+			if !ok {
+				return nil, authjwt.ErrWrongContext
+			}
+			scheme, token, ok := strings.Cut(tr.RequestHeader().Get("Authorization"), " ")
+			if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+				return nil, authjwt.ErrMissingJwtToken
+			}
+			claims := o.claims()
+			if _, err := parser.ParseWithClaims(token, claims, keyFunc); err != nil {
+				return nil, parseError(err)
+			}
+			return handler(authjwt.NewContext(ctx, claims), req)
+		}
+	}
+}
+
+// parseError returns the error of the request of a token failing to parse with err.
+func parseError(err error) error {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired), errors.Is(err, jwt.ErrTokenNotValidYet), errors.Is(err, jwt.ErrTokenUsedBeforeIssued):
+		return authjwt.ErrTokenExpired
+	case errors.Is(err, jwt.ErrTokenInvalidIssuer):
+		return ErrInvalidIssuer
+	case errors.Is(err, jwt.ErrTokenInvalidAudience):
+		return ErrInvalidAudience
+	case errors.Is(err, jwt.ErrTokenSignatureInvalid), errors.Is(err, jwt.ErrTokenUnverifiable),
+		errors.Is(err, jwt.ErrTokenRequiredClaimMissing):
+		return authjwt.ErrTokenInvalid.WithCause(err)
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return authjwt.ErrTokenInvalid
+	}
+	return authjwt.ErrTokenParseFail.WithCause(err)
+}
@@ -0,0 +1,156 @@
+package jwtauth
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	header headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "/test.v1.Users/Get" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.header }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+// assertRejected asserts that err is the error of the rejected requests want.
+func assertRejected(t *testing.T, want *kerrors.Error, err error) {
+	t.Helper()
+	require.Error(t, err)
+	e := kerrors.FromError(err)
+	assert.Equal(t, want.Code, e.Code)
+	assert.Equal(t, want.Message, e.Message)
+}
+
+func TestServer(t *testing.T) {
+	sg := newRSASigner(t, "rsa-1")
+	srv := newJWKSServer(t, sg)
+	keys := NewKeySet(context.Background(), WithJWKS(srv.URL))
+	now := time.Now()
+	claims := func(mutate func(c *jwt.RegisteredClaims)) *jwt.RegisteredClaims {
+		c := &jwt.RegisteredClaims{
+			Subject:   "alice",
+			Issuer:    "https://issuer.example.com",
+			Audience:  jwt.ClaimStrings{"api"},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+		}
+		if mutate != nil {
+			mutate(c)
+		}
+		return c
+	}
+	mw := Server(keys.Keyfunc,
+		WithSigningMethods("RS256"),
+		WithIssuer("https://issuer.example.com"),
+		WithAudience("api", "admin"),
+		WithClockSkew(time.Minute),
+	)
+	call := func(authorization string) (string, error) {
+		header := headerCarrier{}
+		if authorization != "" {
+			header.Set("Authorization", authorization)
+		}
+		ctx := transport.NewServerContext(context.Background(), &testTransport{header: header})
+		reply, err := mw(func(ctx context.Context, _ any) (any, error) {
+			c, ok := authjwt.FromContext(ctx)
+			require.True(t, ok)
+			return c.GetSubject()
+		})(ctx, nil)
+		if err != nil {
+			return "", err
+		}
+		return reply.(string), nil
+	}
+	bearer := func(c jwt.Claims) string { return "Bearer " + sg.sign(t, c) }
+
+	subject, err := call(bearer(claims(nil)))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", subject)
+
+	tests := []struct {
+		name string
+		auth string
+		want *kerrors.Error
+	}{
+		{"MissingToken", "", authjwt.ErrMissingJwtToken},
+		{"NotBearer", "Basic YWxpY2U6c2VjcmV0", authjwt.ErrMissingJwtToken},
+		{"Malformed", "Bearer not-a-token", authjwt.ErrTokenInvalid},
+		{"WrongIssuer", bearer(claims(func(c *jwt.RegisteredClaims) { c.Issuer = "https://evil.example.com" })), ErrInvalidIssuer},
+		{"WrongAudience", bearer(claims(func(c *jwt.RegisteredClaims) { c.Audience = jwt.ClaimStrings{"billing"} })), ErrInvalidAudience},
+		{"NoAudience", bearer(claims(func(c *jwt.RegisteredClaims) { c.Audience = nil })), authjwt.ErrTokenInvalid},
+		{"Expired", bearer(claims(func(c *jwt.RegisteredClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-2 * time.Minute))
+		})), authjwt.ErrTokenExpired},
+		{"NotYetValid", bearer(claims(func(c *jwt.RegisteredClaims) {
+			c.NotBefore = jwt.NewNumericDate(now.Add(2 * time.Minute))
+		})), authjwt.ErrTokenExpired},
+		{"UnknownKey", "Bearer " + newRSASigner(t, "rsa-2").sign(t, claims(nil)), authjwt.ErrTokenInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := call(tt.auth)
+			assertRejected(t, tt.want, err)
+		})
+	}
+
+	t.Run("ClockSkew", func(t *testing.T) {
+		_, err := call(bearer(claims(func(c *jwt.RegisteredClaims) {
+			c.ExpiresAt = jwt.NewNumericDate(now.Add(-30 * time.Second))
+			c.NotBefore = jwt.NewNumericDate(now.Add(30 * time.Second))
+			c.IssuedAt = jwt.NewNumericDate(now.Add(30 * time.Second))
+		})))
+		assert.NoError(t, err)
+	})
+
+	t.Run("SigningMethod", func(t *testing.T) {
+		ec := newECSigner(t, "ec-1")
+		srv.publish(sg, ec)
+		require.NoError(t, keys.Refresh(context.Background()))
+		_, err := call("Bearer " + ec.sign(t, claims(nil)))
+		assertRejected(t, authjwt.ErrTokenInvalid, err)
+	})
+
+	t.Run("WrongContext", func(t *testing.T) {
+		_, err := mw(func(context.Context, any) (any, error) { return nil, nil })(context.Background(), nil)
+		assert.ErrorIs(t, err, authjwt.ErrWrongContext)
+	})
+}
+
+func TestServer_Defaults(t *testing.T) {
+	key := []byte("secret")
+	keys := NewKeySet(context.Background(), WithKeys(Key{Key: key}))
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "bob", "iss": "anyone"})
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+
+	header := headerCarrier{}
+	header.Set("Authorization", "bearer "+signed)
+	ctx := transport.NewServerContext(context.Background(), &testTransport{header: header})
+	reply, err := Server(keys.Keyfunc, WithClaims(func() jwt.Claims { return jwt.MapClaims{} }))(
+		func(ctx context.Context, _ any) (any, error) {
+			claims, _ := authjwt.FromContext(ctx)
+			return claims, nil
+		})(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, jwt.MapClaims{"sub": "bob", "iss": "anyone"}, reply)
+}
@@ -0,0 +1,53 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package matcher matches the operations of the requests with the paths, prefixes and
+// regex of a selector configuration, as the selector middleware does, and the status
+// codes of their replies with code patterns.
+package matcher
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	selectorv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+)
+
+// Matcher reports whether an operation, such as "/pkg.Service/Method", is selected.
+type Matcher func(operation string) bool
+
+// New returns the Matcher of the operations equal to one of the paths of cfg, starting
+// with one of its prefixes or matching its regex. It matches no operation when cfg has
+// none of them.
+func New(cfg *selectorv1.Selector) (Matcher, error) {
+	paths := make(map[string]struct{}, len(cfg.GetPaths()))
+	for _, path := range cfg.GetPaths() {
+		paths[path] = struct{}{}
+	}
+	prefixes := cfg.GetPrefixes()
+	var re *regexp.Regexp
+	if expr := cfg.GetRegex(); expr != "" {
+		var err error
+		if re, err = regexp.Compile(expr); err != nil {
+			return nil, fmt.Errorf("matcher: invalid regex %q: %w", expr, err)
+		}
+	}
+	return func(operation string) bool {
+		if _, ok := paths[operation]; ok {
+			return true
+		}
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(operation, prefix) {
+				return true
+			}
+		}
+		return re != nil && re.MatchString(operation)
+	}, nil
+}
+
+// Empty reports whether cfg selects no operation.
+func Empty(cfg *selectorv1.Selector) bool {
+	return len(cfg.GetPaths()) == 0 && len(cfg.GetPrefixes()) == 0 && cfg.GetRegex() == ""
+}
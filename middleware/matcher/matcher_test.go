@@ -0,0 +1,47 @@
+package matcher
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	selectorv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+)
+
+func TestNew(t *testing.T) {
+	match, err := New(&selectorv1.Selector{
+		Paths:    []string{"/api.Users/Get"},
+		Prefixes: []string{"/api.Reports/"},
+		Regex:    "^/api\\.Jobs/(Get|List)$",
+	})
+	require.NoError(t, err)
+	assert.True(t, match("/api.Users/Get"))
+	assert.False(t, match("/api.Users/GetAll"))
+	assert.True(t, match("/api.Reports/Stream"))
+	assert.True(t, match("/api.Jobs/List"))
+	assert.False(t, match("/api.Jobs/Run"))
+
+	none, err := New(nil)
+	require.NoError(t, err)
+	assert.False(t, none("/api.Users/Get"))
+	assert.True(t, Empty(nil))
+	assert.True(t, Empty(&selectorv1.Selector{}))
+	assert.False(t, Empty(&selectorv1.Selector{Regex: "."}))
+
+	_, err = New(&selectorv1.Selector{Regex: "("})
+	assert.Error(t, err)
+}
+
+func TestStatusCode(t *testing.T) {
+	match, err := StatusCode("429, 500-503")
+	require.NoError(t, err)
+	for code, want := range map[int]bool{200: false, 429: true, 499: false, 500: true, 503: true, 504: false} {
+		assert.Equal(t, want, match(code), code)
+	}
+
+	for _, pattern := range []string{"", "x", "500-", "503-500", "429,"} {
+		_, err := StatusCode(pattern)
+		assert.Error(t, err, pattern)
+	}
+}
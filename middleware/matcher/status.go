@@ -0,0 +1,41 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package matcher
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// StatusCode returns a function reporting whether a status code matches pattern, a code
+// such as "429" or a range such as "500-599", or a comma-separated list of them.
+func StatusCode(pattern string) (func(code int) bool, error) {
+	type codeRange struct{ low, high int }
+	var ranges []codeRange
+	for _, part := range strings.Split(pattern, ",") {
+		part = strings.TrimSpace(part)
+		first, last, isRange := strings.Cut(part, "-")
+		low, err := strconv.Atoi(strings.TrimSpace(first))
+		if err != nil {
+			return nil, fmt.Errorf("matcher: invalid status code %q", pattern)
+		}
+		high := low
+		if isRange {
+			if high, err = strconv.Atoi(strings.TrimSpace(last)); err != nil || high < low {
+				return nil, fmt.Errorf("matcher: invalid status code %q", pattern)
+			}
+		}
+		ranges = append(ranges, codeRange{low, high})
+	}
+	return func(code int) bool {
+		for _, r := range ranges {
+			if code >= r.low && code <= r.high {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
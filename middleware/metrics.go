@@ -0,0 +1,61 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package middleware implements the functions, types, and contracts for the module.
+package middleware
+
+import (
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/middleware/metrics"
+)
+
+type metricsFactory struct {
+}
+
+// NewMiddlewareClient creates a new client-side metrics middleware.
+func (m metricsFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.metrics")
+	logger.Debug("enabling metrics client middleware")
+
+	metricsOpts, err := metricsOptions(cfg, mwOpts)
+	if err != nil {
+		logger.Errorf("failed to create metrics client middleware: %v", err)
+		return nil, false
+	}
+	return metrics.Client(metricsOpts...), true
+}
+
+// NewMiddlewareServer creates a new server-side metrics middleware.
+func (m metricsFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.metrics")
+	logger.Debug("enabling metrics server middleware")
+
+	metricsOpts, err := metricsOptions(cfg, mwOpts)
+	if err != nil {
+		logger.Errorf("failed to create metrics server middleware: %v", err)
+		return nil, false
+	}
+	return metrics.Server(metricsOpts...), true
+}
+
+// metricsOptions returns the options of the metrics middleware configured by cfg,
+// declaring its user metrics on the provider of mwOpts, or on the default one.
+func metricsOptions(cfg *middlewarev1.Middleware, mwOpts *Options) ([]Option, error) {
+	metricsOpts, err := metrics.FromConfig(cfg.GetMetrics())
+	if err != nil {
+		return nil, err
+	}
+	provider := mwOpts.Metrics
+	if provider == nil {
+		if provider, err = metrics.DefaultProvider(); err != nil {
+			return nil, err
+		}
+	}
+	if err := provider.Declare(cfg.GetMetrics().GetUserMetrics()...); err != nil {
+		return nil, err
+	}
+	return append(metricsOpts, metrics.WithMeterProvider(provider.MeterProvider())), nil
+}
@@ -0,0 +1,193 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package metrics implements the metrics middleware: the RED metrics of the operations,
+// their requests, errors and durations, recorded with an OpenTelemetry meter and
+// exported by a Prometheus registry, along with the user metrics of the configuration.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	metricsv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// ScopeName is the instrumentation scope of the metrics of the middleware.
+const ScopeName = "github.com/origadmin/runtime/middleware/metrics"
+
+// The metrics recorded by the middleware, as named in the supported_metrics of the
+// configuration.
+const (
+	// MetricRequests counts the requests: <side>_requests_total{kind, operation, code}.
+	MetricRequests = "requests"
+	// MetricErrors counts the failed requests:
+	// <side>_errors_total{kind, operation, code, reason}.
+	MetricErrors = "errors"
+	// MetricDuration is the histogram of the durations of the requests:
+	// <side>_request_duration_seconds{kind, operation}.
+	MetricDuration = "duration"
+)
+
+// DefaultBuckets are the bucket boundaries of the durations, in seconds.
+var DefaultBuckets = []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// The attributes of the metrics.
+const (
+	attrKind      = "kind"
+	attrOperation = "operation"
+	attrCode      = "code"
+	attrReason    = "reason"
+)
+
+type metricsOptions struct {
+	meterProvider metric.MeterProvider
+	metrics       []string
+	buckets       []float64
+}
+
+// WithMeterProvider sets the meter provider of the metrics, the global one by default.
+func WithMeterProvider(provider metric.MeterProvider) options.Option {
+	return optionutil.Update(func(o *metricsOptions) {
+		o.meterProvider = provider
+	})
+}
+
+// WithMetrics sets the metrics to record, among MetricRequests, MetricErrors and
+// MetricDuration, all of them by default.
+func WithMetrics(metrics ...string) options.Option {
+	return optionutil.Update(func(o *metricsOptions) {
+		o.metrics = metrics
+	})
+}
+
+// WithBuckets sets the bucket boundaries of the durations, in seconds, DefaultBuckets by
+// default.
+func WithBuckets(buckets ...float64) options.Option {
+	return optionutil.Update(func(o *metricsOptions) {
+		o.buckets = buckets
+	})
+}
+
+// FromConfig returns the options of the middleware configured by cfg. The user metrics
+// are declared on a Provider.
+func FromConfig(cfg *metricsv1.Metrics) ([]options.Option, error) {
+	for _, name := range cfg.GetSupportedMetrics() {
+		switch name {
+		case MetricRequests, MetricErrors, MetricDuration:
+		default:
+			return nil, fmt.Errorf("metrics: unknown metric %q", name)
+		}
+	}
+	return []options.Option{WithMetrics(cfg.GetSupportedMetrics()...)}, nil
+}
+
+// Server returns a server middleware recording the metrics of the requests.
+func Server(opts ...options.Option) kratosmiddleware.Middleware {
+	r := newRecorder("server", opts)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, _ := transport.FromServerContext(ctx)
+			return r.record(ctx, tr, handler, req)
+		}
+	}
+}
+
+// Client returns a client middleware recording the metrics of the calls.
+func Client(opts ...options.Option) kratosmiddleware.Middleware {
+	r := newRecorder("client", opts)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, _ := transport.FromClientContext(ctx)
+			return r.record(ctx, tr, handler, req)
+		}
+	}
+}
+
+// recorder holds the instruments of the enabled metrics of a side, nil for the others.
+type recorder struct {
+	requests metric.Int64Counter
+	errors   metric.Int64Counter
+	duration metric.Float64Histogram
+}
+
+// newRecorder creates the instruments of the metrics of side. The errors of the
+// instruments are reported to the OpenTelemetry error handler, their instruments
+// record nothing.
+func newRecorder(side string, opts []options.Option) *recorder {
+	o := optionutil.NewT[metricsOptions](opts...)
+	if o.meterProvider == nil {
+		o.meterProvider = otel.GetMeterProvider()
+	}
+	if len(o.metrics) == 0 {
+		o.metrics = []string{MetricRequests, MetricErrors, MetricDuration}
+	}
+	if len(o.buckets) == 0 {
+		o.buckets = DefaultBuckets
+	}
+	meter := o.meterProvider.Meter(ScopeName)
+	r := &recorder{}
+	var err error
+	for _, name := range o.metrics {
+		switch name {
+		case MetricRequests:
+			r.requests, err = meter.Int64Counter(side+".requests",
+				metric.WithDescription("The number of the "+side+" requests."),
+				metric.WithUnit("{request}"))
+		case MetricErrors:
+			r.errors, err = meter.Int64Counter(side+".errors",
+				metric.WithDescription("The number of the failed "+side+" requests, by reason."),
+				metric.WithUnit("{request}"))
+		case MetricDuration:
+			r.duration, err = meter.Float64Histogram(side+".request.duration",
+				metric.WithDescription("The duration of the "+side+" requests."),
+				metric.WithUnit("s"),
+				metric.WithExplicitBucketBoundaries(o.buckets...))
+		}
+		if err != nil {
+			otel.Handle(err)
+		}
+	}
+	return r
+}
+
+// record calls handler and records the metrics of its request.
+func (r *recorder) record(ctx context.Context, tr transport.Transporter, handler kratosmiddleware.Handler, req any) (any, error) {
+	var kind, operation string
+	if tr != nil {
+		kind, operation = tr.Kind().String(), tr.Operation()
+	}
+	start := time.Now()
+	reply, err := handler(ctx, req)
+	elapsed := time.Since(start)
+
+	code := 200
+	var reason string
+	if err != nil {
+		e := kerrors.FromError(err)
+		code, reason = int(e.Code), e.Reason
+	}
+	attrs := []attribute.KeyValue{attribute.String(attrKind, kind), attribute.String(attrOperation, operation)}
+	if r.duration != nil {
+		r.duration.Record(ctx, elapsed.Seconds(), metric.WithAttributes(attrs...))
+	}
+	attrs = append(attrs, attribute.Int(attrCode, code))
+	if r.requests != nil {
+		r.requests.Add(ctx, 1, metric.WithAttributes(attrs...))
+	}
+	if r.errors != nil && err != nil {
+		r.errors.Add(ctx, 1, metric.WithAttributes(append(attrs, attribute.String(attrReason, reason))...))
+	}
+	return reply, err
+}
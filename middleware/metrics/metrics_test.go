@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+
+	metricsv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	kind      transport.Kind
+	operation string
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return tr.kind }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return headerCarrier{} }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+func newProvider(t *testing.T) *Provider {
+	t.Helper()
+	provider, err := NewProvider(WithRegistry(prometheus.NewRegistry()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	return provider
+}
+
+// scrape returns the exposition of the metrics of provider.
+func scrape(t *testing.T, provider *Provider) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	provider.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, DefaultPath, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestServer(t *testing.T) {
+	provider := newProvider(t)
+	handler := Server(WithMeterProvider(provider.MeterProvider()))(func(_ context.Context, req any) (any, error) {
+		if req == "fail" {
+			return nil, kerrors.NotFound("USER_NOT_FOUND", "no such user")
+		}
+		return "ok", nil
+	})
+	ctx := transport.NewServerContext(context.Background(), &testTransport{kind: transport.KindHTTP, operation: "/api.Users/Get"})
+	for _, req := range []string{"a", "b", "fail"} {
+		_, _ = handler(ctx, req)
+	}
+
+	exposition := scrape(t, provider)
+	assert.Contains(t, exposition, `server_requests_total{code="200",kind="http",operation="/api.Users/Get",otel_scope_name="`+ScopeName+`"`)
+	assert.Regexp(t, `server_requests_total\{code="200",[^}]*\} 2`, exposition)
+	assert.Regexp(t, `server_requests_total\{code="404",[^}]*\} 1`, exposition)
+	assert.Regexp(t, `server_errors_total\{code="404",[^}]*reason="USER_NOT_FOUND"[^}]*\} 1`, exposition)
+	assert.NotContains(t, exposition, `server_errors_total{code="200"`)
+	assert.Regexp(t, `server_request_duration_seconds_count\{kind="http",operation="/api.Users/Get",[^}]*\} 3`, exposition)
+	assert.Contains(t, exposition, `le="0.005"`)
+}
+
+func TestClient(t *testing.T) {
+	provider := newProvider(t)
+	opts, err := FromConfig(&metricsv1.Metrics{SupportedMetrics: []string{MetricErrors}})
+	require.NoError(t, err)
+	handler := Client(append(opts, WithMeterProvider(provider.MeterProvider()))...)(func(context.Context, any) (any, error) {
+		return nil, kerrors.ServiceUnavailable("DOWN", "")
+	})
+	ctx := transport.NewClientContext(context.Background(), &testTransport{kind: transport.KindGRPC, operation: "/api.Users/Get"})
+	_, err = handler(ctx, nil)
+	require.Error(t, err)
+
+	exposition := scrape(t, provider)
+	assert.Regexp(t, `client_errors_total\{code="503",kind="grpc",operation="/api.Users/Get",[^}]*reason="DOWN"[^}]*\} 1`, exposition)
+	assert.NotContains(t, exposition, "client_requests_total", "only the enabled metrics are recorded")
+	assert.NotContains(t, exposition, "client_request_duration_seconds")
+
+	_, err = FromConfig(&metricsv1.Metrics{SupportedMetrics: []string{"latency"}})
+	assert.Error(t, err)
+}
+
+func TestUserMetrics(t *testing.T) {
+	provider := newProvider(t)
+	require.NoError(t, provider.Declare(
+		&metricsv1.UserMetric{
+			Name:        "build_info",
+			Type:        metricsv1.UserMetric_METRIC_TYPE_GAUGE,
+			Value:       1,
+			Labels:      map[string]string{"version": "v1.2.3"},
+			Description: "The build of the service.",
+		},
+		&metricsv1.UserMetric{Name: "orders", Type: metricsv1.UserMetric_METRIC_TYPE_COUNTER},
+		&metricsv1.UserMetric{Name: "order_amount", Type: metricsv1.UserMetric_METRIC_TYPE_SUMMARY, Unit: "USD"},
+	))
+	require.NoError(t, provider.Declare(&metricsv1.UserMetric{Name: "orders", Type: metricsv1.UserMetric_METRIC_TYPE_COUNTER}),
+		"a metric may be declared again")
+	assert.Error(t, provider.Declare(&metricsv1.UserMetric{Name: "orders", Type: metricsv1.UserMetric_METRIC_TYPE_GAUGE}))
+	assert.Error(t, provider.Declare(&metricsv1.UserMetric{Name: "untyped"}))
+	assert.Error(t, provider.Declare(&metricsv1.UserMetric{Type: metricsv1.UserMetric_METRIC_TYPE_GAUGE}))
+	assert.Nil(t, provider.UserMetric("untyped"))
+
+	orders := provider.UserMetric("orders")
+	require.NotNil(t, orders)
+	assert.Equal(t, metricsv1.UserMetric_METRIC_TYPE_COUNTER, orders.Type())
+	orders.Record(context.Background(), 2, attribute.String("channel", "web"))
+	orders.Record(context.Background(), 1, attribute.String("channel", "web"))
+	provider.UserMetric("order_amount").Record(context.Background(), 42.5)
+
+	exposition := scrape(t, provider)
+	assert.Regexp(t, `build_info\{[^}]*version="v1.2.3"\} 1`, exposition)
+	assert.Contains(t, exposition, "# HELP build_info The build of the service.")
+	assert.Regexp(t, `orders_total\{channel="web",[^}]*\} 3`, exposition)
+	assert.Regexp(t, `order_amount_USD_sum\{[^}]*\} 42.5`, exposition)
+}
@@ -0,0 +1,145 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	otelprometheus "go.opentelemetry.io/otel/exporters/prometheus"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+
+	metricsv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultPath is the path of the Prometheus exposition handler on the HTTP servers.
+const DefaultPath = "/metrics"
+
+type providerOptions struct {
+	registry  *prometheus.Registry
+	namespace string
+}
+
+// WithRegistry sets the Prometheus registry the metrics are exported by, a new registry
+// with the Go and process collectors by default.
+func WithRegistry(registry *prometheus.Registry) options.Option {
+	return optionutil.Update(func(o *providerOptions) {
+		o.registry = registry
+	})
+}
+
+// WithNamespace sets the prefix of the names of the exported metrics.
+func WithNamespace(namespace string) options.Option {
+	return optionutil.Update(func(o *providerOptions) {
+		o.namespace = namespace
+	})
+}
+
+// Provider is an OpenTelemetry meter provider whose metrics are exported by a Prometheus
+// registry, holding the user metrics declared by the configurations. It is safe for
+// concurrent use.
+type Provider struct {
+	registry *prometheus.Registry
+	provider *sdkmetric.MeterProvider
+	meter    metric.Meter
+
+	mu   sync.Mutex
+	user map[string]*UserMetric
+}
+
+// NewProvider creates a Provider exporting its metrics to a Prometheus registry.
+func NewProvider(opts ...options.Option) (*Provider, error) {
+	o := optionutil.NewT[providerOptions](opts...)
+	if o.registry == nil {
+		o.registry = prometheus.NewRegistry()
+		o.registry.MustRegister(collectors.NewGoCollector(), collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	}
+	exporterOpts := []otelprometheus.Option{otelprometheus.WithRegisterer(o.registry)}
+	if o.namespace != "" {
+		exporterOpts = append(exporterOpts, otelprometheus.WithNamespace(o.namespace))
+	}
+	exporter, err := otelprometheus.New(exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: create prometheus exporter: %w", err)
+	}
+	provider := sdkmetric.NewMeterProvider(sdkmetric.WithReader(exporter))
+	return &Provider{
+		registry: o.registry,
+		provider: provider,
+		meter:    provider.Meter(ScopeName),
+		user:     make(map[string]*UserMetric),
+	}, nil
+}
+
+// MeterProvider returns the OpenTelemetry meter provider of p.
+func (p *Provider) MeterProvider() metric.MeterProvider {
+	return p.provider
+}
+
+// Registry returns the Prometheus registry exporting the metrics of p.
+func (p *Provider) Registry() *prometheus.Registry {
+	return p.registry
+}
+
+// Handler returns the Prometheus exposition handler of the metrics of p.
+func (p *Provider) Handler() http.Handler {
+	return promhttp.HandlerFor(p.registry, promhttp.HandlerOpts{})
+}
+
+// Declare declares the user metrics configured by cfgs. A metric declared again keeps
+// its instrument, and must keep its type.
+func (p *Provider) Declare(cfgs ...*metricsv1.UserMetric) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for _, cfg := range cfgs {
+		if m, ok := p.user[cfg.GetName()]; ok {
+			if m.typ != cfg.GetType() {
+				return fmt.Errorf("metrics: user metric %q declared as %s and %s", cfg.GetName(), m.typ, cfg.GetType())
+			}
+			continue
+		}
+		m, err := newUserMetric(p.meter, cfg)
+		if err != nil {
+			return err
+		}
+		p.user[cfg.GetName()] = m
+	}
+	return nil
+}
+
+// UserMetric returns the user metric declared with name, nil if none is.
+func (p *Provider) UserMetric(name string) *UserMetric {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.user[name]
+}
+
+// Shutdown flushes and stops the meter provider of p.
+func (p *Provider) Shutdown(ctx context.Context) error {
+	return p.provider.Shutdown(ctx)
+}
+
+var (
+	defaultOnce     sync.Once
+	defaultErr      error
+	defaultProvider *Provider
+)
+
+// DefaultProvider returns the Provider of the middlewares built from the
+// configuration, created by its first call.
+func DefaultProvider() (*Provider, error) {
+	defaultOnce.Do(func() {
+		defaultProvider, defaultErr = NewProvider()
+	})
+	return defaultProvider, defaultErr
+}
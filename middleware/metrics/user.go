@@ -0,0 +1,96 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package metrics
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	metricsv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/metrics/v1"
+)
+
+// UserMetric is a metric declared by the configuration and recorded by the application.
+// It is safe for concurrent use.
+type UserMetric struct {
+	name   string
+	typ    metricsv1.UserMetric_MetricType
+	labels []attribute.KeyValue
+	record func(ctx context.Context, value float64, attrs metric.MeasurementOption)
+}
+
+// newUserMetric creates the instrument of the user metric configured by cfg on meter,
+// recording its initial value.
+func newUserMetric(meter metric.Meter, cfg *metricsv1.UserMetric) (*UserMetric, error) {
+	name := cfg.GetName()
+	if name == "" {
+		return nil, errors.New("metrics: user metric without name")
+	}
+	m := &UserMetric{name: name, typ: cfg.GetType(), labels: labelsOf(cfg.GetLabels())}
+	switch cfg.GetType() {
+	case metricsv1.UserMetric_METRIC_TYPE_COUNTER:
+		counter, err := meter.Float64Counter(name, metric.WithDescription(cfg.GetDescription()), metric.WithUnit(cfg.GetUnit()))
+		if err != nil {
+			return nil, fmt.Errorf("metrics: user metric %q: %w", name, err)
+		}
+		m.record = func(ctx context.Context, value float64, attrs metric.MeasurementOption) {
+			counter.Add(ctx, value, attrs)
+		}
+	case metricsv1.UserMetric_METRIC_TYPE_GAUGE:
+		gauge, err := meter.Float64Gauge(name, metric.WithDescription(cfg.GetDescription()), metric.WithUnit(cfg.GetUnit()))
+		if err != nil {
+			return nil, fmt.Errorf("metrics: user metric %q: %w", name, err)
+		}
+		m.record = func(ctx context.Context, value float64, attrs metric.MeasurementOption) {
+			gauge.Record(ctx, value, attrs)
+		}
+	case metricsv1.UserMetric_METRIC_TYPE_HISTOGRAM, metricsv1.UserMetric_METRIC_TYPE_SUMMARY:
+		histogram, err := meter.Float64Histogram(name, metric.WithDescription(cfg.GetDescription()), metric.WithUnit(cfg.GetUnit()))
+		if err != nil {
+			return nil, fmt.Errorf("metrics: user metric %q: %w", name, err)
+		}
+		m.record = func(ctx context.Context, value float64, attrs metric.MeasurementOption) {
+			histogram.Record(ctx, value, attrs)
+		}
+	default:
+		return nil, fmt.Errorf("metrics: user metric %q has no type", name)
+	}
+	switch m.typ {
+	case metricsv1.UserMetric_METRIC_TYPE_COUNTER, metricsv1.UserMetric_METRIC_TYPE_GAUGE:
+		if value := cfg.GetValue(); value != 0 {
+			m.Record(context.Background(), value)
+		}
+	}
+	return m, nil
+}
+
+// Name returns the name of m.
+func (m *UserMetric) Name() string {
+	return m.name
+}
+
+// Type returns the type of m.
+func (m *UserMetric) Type() metricsv1.UserMetric_MetricType {
+	return m.typ
+}
+
+// Record records value with the labels of m and attrs: it is added to a counter, set to
+// a gauge and observed by a histogram or a summary. The values added to a counter must
+// not be negative.
+func (m *UserMetric) Record(ctx context.Context, value float64, attrs ...attribute.KeyValue) {
+	m.record(ctx, value, metric.WithAttributes(append(attrs[:len(attrs):len(attrs)], m.labels...)...))
+}
+
+// labelsOf returns the attributes of labels.
+func labelsOf(labels map[string]string) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(labels))
+	for k, v := range labels {
+		attrs = append(attrs, attribute.String(k, v))
+	}
+	return attrs
+}
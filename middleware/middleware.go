@@ -19,7 +19,10 @@ const (
 	CircuitBreaker      Name = "circuit_breaker"
 	Logging             Name = "logging"
 	Metadata            Name = "metadata"
+	Metrics             Name = "metrics"
 	RateLimiter         Name = "rate_limiter"
+	Retry               Name = "retry"
+	Timeout             Name = "timeout"
 	Tracing             Name = "tracing"
 	Validator           Name = "validator"
 	Optimize            Name = "optimize"
@@ -34,6 +37,8 @@ const (
 	CategoryMiddleware component.Category = "middleware"
 	// CategoryLogger is the category for logger components.
 	CategoryLogger component.Category = "logger"
+	// CategoryTracer is the category for tracer components.
+	CategoryTracer component.Category = "tracer"
 
 	// ServerScope is the standard scope for server-side components.
 	ServerScope component.Scope = "server"
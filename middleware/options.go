@@ -3,22 +3,30 @@ package middleware
 import (
 	"github.com/go-kratos/kratos/v2/middleware/selector"
 	"github.com/golang-jwt/jwt/v5"
+	"go.opentelemetry.io/otel/trace"
 
 	"github.com/origadmin/runtime/contracts/options"
 	"github.com/origadmin/runtime/helpers/optionutil"
 	"github.com/origadmin/runtime/log"
+	"github.com/origadmin/runtime/middleware/metrics"
+	"github.com/origadmin/runtime/middleware/ratelimit"
+	"github.com/origadmin/runtime/middleware/security"
 )
 
 // Options holds common options that have been resolved once at the top level.
 // These options are then passed down to individual middleware factory functions.
 type Options struct {
-	Logger         log.Logger
-	MatchFunc      selector.MatchFunc
-	Carrier        *Carrier
-	Options        []Option
-	ClaimsFactory  func() jwt.Claims
-	SubjectFactory func() string
-	SigningMethod  jwt.SigningMethod
+	Logger          log.Logger
+	MatchFunc       selector.MatchFunc
+	Carrier         *Carrier
+	Options         []Option
+	ClaimsFactory   func() jwt.Claims
+	SubjectFactory  func() string
+	SigningMethod   jwt.SigningMethod
+	RateLimitStore  ratelimit.Store
+	Metrics         *metrics.Provider
+	TracerProvider  trace.TracerProvider
+	PolicyEvaluator security.PolicyEvaluator
 }
 
 // Option is a functional option for configuring middleware options.
@@ -74,6 +82,39 @@ func WithSigningMethod(method jwt.SigningMethod) Option {
 	})
 }
 
+// WithRateLimitStore sets the store of the counters of the memory rate limiter, such as
+// a ratelimit.CacheStore sharing them through a cache, instead of the process memory.
+func WithRateLimitStore(store ratelimit.Store) Option {
+	return optionutil.Update(func(o *Options) {
+		o.RateLimitStore = store
+	})
+}
+
+// WithMetricsProvider sets the Provider of the metrics middleware, instead of the
+// default one. The HTTP servers given it expose its metrics when they enable them.
+func WithMetricsProvider(provider *metrics.Provider) Option {
+	return optionutil.Update(func(o *Options) {
+		o.Metrics = provider
+	})
+}
+
+// WithTracerProvider sets the tracer provider of the tracing middleware, instead of the
+// global one.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return optionutil.Update(func(o *Options) {
+		o.TracerProvider = provider
+	})
+}
+
+// WithPolicyEvaluator sets the evaluator of the policies of the declarative security
+// middleware other than public, authenticated and deny, whose requests are denied
+// without one.
+func WithPolicyEvaluator(evaluator security.PolicyEvaluator) Option {
+	return optionutil.Update(func(o *Options) {
+		o.PolicyEvaluator = evaluator
+	})
+}
+
 // WithSubjectFactory provides a function that generates the JWT 'subject' (sub) claim.
 // This is the recommended way to provide a meaningful user identifier for the token.
 func WithSubjectFactory(factory func() string) Option {
@@ -99,7 +140,8 @@ func FromOptions(opts ...Option) *Options {
 	return mwOpts
 }
 
-// GetLogger returns a log.Helper with the specified module name.
+// GetLogger returns a log.Helper with the specified module name, whose level may be
+// adjusted at runtime through the module levels of the log package.
 func (o *Options) GetLogger(module string) *log.Helper {
-	return log.NewHelper(log.With(o.Logger, "module", module))
+	return log.NewHelper(log.WithModule(o.Logger, module))
 }
@@ -6,9 +6,19 @@
 package middleware
 
 import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
 	"github.com/go-kratos/kratos/v2/middleware/ratelimit"
+	"github.com/go-kratos/kratos/v2/middleware/selector"
 
+	ratelimitv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
 	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/middleware/matcher"
+	runtimeratelimit "github.com/origadmin/runtime/middleware/ratelimit"
 )
 
 type rateLimitFactory struct {
@@ -34,14 +44,14 @@ func (r rateLimitFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts
 
 	var rlOpts []ratelimit.Option
 	switch ratelimitConfig.GetName() {
-	case "redis":
-		// TODO: Implement Redis rate limiter options
-		logger.Warnf("Redis rate limiter not yet implemented")
-		return nil, false
-	case "memory":
-		// TODO: Implement Memory rate limiter options
-		logger.Warnf("Memory rate limiter not yet implemented")
-		return nil, false
+	case runtimeratelimit.Redis, runtimeratelimit.Memory:
+		mw, err := rateLimitServer(ratelimitConfig, mwOpts)
+		if err != nil {
+			logger.Errorf("failed to create %s rate limiter: %v", ratelimitConfig.GetName(), err)
+			return nil, false
+		}
+		logger.Debugf("using %s rate limiter with %d rules", ratelimitConfig.GetName(), len(ratelimitConfig.GetRules()))
+		return mw, true
 	//case "bbr":
 	// default is bbr
 	// rlOpts = append(rlOpts, middlewareRateLimit.WithLimiter(bbr.NewLimiter()))
@@ -52,3 +62,58 @@ func (r rateLimitFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts
 
 	return ratelimit.Server(rlOpts...), true
 }
+
+// rateLimitServer builds the middleware of a memory or redis rate limiter, limiting all
+// requests by x_ratelimit_limit and the requests matched by each rule by its key. The
+// limiters share the store or the redis client, their keys prefixed by the rule names.
+func rateLimitServer(cfg *ratelimitv1.RateLimiter, mwOpts *Options) (KMiddleware, error) {
+	var limiterOpts []options.Option
+	switch {
+	case cfg.GetName() == runtimeratelimit.Redis:
+		limiterOpts = append(limiterOpts, runtimeratelimit.WithRedisClient(runtimeratelimit.NewRedisClient(cfg.GetRedis())))
+	case mwOpts.RateLimitStore != nil:
+		limiterOpts = append(limiterOpts, runtimeratelimit.WithStore(mwOpts.RateLimitStore))
+	default:
+		cleanup := time.Duration(cfg.GetMemory().GetCleanupInterval()) * time.Second
+		limiterOpts = append(limiterOpts, runtimeratelimit.WithStore(runtimeratelimit.NewMemoryStore(runtimeratelimit.WithCleanupInterval(cleanup))))
+	}
+	var serverOpts []options.Option
+	if retryAfter := cfg.GetRetryAfter(); retryAfter > 0 {
+		serverOpts = append(serverOpts, runtimeratelimit.WithRetryAfter(time.Duration(retryAfter)*time.Second))
+	}
+
+	var mws []KMiddleware
+	if cfg.GetXRatelimitLimit() > 0 {
+		limiter, err := runtimeratelimit.New(cfg, limiterOpts...)
+		if err != nil {
+			return nil, err
+		}
+		mws = append(mws, runtimeratelimit.Server(limiter, serverOpts...))
+	}
+	for i, rule := range cfg.GetRules() {
+		limiter, err := runtimeratelimit.NewRuleLimiter(cfg, rule, limiterOpts...)
+		if err != nil {
+			return nil, err
+		}
+		keyFunc, err := runtimeratelimit.ParseKey(rule.GetKey(), runtimeratelimit.WithTrustedProxies(cfg.GetTrustedProxies()...))
+		if err != nil {
+			return nil, err
+		}
+		name := rule.GetName()
+		if name == "" {
+			name = "rule" + strconv.Itoa(i)
+		}
+		prefix := name + ":"
+		mw := runtimeratelimit.Server(limiter, append(serverOpts, runtimeratelimit.WithKeyFunc(func(ctx context.Context) string {
+			return prefix + keyFunc(ctx)
+		}))...)
+		if !matcher.Empty(rule.GetSelector()) {
+			mw = selectorBuilder(rule.GetSelector(), selector.Server(mw), nil)
+		}
+		mws = append(mws, mw)
+	}
+	if len(mws) == 0 {
+		return nil, fmt.Errorf("ratelimit: neither x_ratelimit_limit nor rules are configured")
+	}
+	return KChain(mws...), nil
+}
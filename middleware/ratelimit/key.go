@@ -0,0 +1,202 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	transhttp "github.com/go-kratos/kratos/v2/transport/http"
+	"google.golang.org/grpc/peer"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Keys of the rate limiting rules.
+const (
+	KeyGlobal    = "global"
+	KeyIP        = "ip"
+	KeyOperation = "operation"
+	KeySubject   = "subject"
+	KeyHeader    = "header:"
+	KeyMetadata  = "metadata:"
+)
+
+// KeyFunc returns the key a request is limited by.
+type KeyFunc func(ctx context.Context) string
+
+type keyOptions struct {
+	trustedProxies []string
+}
+
+// WithTrustedProxies sets the IPs or CIDRs of the proxies whose X-Forwarded-For and
+// X-Real-IP headers give the IP of the client of the "ip" key. The headers are ignored
+// by default.
+func WithTrustedProxies(proxies ...string) options.Option {
+	return optionutil.Update(func(o *keyOptions) {
+		o.trustedProxies = proxies
+	})
+}
+
+// ParseKey returns the KeyFunc of the key of a rule:
+//
+//	"" or "global"     a single key for all requests
+//	"ip"               the IP of the client, see ForwardedIP
+//	"operation"        the operation of the request
+//	"subject"          the subject of the JWT claims of the request
+//	"header:<name>"    a request header
+//	"metadata:<key>"   a metadata value of the request
+//
+// The requests without the key, such as the anonymous requests limited by subject,
+// share the empty key.
+func ParseKey(spec string, opts ...options.Option) (KeyFunc, error) {
+	switch spec {
+	case "", KeyGlobal:
+		return func(context.Context) string { return "" }, nil
+	case KeyIP:
+		o := optionutil.NewT[keyOptions](opts...)
+		if len(o.trustedProxies) == 0 {
+			return RemoteIP, nil
+		}
+		proxies, err := ParseTrustedProxies(o.trustedProxies...)
+		if err != nil {
+			return nil, err
+		}
+		return ForwardedIP(proxies...), nil
+	case KeyOperation:
+		return func(ctx context.Context) string {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				return tr.Operation()
+			}
+			return ""
+		}, nil
+	case KeySubject:
+		return Subject, nil
+	}
+	if name, ok := strings.CutPrefix(spec, KeyHeader); ok && name != "" {
+		return func(ctx context.Context) string {
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				return tr.RequestHeader().Get(name)
+			}
+			return ""
+		}, nil
+	}
+	if key, ok := strings.CutPrefix(spec, KeyMetadata); ok && key != "" {
+		return func(ctx context.Context) string {
+			if md, ok := metadata.FromServerContext(ctx); ok {
+				return md.Get(key)
+			}
+			return ""
+		}, nil
+	}
+	return nil, fmt.Errorf("ratelimit: unknown key %q", spec)
+}
+
+// RemoteIP returns the IP of the connection of the client of a request, ignoring the
+// forwarded headers, which any client can set.
+func RemoteIP(ctx context.Context) string {
+	if req, ok := transhttp.RequestFromServerContext(ctx); ok {
+		return hostOf(req.RemoteAddr)
+	}
+	if p, ok := peer.FromContext(ctx); ok && p.Addr != nil {
+		return hostOf(p.Addr.String())
+	}
+	return ""
+}
+
+// ForwardedIP returns a KeyFunc returning the IP of the client of a request. Over HTTP,
+// when the connection comes from one of proxies, it is the last address of
+// X-Forwarded-For that is not one of proxies, or X-Real-IP without X-Forwarded-For.
+// It is the IP of the connection otherwise.
+func ForwardedIP(proxies ...netip.Prefix) KeyFunc {
+	trusted := func(ip string) bool {
+		addr, err := netip.ParseAddr(ip)
+		if err != nil {
+			return false
+		}
+		addr = addr.Unmap()
+		for _, proxy := range proxies {
+			if proxy.Contains(addr) {
+				return true
+			}
+		}
+		return false
+	}
+	return func(ctx context.Context) string {
+		ip := RemoteIP(ctx)
+		req, ok := transhttp.RequestFromServerContext(ctx)
+		if !ok || !trusted(ip) {
+			return ip
+		}
+		if forwarded := req.Header.Values("X-Forwarded-For"); len(forwarded) > 0 {
+			// The proxies append the address of their client, the first one not trusted
+			// from the end is the client.
+			hops := strings.Split(strings.Join(forwarded, ","), ",")
+			for i := len(hops) - 1; i >= 0; i-- {
+				hop := strings.TrimSpace(hops[i])
+				if hop == "" {
+					continue
+				}
+				ip = hop
+				if !trusted(hop) {
+					break
+				}
+			}
+			return ip
+		}
+		if realIP := strings.TrimSpace(req.Header.Get("X-Real-IP")); realIP != "" {
+			return realIP
+		}
+		return ip
+	}
+}
+
+// ParseTrustedProxies parses the IPs or CIDRs of trusted proxies.
+func ParseTrustedProxies(proxies ...string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return nil, fmt.Errorf("ratelimit: invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return nil, fmt.Errorf("ratelimit: invalid trusted proxy %q: %w", proxy, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+	return prefixes, nil
+}
+
+// Subject returns the subject of the JWT claims of a request, as set by the jwt
+// middleware.
+func Subject(ctx context.Context) string {
+	claims, ok := authjwt.FromContext(ctx)
+	if !ok || claims == nil {
+		return ""
+	}
+	subject, _ := claims.GetSubject()
+	return subject
+}
+
+// hostOf returns the host of addr, addr itself when it has no port.
+func hostOf(addr string) string {
+	if host, _, err := net.SplitHostPort(addr); err == nil {
+		return host
+	}
+	return addr
+}
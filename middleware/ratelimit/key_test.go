@@ -0,0 +1,105 @@
+package ratelimit
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-kratos/kratos/v2/metadata"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/peer"
+)
+
+func key(t *testing.T, spec string, ctx context.Context) string {
+	t.Helper()
+	fn, err := ParseKey(spec)
+	require.NoError(t, err)
+	return fn(ctx)
+}
+
+func TestParseKey(t *testing.T) {
+	ctx := transport.NewServerContext(context.Background(), &testTransport{
+		request: headerCarrier{"X-Api-Key": []string{"k1"}},
+		reply:   headerCarrier{},
+	})
+	ctx = metadata.NewServerContext(ctx, metadata.New(map[string][]string{"x-md-tenant": {"t1"}}))
+	ctx = authjwt.NewContext(ctx, jwt.RegisteredClaims{Subject: "u1"})
+
+	assert.Equal(t, "", key(t, "", ctx))
+	assert.Equal(t, "", key(t, KeyGlobal, ctx))
+	assert.Equal(t, "/test", key(t, KeyOperation, ctx))
+	assert.Equal(t, "u1", key(t, KeySubject, ctx))
+	assert.Equal(t, "k1", key(t, "header:X-Api-Key", ctx))
+	assert.Equal(t, "t1", key(t, "metadata:x-md-tenant", ctx))
+	assert.Equal(t, "", key(t, KeySubject, context.Background()), "anonymous requests share the empty key")
+
+	for _, spec := range []string{"user", "header:", "metadata:"} {
+		_, err := ParseKey(spec)
+		assert.Error(t, err, spec)
+	}
+}
+
+// httpTransport is a testTransport carrying an HTTP request.
+type httpTransport struct {
+	testTransport
+	req *http.Request
+}
+
+func (tr *httpTransport) Request() *http.Request { return tr.req }
+func (tr *httpTransport) PathTemplate() string   { return "" }
+
+// httpContext returns the server context of a request of remoteAddr with headers.
+func httpContext(remoteAddr string, headers map[string]string) context.Context {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	return transport.NewServerContext(context.Background(), &httpTransport{req: req})
+}
+
+func TestRemoteIP(t *testing.T) {
+	// The forwarded headers are ignored.
+	ctx := httpContext("10.0.0.1:1234", map[string]string{"X-Forwarded-For": "172.16.0.1", "X-Real-IP": "192.168.0.1"})
+	assert.Equal(t, "10.0.0.1", RemoteIP(ctx))
+	assert.Equal(t, "10.0.0.1", key(t, KeyIP, ctx))
+
+	ctx = peer.NewContext(context.Background(), &peer.Peer{Addr: &net.TCPAddr{IP: net.ParseIP("10.0.0.3"), Port: 50051}})
+	assert.Equal(t, "10.0.0.3", RemoteIP(ctx))
+	assert.Equal(t, "", RemoteIP(context.Background()))
+}
+
+func TestForwardedIP(t *testing.T) {
+	fn, err := ParseKey(KeyIP, WithTrustedProxies("10.0.0.0/8", "192.168.1.1"))
+	require.NoError(t, err)
+	tests := []struct {
+		name    string
+		remote  string
+		headers map[string]string
+		want    string
+	}{
+		{"Direct", "10.0.0.1:1234", nil, "10.0.0.1"},
+		{"RealIP", "10.0.0.1:1234", map[string]string{"X-Real-IP": "172.16.0.9"}, "172.16.0.9"},
+		{"ForwardedFor", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "172.16.0.1", "X-Real-IP": "172.16.0.9"}, "172.16.0.1"},
+		{"ProxyChain", "192.168.1.1:1234", map[string]string{"X-Forwarded-For": "172.16.0.1, 10.0.0.2"}, "172.16.0.1"},
+		{"SpoofedHop", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "1.2.3.4, 172.16.0.1"}, "172.16.0.1"},
+		{"AllTrusted", "10.0.0.1:1234", map[string]string{"X-Forwarded-For": "10.0.0.3, 10.0.0.2"}, "10.0.0.3"},
+		{"UntrustedConnection", "172.16.0.5:1234", map[string]string{"X-Forwarded-For": "1.2.3.4", "X-Real-IP": "1.2.3.4"}, "172.16.0.5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, fn(httpContext(tt.remote, tt.headers)))
+		})
+	}
+
+	_, err = ParseKey(KeyIP, WithTrustedProxies("10.0.0.0/33"))
+	assert.Error(t, err)
+	_, err = ParseKey(KeyIP, WithTrustedProxies("proxy.local"))
+	assert.Error(t, err)
+}
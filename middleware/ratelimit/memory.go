@@ -0,0 +1,177 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultCleanupInterval is the interval of the removal of the expired keys of a
+// MemoryStore when none is configured.
+const DefaultCleanupInterval = time.Minute
+
+type memoryOptions struct {
+	expiration time.Duration
+	cleanup    time.Duration
+}
+
+// WithExpiration sets the time after which the state of an idle key is removed. It is
+// never shorter than the period, the state of a key idle for a period is that of a new key.
+func WithExpiration(d time.Duration) options.Option {
+	return optionutil.Update(func(o *memoryOptions) {
+		o.expiration = d
+	})
+}
+
+// WithCleanupInterval sets the interval of the removal of the expired keys of a
+// MemoryStore.
+func WithCleanupInterval(d time.Duration) options.Option {
+	return optionutil.Update(func(o *memoryOptions) {
+		o.cleanup = d
+	})
+}
+
+// StoreLimiter is a Limiter keeping the state of its keys in a Store. It is safe for
+// concurrent use when its store is.
+type StoreLimiter struct {
+	store      Store
+	limit      int
+	period     time.Duration
+	window     bool
+	expiration time.Duration
+	now        func() time.Time
+}
+
+// NewTokenBucket creates a StoreLimiter refilling a bucket of limit tokens over period,
+// allowing bursts of up to limit requests.
+func NewTokenBucket(store Store, limit int, period time.Duration, opts ...options.Option) *StoreLimiter {
+	return newStoreLimiter(store, limit, period, false, opts)
+}
+
+// NewSlidingWindow creates a StoreLimiter allowing limit requests within any window of
+// period.
+func NewSlidingWindow(store Store, limit int, period time.Duration, opts ...options.Option) *StoreLimiter {
+	return newStoreLimiter(store, limit, period, true, opts)
+}
+
+// NewMemoryTokenBucket creates a token bucket StoreLimiter on a new MemoryStore, for the
+// limits of a single instance.
+func NewMemoryTokenBucket(limit int, period time.Duration, opts ...options.Option) *StoreLimiter {
+	return newStoreLimiter(NewMemoryStore(opts...), limit, period, false, opts)
+}
+
+// NewMemorySlidingWindow creates a sliding window StoreLimiter on a new MemoryStore, for
+// the limits of a single instance.
+func NewMemorySlidingWindow(limit int, period time.Duration, opts ...options.Option) *StoreLimiter {
+	return newStoreLimiter(NewMemoryStore(opts...), limit, period, true, opts)
+}
+
+func newStoreLimiter(store Store, limit int, period time.Duration, window bool, opts []options.Option) *StoreLimiter {
+	o := optionutil.NewT[memoryOptions](opts...)
+	if o.expiration < period {
+		o.expiration = period
+	}
+	return &StoreLimiter{
+		store:      store,
+		limit:      limit,
+		period:     period,
+		window:     window,
+		expiration: o.expiration,
+		now:        time.Now,
+	}
+}
+
+// Allow takes a request of key from its limit.
+func (l *StoreLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	now := l.now()
+	var res Result
+	err := l.store.Update(ctx, key, l.expiration, func(state *State) {
+		if state.Last.IsZero() {
+			state.Tokens = float64(l.limit)
+			state.Last = now
+		}
+		if l.window {
+			res = l.slide(state, now)
+		} else {
+			res = l.take(state, now)
+		}
+	})
+	if err != nil {
+		return Result{}, err
+	}
+	return res, nil
+}
+
+// take takes a token from the bucket of state.
+func (l *StoreLimiter) take(state *State, now time.Time) Result {
+	var res Result
+	state.Tokens, res = takeToken(state.Tokens, now.Sub(state.Last), l.limit, l.period)
+	state.Last = now
+	return res
+}
+
+// takeToken refills a bucket holding tokens for elapsed and takes a token from it. It
+// returns the tokens left in the bucket.
+func takeToken(tokens float64, elapsed time.Duration, limit int, period time.Duration) (float64, Result) {
+	if elapsed > 0 {
+		tokens = math.Min(float64(limit), tokens+float64(elapsed)/float64(period/time.Duration(limit)))
+	}
+	allowed := tokens >= 1
+	if allowed {
+		tokens--
+	}
+	return tokens, bucketResult(allowed, tokens, limit, period)
+}
+
+// bucketResult returns the Result of a request to a bucket left with tokens.
+func bucketResult(allowed bool, tokens float64, limit int, period time.Duration) Result {
+	perToken := float64(period / time.Duration(limit))
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: int(tokens),
+		Reset:     time.Duration((float64(limit) - tokens) * perToken),
+	}
+	if !allowed {
+		res.RetryAfter = time.Duration((1 - tokens) * perToken)
+	}
+	return res
+}
+
+// slide records a hit in the window of state.
+func (l *StoreLimiter) slide(state *State, now time.Time) Result {
+	start := now.Add(-l.period)
+	i := 0
+	for i < len(state.Hits) && !state.Hits[i].After(start) {
+		i++
+	}
+	state.Hits = state.Hits[i:]
+	state.Last = now
+	allowed := len(state.Hits) < l.limit
+	if allowed {
+		state.Hits = append(state.Hits, now)
+	}
+	return windowResult(allowed, len(state.Hits), state.Hits[0], state.Hits[len(state.Hits)-1], now, l.limit, l.period)
+}
+
+// windowResult returns the Result of a request to a window holding count hits, from
+// oldest to newest.
+func windowResult(allowed bool, count int, oldest, newest, now time.Time, limit int, period time.Duration) Result {
+	res := Result{
+		Allowed:   allowed,
+		Limit:     limit,
+		Remaining: limit - count,
+		Reset:     newest.Add(period).Sub(now),
+	}
+	if !allowed {
+		res.RetryAfter = oldest.Add(period).Sub(now)
+	}
+	return res
+}
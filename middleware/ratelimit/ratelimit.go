@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package ratelimit implements the memory and redis rate limiters of the rate_limiter middleware.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	kratosratelimit "github.com/go-kratos/kratos/v2/middleware/ratelimit"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+
+	ratelimitv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Names of the limiters and of their algorithms.
+const (
+	Memory = "memory"
+	Redis  = "redis"
+
+	TokenBucket   = "token_bucket"
+	SlidingWindow = "sliding_window"
+)
+
+// DefaultPeriod is the rate limit window when none is configured.
+const DefaultPeriod = time.Second
+
+// Headers describing the rate limit of a response.
+const (
+	HeaderLimit      = "X-RateLimit-Limit"
+	HeaderRemaining  = "X-RateLimit-Remaining"
+	HeaderReset      = "X-RateLimit-Reset"
+	HeaderRetryAfter = "Retry-After"
+)
+
+// ErrLimitExceed is returned by the middleware for the requests exceeding the limit.
+var ErrLimitExceed = kratosratelimit.ErrLimitExceed
+
+// Result is the decision of a Limiter on a request.
+type Result struct {
+	// Allowed reports whether the request may proceed.
+	Allowed bool
+	// Limit is the number of requests allowed per period.
+	Limit int
+	// Remaining is the number of requests that can still be made in the current period.
+	Remaining int
+	// Reset is the time until the limit is fully available again.
+	Reset time.Duration
+	// RetryAfter is the time to wait before the next request is allowed, when it is not.
+	RetryAfter time.Duration
+}
+
+// Limiter decides whether the requests of a key may proceed.
+type Limiter interface {
+	Allow(ctx context.Context, key string) (Result, error)
+}
+
+type limiterOptions struct {
+	store  Store
+	client redis.Scripter
+}
+
+// WithStore sets the Store of a memory rate limiter, instead of a new MemoryStore.
+func WithStore(store Store) options.Option {
+	return optionutil.Update(func(o *limiterOptions) {
+		o.store = store
+	})
+}
+
+// WithRedisClient sets the client of a redis rate limiter, instead of a new one for its
+// redis configuration.
+func WithRedisClient(client redis.Scripter) options.Option {
+	return optionutil.Update(func(o *limiterOptions) {
+		o.client = client
+	})
+}
+
+// New creates the Limiter of a memory or redis rate limiter configuration, limiting the
+// requests by x_ratelimit_limit.
+func New(cfg *ratelimitv1.RateLimiter, opts ...options.Option) (Limiter, error) {
+	limit := int(cfg.GetXRatelimitLimit())
+	if limit <= 0 {
+		return nil, fmt.Errorf("ratelimit: x_ratelimit_limit must be positive, got %d", limit)
+	}
+	return newLimiter(cfg, limit, cfg.GetPeriod(), cfg.GetAlgorithm(), opts)
+}
+
+// NewRuleLimiter creates the Limiter of a rule of a memory or redis rate limiter
+// configuration. The period and the algorithm of the rule default to those of cfg.
+func NewRuleLimiter(cfg *ratelimitv1.RateLimiter, rule *ratelimitv1.RateLimiter_Rule, opts ...options.Option) (Limiter, error) {
+	limit := int(rule.GetLimit())
+	if limit <= 0 {
+		return nil, fmt.Errorf("ratelimit: limit of rule %q must be positive, got %d", rule.GetName(), limit)
+	}
+	period := rule.GetPeriod()
+	if period <= 0 {
+		period = cfg.GetPeriod()
+	}
+	algorithm := rule.GetAlgorithm()
+	if algorithm == "" {
+		algorithm = cfg.GetAlgorithm()
+	}
+	return newLimiter(cfg, limit, period, algorithm, opts)
+}
+
+func newLimiter(cfg *ratelimitv1.RateLimiter, limit int, periodSeconds int32, algorithm string, opts []options.Option) (Limiter, error) {
+	period := time.Duration(periodSeconds) * time.Second
+	if period <= 0 {
+		period = DefaultPeriod
+	}
+	if algorithm == "" {
+		algorithm = TokenBucket
+	}
+	if algorithm != TokenBucket && algorithm != SlidingWindow {
+		return nil, fmt.Errorf("ratelimit: unknown algorithm %q", algorithm)
+	}
+
+	o := optionutil.NewT[limiterOptions](opts...)
+	switch cfg.GetName() {
+	case Memory:
+		memory := cfg.GetMemory()
+		memoryOpts := []options.Option{
+			WithExpiration(time.Duration(memory.GetExpiration()) * time.Second),
+			WithCleanupInterval(time.Duration(memory.GetCleanupInterval()) * time.Second),
+		}
+		store := o.store
+		if store == nil {
+			store = NewMemoryStore(memoryOpts...)
+		}
+		if algorithm == SlidingWindow {
+			return NewSlidingWindow(store, limit, period, memoryOpts...), nil
+		}
+		return NewTokenBucket(store, limit, period, memoryOpts...), nil
+	case Redis:
+		client := o.client
+		if client == nil {
+			client = NewRedisClient(cfg.GetRedis())
+		}
+		if algorithm == SlidingWindow {
+			return NewRedisSlidingWindow(client, limit, period), nil
+		}
+		return NewRedisTokenBucket(client, limit, period), nil
+	}
+	return nil, fmt.Errorf("ratelimit: unknown rate limiter %q", cfg.GetName())
+}
+
+type serverOptions struct {
+	keyFunc    KeyFunc
+	retryAfter time.Duration
+}
+
+// WithKeyFunc sets the function returning the key a request is limited by. All requests
+// share a single limit by default.
+func WithKeyFunc(fn KeyFunc) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.keyFunc = fn
+	})
+}
+
+// WithRetryAfter sets the Retry-After of the limited requests, instead of the time until
+// the limiter allows the next one.
+func WithRetryAfter(d time.Duration) options.Option {
+	return optionutil.Update(func(o *serverOptions) {
+		o.retryAfter = d
+	})
+}
+
+// Server returns a server middleware limiting the requests with limiter. The rate limit
+// headers are set on the responses, the limited requests fail with ErrLimitExceed.
+// Requests are allowed when the limiter fails, a broken store must not take the
+// service down.
+func Server(limiter Limiter, opts ...options.Option) kratosmiddleware.Middleware {
+	o := optionutil.NewT[serverOptions](opts...)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			var key string
+			if o.keyFunc != nil {
+				key = o.keyFunc(ctx)
+			}
+			res, err := limiter.Allow(ctx, key)
+			if err != nil {
+				return handler(ctx, req)
+			}
+			if !res.Allowed && o.retryAfter > 0 {
+				res.RetryAfter = o.retryAfter
+			}
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				SetHeaders(tr.ReplyHeader(), res)
+			}
+			if !res.Allowed {
+				return nil, ErrLimitExceed
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// SetHeaders sets the rate limit headers of res, with the times in whole seconds.
+func SetHeaders(header transport.Header, res Result) {
+	header.Set(HeaderLimit, strconv.Itoa(res.Limit))
+	header.Set(HeaderRemaining, strconv.Itoa(res.Remaining))
+	header.Set(HeaderReset, strconv.Itoa(seconds(res.Reset)))
+	if !res.Allowed {
+		header.Set(HeaderRetryAfter, strconv.Itoa(seconds(res.RetryAfter)))
+	}
+}
+
+// seconds rounds d up to whole seconds.
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,249 @@
+package ratelimit
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cachev1 "github.com/origadmin/runtime/api/gen/go/config/data/cache/v1"
+	ratelimitv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
+	"github.com/origadmin/runtime/data/storage/cache/memory"
+)
+
+// clock is a settable time source.
+type clock struct{ now time.Time }
+
+func (c *clock) Now() time.Time          { return c.now }
+func (c *clock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newClock() *clock {
+	return &clock{now: time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)}
+}
+
+// limiters returns the memory and redis limiters of an algorithm, sharing c.
+func limiters(t *testing.T, window bool, limit int, period time.Duration, c *clock) map[string]Limiter {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+
+	store := NewMemoryStore()
+	store.now = c.Now
+	memory := newStoreLimiter(store, limit, period, window, nil)
+	memory.now = c.Now
+	rl := newRedisLimiter(client, limit, period, window, nil)
+	rl.now = c.Now
+	return map[string]Limiter{Memory: memory, Redis: rl}
+}
+
+func allow(t *testing.T, l Limiter, key string) Result {
+	t.Helper()
+	res, err := l.Allow(context.Background(), key)
+	require.NoError(t, err)
+	return res
+}
+
+func TestTokenBucket(t *testing.T) {
+	for name, build := range map[string]func(*testing.T, *clock) Limiter{
+		Memory: func(t *testing.T, c *clock) Limiter { return limiters(t, false, 3, 3*time.Second, c)[Memory] },
+		Redis:  func(t *testing.T, c *clock) Limiter { return limiters(t, false, 3, 3*time.Second, c)[Redis] },
+	} {
+		t.Run(name, func(t *testing.T) {
+			c := newClock()
+			l := build(t, c)
+			for i := 2; i >= 0; i-- {
+				res := allow(t, l, "a")
+				assert.True(t, res.Allowed)
+				assert.Equal(t, i, res.Remaining)
+			}
+			res := allow(t, l, "a")
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 3, res.Limit)
+			assert.Equal(t, time.Second, res.RetryAfter)
+			assert.Equal(t, 3*time.Second, res.Reset)
+			assert.True(t, allow(t, l, "b").Allowed, "keys are limited separately")
+
+			c.Advance(1500 * time.Millisecond)
+			res = allow(t, l, "a")
+			assert.True(t, res.Allowed, "refilled")
+			assert.Equal(t, 0, res.Remaining)
+			assert.False(t, allow(t, l, "a").Allowed)
+
+			c.Advance(time.Hour)
+			assert.Equal(t, 2, allow(t, l, "a").Remaining, "bucket capped at the limit")
+		})
+	}
+}
+
+func TestSlidingWindow(t *testing.T) {
+	for _, name := range []string{Memory, Redis} {
+		t.Run(name, func(t *testing.T) {
+			c := newClock()
+			l := limiters(t, true, 2, 10*time.Second, c)[name]
+			assert.True(t, allow(t, l, "a").Allowed)
+			c.Advance(4 * time.Second)
+			res := allow(t, l, "a")
+			assert.True(t, res.Allowed)
+			assert.Equal(t, 0, res.Remaining)
+			assert.Equal(t, 10*time.Second, res.Reset)
+
+			c.Advance(4 * time.Second)
+			res = allow(t, l, "a")
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 2*time.Second, res.RetryAfter)
+
+			c.Advance(2 * time.Second)
+			res = allow(t, l, "a")
+			assert.True(t, res.Allowed, "the first hit left the window")
+			assert.Equal(t, 0, res.Remaining)
+		})
+	}
+}
+
+func TestMemoryExpiration(t *testing.T) {
+	c := newClock()
+	store := NewMemoryStore(WithCleanupInterval(time.Minute))
+	store.now = c.Now
+	l := NewTokenBucket(store, 1, time.Second, WithExpiration(time.Minute))
+	l.now = c.Now
+	allow(t, l, "a")
+	c.Advance(30 * time.Second)
+	allow(t, l, "b")
+	assert.Equal(t, 2, store.Len())
+	c.Advance(45 * time.Second)
+	allow(t, l, "b")
+	assert.Equal(t, 1, store.Len(), "a expired")
+	assert.Contains(t, store.entries, "b")
+}
+
+func TestCacheStore(t *testing.T) {
+	c := newClock()
+	cache, err := memory.New(&cachev1.CacheConfig{Driver: memory.DriverName})
+	require.NoError(t, err)
+	store := NewCacheStore(cache)
+	for name, l := range map[string]*StoreLimiter{
+		TokenBucket:   NewTokenBucket(store, 2, time.Minute),
+		SlidingWindow: NewSlidingWindow(store, 2, time.Minute),
+	} {
+		t.Run(name, func(t *testing.T) {
+			l.now = c.Now
+			assert.True(t, allow(t, l, name).Allowed)
+			assert.True(t, allow(t, l, name).Allowed)
+			res := allow(t, l, name)
+			assert.False(t, res.Allowed)
+			assert.Equal(t, 0, res.Remaining)
+			ok, err := cache.Exists(context.Background(), DefaultPrefix+name)
+			require.NoError(t, err)
+			assert.True(t, ok)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	mr := miniredis.RunT(t)
+	l, err := New(&ratelimitv1.RateLimiter{
+		Name:            Redis,
+		XRatelimitLimit: 1,
+		Algorithm:       SlidingWindow,
+		Redis:           &ratelimitv1.RateLimiter_Redis{Addr: mr.Addr()},
+	})
+	require.NoError(t, err)
+	assert.True(t, allow(t, l, "k").Allowed)
+	assert.False(t, allow(t, l, "k").Allowed)
+	assert.True(t, mr.Exists(DefaultPrefix+"k"))
+
+	l, err = New(&ratelimitv1.RateLimiter{Name: Memory, XRatelimitLimit: 5, Period: 60})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, l.(*StoreLimiter).period)
+
+	cfg := &ratelimitv1.RateLimiter{Name: Memory, Period: 60, Algorithm: SlidingWindow}
+	l, err = NewRuleLimiter(cfg, &ratelimitv1.RateLimiter_Rule{Name: "login", Limit: 3})
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, l.(*StoreLimiter).period, "period of the rate limiter")
+	assert.True(t, l.(*StoreLimiter).window, "algorithm of the rate limiter")
+	l, err = NewRuleLimiter(cfg, &ratelimitv1.RateLimiter_Rule{Limit: 3, Period: 1, Algorithm: TokenBucket})
+	require.NoError(t, err)
+	assert.Equal(t, time.Second, l.(*StoreLimiter).period)
+	assert.False(t, l.(*StoreLimiter).window)
+	_, err = NewRuleLimiter(cfg, &ratelimitv1.RateLimiter_Rule{Name: "login"})
+	assert.Error(t, err, "rules need a limit")
+
+	for _, cfg := range []*ratelimitv1.RateLimiter{
+		{Name: Memory},
+		{Name: Memory, XRatelimitLimit: 1, Algorithm: "leaky"},
+		{Name: "unknown", XRatelimitLimit: 1},
+	} {
+		_, err := New(cfg)
+		assert.Error(t, err, "%v", cfg)
+	}
+}
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	request headerCarrier
+	reply   headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return "/test" }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.request }
+func (tr *testTransport) ReplyHeader() transport.Header   { return tr.reply }
+
+func TestServer(t *testing.T) {
+	c := newClock()
+	l := NewMemoryTokenBucket(2, time.Minute)
+	l.now = c.Now
+	key, err := ParseKey(KeyOperation)
+	require.NoError(t, err)
+	handler := Server(l, WithRetryAfter(5*time.Second), WithKeyFunc(key))(func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+
+	call := func() (http.Header, error) {
+		tr := &testTransport{request: headerCarrier{}, reply: headerCarrier{}}
+		_, err := handler(transport.NewServerContext(context.Background(), tr), nil)
+		return http.Header(tr.reply), err
+	}
+	header, err := call()
+	require.NoError(t, err)
+	assert.Equal(t, "2", header.Get(HeaderLimit))
+	assert.Equal(t, "1", header.Get(HeaderRemaining))
+	assert.Equal(t, "30", header.Get(HeaderReset))
+	assert.Empty(t, header.Get(HeaderRetryAfter))
+
+	_, _ = call()
+	header, err = call()
+	assert.EqualValues(t, 429, errors.FromError(err).Code)
+	assert.Equal(t, "0", header.Get(HeaderRemaining))
+	assert.Equal(t, "5", header.Get(HeaderRetryAfter))
+	assert.Contains(t, l.store.(*MemoryStore).entries, "/test")
+}
+
+func TestServerLimiterError(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr(), MaxRetries: -1})
+	defer client.Close()
+	mr.Close()
+	handler := Server(NewRedisTokenBucket(client, 1, time.Second))(func(ctx context.Context, req any) (any, error) {
+		return "ok", nil
+	})
+	reply, err := handler(context.Background(), nil)
+	assert.NoError(t, err, "requests are allowed when the store fails")
+	assert.Equal(t, "ok", reply)
+}
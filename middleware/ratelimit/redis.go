@@ -0,0 +1,168 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+
+	ratelimitv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/ratelimit/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultPrefix prefixes the keys of the RedisLimiters and of the CacheStores.
+const DefaultPrefix = "ratelimit:"
+
+// tokenBucketScript refills the bucket of KEYS[1] and takes a token from it. The
+// arguments are the limit, the period and the current time in microseconds.
+var tokenBucketScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+local state = redis.call('HMGET', KEYS[1], 'tokens', 'last')
+local tokens = tonumber(state[1])
+local last = tonumber(state[2])
+if tokens == nil or last == nil then
+  tokens = limit
+  last = now
+end
+if now > last then
+  tokens = math.min(limit, tokens + (now - last) * limit / period)
+else
+  now = last
+end
+local allowed = 0
+if tokens >= 1 then
+  tokens = tokens - 1
+  allowed = 1
+end
+redis.call('HSET', KEYS[1], 'tokens', tostring(tokens), 'last', tostring(now))
+redis.call('PEXPIRE', KEYS[1], math.ceil(period / 1000))
+return {allowed, tostring(tokens)}
+`)
+
+// slidingWindowScript records a hit in the window of KEYS[1]. The arguments are the
+// limit, the period and the current time in microseconds, and a unique member.
+var slidingWindowScript = redis.NewScript(`
+local limit = tonumber(ARGV[1])
+local period = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+redis.call('ZREMRANGEBYSCORE', KEYS[1], '-inf', now - period)
+local count = redis.call('ZCARD', KEYS[1])
+local allowed = 0
+if count < limit then
+  redis.call('ZADD', KEYS[1], now, ARGV[4])
+  count = count + 1
+  allowed = 1
+end
+local oldest = redis.call('ZRANGE', KEYS[1], 0, 0, 'WITHSCORES')
+local newest = redis.call('ZRANGE', KEYS[1], -1, -1, 'WITHSCORES')
+redis.call('PEXPIRE', KEYS[1], math.ceil(period / 1000))
+return {allowed, count, oldest[2], newest[2]}
+`)
+
+type prefixOptions struct {
+	prefix string
+}
+
+// WithPrefix sets the prefix of the keys of a RedisLimiter or a CacheStore, DefaultPrefix
+// by default.
+func WithPrefix(prefix string) options.Option {
+	return optionutil.Update(func(o *prefixOptions) {
+		o.prefix = prefix
+	})
+}
+
+// NewRedisClient creates the redis client of the redis configuration of a rate limiter.
+func NewRedisClient(cfg *ratelimitv1.RateLimiter_Redis) *redis.Client {
+	return redis.NewClient(&redis.Options{
+		Addr:     cfg.GetAddr(),
+		Username: cfg.GetUsername(),
+		Password: cfg.GetPassword(),
+		DB:       int(cfg.GetDb()),
+	})
+}
+
+// RedisLimiter is a Limiter keeping the state of its keys in redis, for the limits to be
+// shared by the instances of a service. The state of a key expires after a period.
+type RedisLimiter struct {
+	client redis.Scripter
+	limit  int
+	period time.Duration
+	window bool
+	prefix string
+	now    func() time.Time
+}
+
+// NewRedisTokenBucket creates a RedisLimiter refilling a bucket of limit tokens over
+// period, allowing bursts of up to limit requests.
+func NewRedisTokenBucket(client redis.Scripter, limit int, period time.Duration, opts ...options.Option) *RedisLimiter {
+	return newRedisLimiter(client, limit, period, false, opts)
+}
+
+// NewRedisSlidingWindow creates a RedisLimiter allowing limit requests within any window
+// of period.
+func NewRedisSlidingWindow(client redis.Scripter, limit int, period time.Duration, opts ...options.Option) *RedisLimiter {
+	return newRedisLimiter(client, limit, period, true, opts)
+}
+
+func newRedisLimiter(client redis.Scripter, limit int, period time.Duration, window bool, opts []options.Option) *RedisLimiter {
+	o := optionutil.NewT[prefixOptions](append([]options.Option{WithPrefix(DefaultPrefix)}, opts...)...)
+	return &RedisLimiter{
+		client: client,
+		limit:  limit,
+		period: period,
+		window: window,
+		prefix: o.prefix,
+		now:    time.Now,
+	}
+}
+
+// Allow takes a request of key from its limit.
+func (l *RedisLimiter) Allow(ctx context.Context, key string) (Result, error) {
+	now := l.now()
+	args := []any{l.limit, l.period.Microseconds(), now.UnixMicro()}
+	if !l.window {
+		reply, err := tokenBucketScript.Run(ctx, l.client, []string{l.prefix + key}, args...).Slice()
+		if err != nil {
+			return Result{}, err
+		}
+		if len(reply) != 2 {
+			return Result{}, fmt.Errorf("ratelimit: unexpected reply %v", reply)
+		}
+		tokens, err := strconv.ParseFloat(fmt.Sprint(reply[1]), 64)
+		if err != nil {
+			return Result{}, err
+		}
+		return bucketResult(reply[0] == int64(1), tokens, l.limit, l.period), nil
+	}
+
+	args = append(args, uuid.NewString())
+	reply, err := slidingWindowScript.Run(ctx, l.client, []string{l.prefix + key}, args...).Slice()
+	if err != nil {
+		return Result{}, err
+	}
+	if len(reply) != 4 {
+		return Result{}, fmt.Errorf("ratelimit: unexpected reply %v", reply)
+	}
+	count, _ := reply[1].(int64)
+	// Scores are returned as formatted floats.
+	oldest, err := strconv.ParseFloat(fmt.Sprint(reply[2]), 64)
+	if err != nil {
+		return Result{}, err
+	}
+	newest, err := strconv.ParseFloat(fmt.Sprint(reply[3]), 64)
+	if err != nil {
+		return Result{}, err
+	}
+	return windowResult(reply[0] == int64(1), int(count), time.UnixMicro(int64(oldest)), time.UnixMicro(int64(newest)),
+		now, l.limit, l.period), nil
+}
@@ -0,0 +1,147 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package ratelimit
+
+import (
+	"context"
+	"encoding/json"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/contracts/storage"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// State is the state of a key of a StoreLimiter, the tokens of a bucket or the hits of a
+// window. The zero State is that of a new key.
+type State struct {
+	Tokens float64     `json:"tokens,omitempty"`
+	Last   time.Time   `json:"last"`
+	Hits   []time.Time `json:"hits,omitempty"`
+}
+
+// Store keeps the states of the keys of a StoreLimiter.
+type Store interface {
+	// Update calls fn with the state of key, the zero State when there is none, and keeps
+	// the state fn leaves for ttl. The updates of a key must not interleave.
+	Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error
+}
+
+// MemoryStore is a Store keeping the states in memory, for the limits of a single
+// instance. It is safe for concurrent use.
+type MemoryStore struct {
+	cleanup time.Duration
+	now     func() time.Time
+
+	mu          sync.Mutex
+	entries     map[string]*memoryEntry
+	lastCleanup time.Time
+}
+
+type memoryEntry struct {
+	state   State
+	expires time.Time
+}
+
+// NewMemoryStore creates a MemoryStore, removing the expired keys every
+// DefaultCleanupInterval unless WithCleanupInterval is given.
+func NewMemoryStore(opts ...options.Option) *MemoryStore {
+	o := optionutil.NewT[memoryOptions](opts...)
+	if o.cleanup <= 0 {
+		o.cleanup = DefaultCleanupInterval
+	}
+	return &MemoryStore{
+		cleanup: o.cleanup,
+		now:     time.Now,
+		entries: make(map[string]*memoryEntry),
+	}
+}
+
+// Update implements Store.
+func (s *MemoryStore) Update(_ context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := s.now()
+	if now.Sub(s.lastCleanup) >= s.cleanup {
+		s.removeExpired(now)
+		s.lastCleanup = now
+	}
+	entry, ok := s.entries[key]
+	if !ok || !now.Before(entry.expires) {
+		entry = &memoryEntry{}
+		s.entries[key] = entry
+	}
+	fn(&entry.state)
+	entry.expires = now.Add(ttl)
+	return nil
+}
+
+// Len returns the number of keys kept, including the expired keys not yet removed.
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.entries)
+}
+
+// removeExpired removes the states of the expired keys.
+func (s *MemoryStore) removeExpired(now time.Time) {
+	for key, entry := range s.entries {
+		if !now.Before(entry.expires) {
+			delete(s.entries, key)
+		}
+	}
+}
+
+// cacheLocks is the number of the mutexes serializing the updates of a CacheStore.
+const cacheLocks = 64
+
+// CacheStore is a Store keeping the states as JSON in a storage.Cache. The updates of a
+// key are serialized within the instance only, the limits are shared but approximate
+// when several instances use the same cache.
+type CacheStore struct {
+	cache  storage.Cache
+	prefix string
+	locks  [cacheLocks]sync.Mutex
+}
+
+// NewCacheStore creates a CacheStore on cache, its keys prefixed by DefaultPrefix unless
+// WithPrefix is given.
+func NewCacheStore(cache storage.Cache, opts ...options.Option) *CacheStore {
+	o := optionutil.NewT[prefixOptions](append([]options.Option{WithPrefix(DefaultPrefix)}, opts...)...)
+	return &CacheStore{cache: cache, prefix: o.prefix}
+}
+
+// Update implements Store.
+func (s *CacheStore) Update(ctx context.Context, key string, ttl time.Duration, fn func(state *State)) error {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	mu := &s.locks[h.Sum32()%cacheLocks]
+	mu.Lock()
+	defer mu.Unlock()
+
+	key = s.prefix + key
+	var state State
+	ok, err := s.cache.Exists(ctx, key)
+	if err != nil {
+		return err
+	}
+	if ok {
+		data, err := s.cache.Get(ctx, key)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(data), &state); err != nil {
+			return err
+		}
+	}
+	fn(&state)
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.cache.Set(ctx, key, string(data), ttl)
+}
@@ -8,6 +8,8 @@ import (
 	"context"
 	"fmt"
 
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/origadmin/runtime/contracts"
 	"github.com/origadmin/runtime/contracts/component"
 	"github.com/origadmin/runtime/helpers/comp"
@@ -58,6 +60,10 @@ func getBaseOptions(ctx context.Context, h component.Handle) []Option {
 	if l, err := comp.Get[log.Logger](ctx, h.Locator().In(CategoryLogger)); err == nil {
 		resOpts = append(resOpts, log.WithLogger(l))
 	}
+	// Attach the tracer, creating it before the tracing middleware
+	if tp, err := comp.Get[trace.TracerProvider](ctx, h.Locator().In(CategoryTracer)); err == nil {
+		resOpts = append(resOpts, WithTracerProvider(tp))
+	}
 	return resOpts
 }
 
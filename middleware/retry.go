@@ -0,0 +1,33 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package middleware implements the functions, types, and contracts for the module.
+package middleware
+
+import (
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/middleware/retry"
+)
+
+type retryFactory struct {
+}
+
+// NewMiddlewareClient creates a new client-side retry middleware.
+func (r retryFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.retry")
+	logger.Debug("enabling retry client middleware")
+
+	retryOpts, err := retry.FromConfig(cfg.GetRetry())
+	if err != nil {
+		logger.Errorf("failed to create retry client middleware: %v", err)
+		return nil, false
+	}
+	return retry.Client(retryOpts...), true
+}
+
+// NewMiddlewareServer returns no middleware, retries are made by the clients.
+func (r retryFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	return nil, false
+}
@@ -0,0 +1,66 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package retry
+
+import (
+	"sync"
+)
+
+// Defaults of the Budget.
+const (
+	DefaultMaxTokens  = 10
+	DefaultTokenRatio = 0.1
+)
+
+// Budget is a token bucket limiting the retries of a client, as the retry throttling of
+// gRPC: each failed attempt takes a token, each success adds a fraction of one, and the
+// calls are not retried while half of the tokens are gone. It keeps a struggling service
+// from a retry storm. It is safe for concurrent use.
+type Budget struct {
+	maxTokens float64
+	ratio     float64
+
+	mu     sync.Mutex
+	tokens float64
+}
+
+// NewBudget creates a full Budget of maxTokens, each success adding ratio tokens.
+func NewBudget(maxTokens, ratio float64) *Budget {
+	if maxTokens <= 0 {
+		maxTokens = DefaultMaxTokens
+	}
+	if ratio <= 0 {
+		ratio = DefaultTokenRatio
+	}
+	return &Budget{maxTokens: maxTokens, ratio: ratio, tokens: maxTokens}
+}
+
+// Allow reports whether a failed call may be retried.
+func (b *Budget) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens > b.maxTokens/2
+}
+
+// Success records a successful attempt.
+func (b *Budget) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = min(b.maxTokens, b.tokens+b.ratio)
+}
+
+// Failure records a failed attempt.
+func (b *Budget) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens = max(0, b.tokens-1)
+}
+
+// Tokens returns the tokens left in the bucket.
+func (b *Budget) Tokens() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.tokens
+}
@@ -0,0 +1,144 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package retry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+	"google.golang.org/protobuf/types/descriptorpb"
+
+	retryv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/retry/v1"
+	selectorv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+	"github.com/origadmin/runtime/middleware/matcher"
+)
+
+// Condition reports whether the error of an attempt may be retried.
+type Condition func(err error) bool
+
+// DefaultCondition retries the errors of unavailable services: 502, 503 and 504, which
+// include the gRPC UNAVAILABLE and DEADLINE_EXCEEDED codes, and the attempts timing out.
+func DefaultCondition(err error) bool {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	switch errors.FromError(err).Code {
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// NewCondition returns the Condition of the configuration of a retryable condition,
+// matching the errors with any of its status codes, reasons or gRPC codes.
+func NewCondition(cfg *retryv1.Retry_Condition) (Condition, error) {
+	var conditions []Condition
+	for _, pattern := range cfg.GetStatusCodes() {
+		match, err := matcher.StatusCode(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("retry: %w", err)
+		}
+		conditions = append(conditions, func(err error) bool { return match(int(errors.FromError(err).Code)) })
+	}
+	if reasons := cfg.GetReasons(); len(reasons) > 0 {
+		conditions = append(conditions, Reasons(reasons...))
+	}
+	if names := cfg.GetGrpcCodes(); len(names) > 0 {
+		grpcCodes := make([]codes.Code, 0, len(names))
+		for _, name := range names {
+			code, err := parseCode(name)
+			if err != nil {
+				return nil, err
+			}
+			grpcCodes = append(grpcCodes, code)
+		}
+		conditions = append(conditions, GRPCCodes(grpcCodes...))
+	}
+	return func(err error) bool {
+		for _, condition := range conditions {
+			if condition(err) {
+				return true
+			}
+		}
+		return false
+	}, nil
+}
+
+// Reasons returns a Condition matching the errors with any of reasons.
+func Reasons(reasons ...string) Condition {
+	return func(err error) bool {
+		reason := errors.Reason(err)
+		for _, r := range reasons {
+			if r == reason {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// GRPCCodes returns a Condition matching the errors with any of the gRPC codes.
+func GRPCCodes(grpcCodes ...codes.Code) Condition {
+	return func(err error) bool {
+		code := errors.FromError(err).GRPCStatus().Code()
+		for _, c := range grpcCodes {
+			if c == code {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// parseCode parses a gRPC code by name, such as "UNAVAILABLE", or by number.
+func parseCode(name string) (codes.Code, error) {
+	if n, err := strconv.ParseUint(name, 10, 32); err == nil {
+		return codes.Code(n), nil
+	}
+	var code codes.Code
+	if err := code.UnmarshalJSON([]byte(strconv.Quote(strings.ToUpper(name)))); err != nil {
+		return 0, fmt.Errorf("retry: invalid gRPC code %q", name)
+	}
+	return code, nil
+}
+
+// Idempotent reports whether the calls of an operation are safe to retry.
+type Idempotent func(operation string) bool
+
+// IdempotencyLevel reports whether the method of an operation, such as
+// "/pkg.Service/Method", has an idempotency_level option of IDEMPOTENT or
+// NO_SIDE_EFFECTS, as registered by its generated code.
+func IdempotencyLevel(operation string) bool {
+	name := protoreflect.FullName(strings.ReplaceAll(strings.TrimPrefix(operation, "/"), "/", "."))
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+	if err != nil {
+		return false
+	}
+	method, ok := desc.(protoreflect.MethodDescriptor)
+	if !ok {
+		return false
+	}
+	opts, ok := method.Options().(*descriptorpb.MethodOptions)
+	return ok && opts.GetIdempotencyLevel() != descriptorpb.MethodOptions_IDEMPOTENCY_UNKNOWN
+}
+
+// NewIdempotent returns the Idempotent of the operations with an idempotency_level
+// option or matched by the paths, prefixes or regex of selector.
+func NewIdempotent(selector *selectorv1.Selector) (Idempotent, error) {
+	match, err := matcher.New(selector)
+	if err != nil {
+		return nil, fmt.Errorf("retry: idempotent operations: %w", err)
+	}
+	return func(operation string) bool {
+		return match(operation) || IdempotencyLevel(operation)
+	}, nil
+}
@@ -0,0 +1,247 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package retry implements the retry middleware of the clients: the failed calls of the
+// idempotent operations are attempted again with an exponential backoff, within a retry
+// budget.
+package retry
+
+import (
+	"context"
+	"math"
+	"math/rand/v2"
+	"time"
+
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	transhttp "github.com/go-kratos/kratos/v2/transport/http"
+
+	retryv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/retry/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// Defaults of the retry middleware.
+const (
+	DefaultAttempts   = 3
+	DefaultInitial    = 100 * time.Millisecond
+	DefaultMax        = 5 * time.Second
+	DefaultMultiplier = 2.0
+	DefaultJitter     = 0.2
+)
+
+// Backoff returns the delay before the retry of an attempt, from 1.
+type Backoff func(attempt int) time.Duration
+
+// ExponentialBackoff returns a Backoff growing from initial by multiplier up to
+// maxDelay, by a random fraction of up to jitter more or less.
+func ExponentialBackoff(initial, maxDelay time.Duration, multiplier, jitter float64) Backoff {
+	return func(attempt int) time.Duration {
+		d := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+		d = math.Min(d, float64(maxDelay))
+		if jitter > 0 {
+			d *= 1 + jitter*(2*rand.Float64()-1)
+		}
+		return time.Duration(d)
+	}
+}
+
+type retryOptions struct {
+	attempts      int
+	perTryTimeout time.Duration
+	backoff       Backoff
+	conditions    []Condition
+	budget        *Budget
+	idempotent    Idempotent
+}
+
+// WithAttempts sets the maximum number of attempts of a call, including the first,
+// DefaultAttempts by default.
+func WithAttempts(attempts int) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.attempts = attempts
+	})
+}
+
+// WithPerTryTimeout sets the timeout of each attempt, within that of the call.
+func WithPerTryTimeout(d time.Duration) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.perTryTimeout = d
+	})
+}
+
+// WithBackoff sets the delay between the attempts, an exponential backoff with the
+// defaults by default.
+func WithBackoff(backoff Backoff) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.backoff = backoff
+	})
+}
+
+// WithConditions sets the conditions of the errors to retry, any of them matching.
+// DefaultCondition is used when none is set.
+func WithConditions(conditions ...Condition) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.conditions = conditions
+	})
+}
+
+// WithBudget sets the Budget of the retries, a Budget with the defaults by default.
+func WithBudget(budget *Budget) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.budget = budget
+	})
+}
+
+// WithIdempotent sets the operations safe to retry, those with an idempotency_level
+// option by default.
+func WithIdempotent(idempotent Idempotent) options.Option {
+	return optionutil.Update(func(o *retryOptions) {
+		o.idempotent = idempotent
+	})
+}
+
+// FromConfig returns the options of the middleware configured by cfg.
+func FromConfig(cfg *retryv1.Retry) ([]options.Option, error) {
+	opts := []options.Option{
+		WithAttempts(int(cfg.GetAttempts())),
+		WithPerTryTimeout(time.Duration(cfg.GetPerTryTimeout()) * time.Millisecond),
+		WithBudget(NewBudget(cfg.GetBudget().GetMaxTokens(), cfg.GetBudget().GetTokenRatio())),
+	}
+	backoff := cfg.GetBackoff()
+	initial := time.Duration(backoff.GetInitial()) * time.Millisecond
+	if initial <= 0 {
+		initial = DefaultInitial
+	}
+	maxDelay := time.Duration(backoff.GetMax()) * time.Millisecond
+	if maxDelay <= 0 {
+		maxDelay = DefaultMax
+	}
+	multiplier := backoff.GetMultiplier()
+	if multiplier < 1 {
+		multiplier = DefaultMultiplier
+	}
+	jitter := DefaultJitter
+	if backoff != nil {
+		jitter = math.Min(math.Max(backoff.GetJitter(), 0), 1)
+	}
+	opts = append(opts, WithBackoff(ExponentialBackoff(initial, maxDelay, multiplier, jitter)))
+
+	var conditions []Condition
+	for _, c := range cfg.GetConditions() {
+		condition, err := NewCondition(c)
+		if err != nil {
+			return nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	idempotent, err := NewIdempotent(cfg.GetIdempotent())
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, WithConditions(conditions...), WithIdempotent(idempotent)), nil
+}
+
+// Client returns a client middleware retrying the failed calls of the idempotent
+// operations. A call is retried while its error matches the conditions, its context is
+// not done and the budget allows it, up to the maximum number of attempts, and the
+// error of the last attempt is returned. The requests of the HTTP clients are rewound
+// before each retry.
+func Client(opts ...options.Option) kratosmiddleware.Middleware {
+	o := optionutil.NewT[retryOptions](opts...)
+	if o.attempts <= 0 {
+		o.attempts = DefaultAttempts
+	}
+	if o.backoff == nil {
+		o.backoff = ExponentialBackoff(DefaultInitial, DefaultMax, DefaultMultiplier, DefaultJitter)
+	}
+	if len(o.conditions) == 0 {
+		o.conditions = []Condition{DefaultCondition}
+	}
+	if o.budget == nil {
+		o.budget = NewBudget(DefaultMaxTokens, DefaultTokenRatio)
+	}
+	if o.idempotent == nil {
+		o.idempotent = IdempotencyLevel
+	}
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if !ok || o.attempts == 1 || !o.idempotent(tr.Operation()) {
+				return handler(ctx, req)
+			}
+			for attempt := 1; ; attempt++ {
+				reply, err := o.attempt(ctx, handler, req)
+				if err == nil {
+					o.budget.Success()
+					return reply, nil
+				}
+				if !o.retryable(err) {
+					return reply, err
+				}
+				o.budget.Failure()
+				if attempt == o.attempts || ctx.Err() != nil || !o.budget.Allow() {
+					return reply, err
+				}
+				if sleep(ctx, o.backoff(attempt)) != nil || rewind(tr) != nil {
+					return reply, err
+				}
+			}
+		}
+	}
+}
+
+// attempt calls handler within the per-try timeout.
+func (o *retryOptions) attempt(ctx context.Context, handler kratosmiddleware.Handler, req any) (any, error) {
+	if o.perTryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.perTryTimeout)
+		defer cancel()
+	}
+	return handler(ctx, req)
+}
+
+// retryable reports whether err matches any of the conditions.
+func (o *retryOptions) retryable(err error) bool {
+	for _, condition := range o.conditions {
+		if condition(err) {
+			return true
+		}
+	}
+	return false
+}
+
+// rewind resets the body of the request of an HTTP client, consumed by the last
+// attempt.
+func rewind(tr transport.Transporter) error {
+	htr, ok := tr.(transhttp.Transporter)
+	if !ok {
+		return nil
+	}
+	req := htr.Request()
+	if req == nil || req.Body == nil || req.GetBody == nil {
+		return nil
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return err
+	}
+	req.Body = body
+	return nil
+}
+
+// sleep waits for d, or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,200 @@
+package retry
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	retryv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/retry/v1"
+	selectorv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+)
+
+type testTransport struct {
+	operation string
+	req       *http.Request
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindHTTP }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return nil }
+func (tr *testTransport) ReplyHeader() transport.Header   { return nil }
+func (tr *testTransport) Request() *http.Request          { return tr.req }
+func (tr *testTransport) PathTemplate() string            { return "" }
+
+func clientContext(operation string) context.Context {
+	return transport.NewClientContext(context.Background(), &testTransport{operation: operation})
+}
+
+// failing returns a handler failing with errs in turn, then succeeding, and the number of
+// its calls.
+func failing(errs ...error) (func(context.Context, any) (any, error), *int) {
+	calls := new(int)
+	return func(ctx context.Context, req any) (any, error) {
+		*calls++
+		if *calls <= len(errs) {
+			return nil, errs[*calls-1]
+		}
+		return "ok", nil
+	}, calls
+}
+
+func idempotent(string) bool { return true }
+
+func TestClient(t *testing.T) {
+	noDelay := WithBackoff(func(int) time.Duration { return 0 })
+	unavailable := errors.ServiceUnavailable("DOWN", "")
+
+	h, calls := failing(unavailable, unavailable)
+	reply, err := Client(noDelay, WithIdempotent(idempotent))(h)(clientContext("/a"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+	assert.Equal(t, 3, *calls)
+
+	h, calls = failing(unavailable, unavailable, unavailable)
+	_, err = Client(noDelay, WithIdempotent(idempotent))(h)(clientContext("/a"), nil)
+	assert.Equal(t, unavailable, err, "the error of the last attempt")
+	assert.Equal(t, 3, *calls)
+
+	h, calls = failing(errors.BadRequest("BAD", ""))
+	_, err = Client(noDelay, WithIdempotent(idempotent))(h)(clientContext("/a"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls, "client errors are not retried")
+
+	h, calls = failing(unavailable)
+	_, err = Client(noDelay)(h)(clientContext("/a"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls, "operations are not idempotent by default")
+
+	h, calls = failing(errors.Conflict("BUSY", ""))
+	_, err = Client(noDelay, WithIdempotent(idempotent), WithConditions(Reasons("BUSY")))(h)(clientContext("/a"), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+}
+
+func TestClientPerTryTimeout(t *testing.T) {
+	calls := 0
+	h := func(ctx context.Context, req any) (any, error) {
+		calls++
+		if calls == 1 {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+		return "ok", nil
+	}
+	mw := Client(WithIdempotent(idempotent), WithPerTryTimeout(10*time.Millisecond), WithBackoff(func(int) time.Duration { return 0 }))
+	reply, err := mw(h)(clientContext("/a"), nil)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", reply)
+
+	ctx, cancel := context.WithCancel(clientContext("/a"))
+	cancel()
+	calls = 0
+	_, err = Client(WithIdempotent(idempotent))(func(ctx context.Context, req any) (any, error) {
+		calls++
+		return nil, errors.ServiceUnavailable("DOWN", "")
+	})(ctx, nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "calls of done contexts are not retried")
+}
+
+func TestClientRewind(t *testing.T) {
+	req, err := http.NewRequest(http.MethodPost, "http://localhost/a", bytes.NewReader([]byte("body")))
+	require.NoError(t, err)
+	ctx := transport.NewClientContext(context.Background(), &testTransport{operation: "/a", req: req})
+	var bodies []string
+	h := func(ctx context.Context, _ any) (any, error) {
+		data, _ := io.ReadAll(req.Body)
+		bodies = append(bodies, string(data))
+		if len(bodies) == 1 {
+			return nil, errors.ServiceUnavailable("DOWN", "")
+		}
+		return "ok", nil
+	}
+	_, err = Client(WithIdempotent(idempotent), WithBackoff(func(int) time.Duration { return 0 }))(h)(ctx, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"body", "body"}, bodies)
+}
+
+func TestBudget(t *testing.T) {
+	b := NewBudget(4, 0.5)
+	assert.True(t, b.Allow())
+	b.Failure()
+	assert.True(t, b.Allow())
+	b.Failure()
+	assert.False(t, b.Allow(), "half of the tokens are gone")
+	b.Success()
+	assert.True(t, b.Allow())
+	for i := 0; i < 10; i++ {
+		b.Success()
+	}
+	assert.Equal(t, 4.0, b.Tokens(), "capped at the size of the bucket")
+
+	b = NewBudget(2, 0.1)
+	h, calls := failing(errors.ServiceUnavailable("DOWN", ""), errors.ServiceUnavailable("DOWN", ""))
+	_, err := Client(WithIdempotent(idempotent), WithBudget(b), WithBackoff(func(int) time.Duration { return 0 }))(h)(clientContext("/a"), nil)
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls, "no retry without budget")
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := ExponentialBackoff(100*time.Millisecond, time.Second, 2, 0)
+	assert.Equal(t, 100*time.Millisecond, backoff(1))
+	assert.Equal(t, 400*time.Millisecond, backoff(3))
+	assert.Equal(t, time.Second, backoff(10))
+
+	backoff = ExponentialBackoff(100*time.Millisecond, time.Second, 2, 0.5)
+	for i := 0; i < 100; i++ {
+		d := backoff(2)
+		assert.True(t, d >= 100*time.Millisecond && d <= 300*time.Millisecond, "%v", d)
+	}
+}
+
+func TestFromConfig(t *testing.T) {
+	opts, err := FromConfig(&retryv1.Retry{
+		Attempts: 2,
+		Backoff:  &retryv1.Retry_Backoff{Initial: 1},
+		Conditions: []*retryv1.Retry_Condition{
+			{StatusCodes: []string{"429"}},
+			{GrpcCodes: []string{"resource_exhausted", "4"}},
+		},
+		Idempotent: &selectorv1.Selector{Prefixes: []string{"/api.Users/Get"}},
+	})
+	require.NoError(t, err)
+	mw := Client(opts...)
+
+	for _, c := range []struct {
+		operation string
+		err       error
+		calls     int
+	}{
+		{"/api.Users/GetUser", errors.New(429, "LIMIT", ""), 2},
+		{"/api.Users/GetUser", status.Error(codes.ResourceExhausted, ""), 2},
+		{"/api.Users/GetUser", status.Error(codes.DeadlineExceeded, ""), 2},
+		{"/api.Users/GetUser", errors.ServiceUnavailable("DOWN", ""), 1},
+		{"/api.Users/DeleteUser", errors.New(429, "LIMIT", ""), 1},
+	} {
+		h, calls := failing(c.err)
+		_, _ = mw(h)(clientContext(c.operation), nil)
+		assert.Equal(t, c.calls, *calls, "%s %v", c.operation, c.err)
+	}
+
+	for _, cfg := range []*retryv1.Retry{
+		{Conditions: []*retryv1.Retry_Condition{{StatusCodes: []string{"5xx"}}}},
+		{Conditions: []*retryv1.Retry_Condition{{GrpcCodes: []string{"GONE"}}}},
+		{Idempotent: &selectorv1.Selector{Regex: "("}},
+	} {
+		_, err := FromConfig(cfg)
+		assert.Error(t, err, "%v", cfg)
+	}
+}
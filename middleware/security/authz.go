@@ -0,0 +1,36 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package security
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/origadmin/runtime/security"
+	"github.com/origadmin/runtime/security/authz"
+)
+
+// AuthzEvaluator returns a PolicyEvaluator deciding with authorizer. A policy named
+// resource:action requires that permission, any other policy requires its name as the
+// action on the service method of the operation. The principal has the roles of its
+// claims; the decisions denying a request are returned as errors explaining them.
+func AuthzEvaluator(authorizer *authz.Authorizer) PolicyEvaluator {
+	return PolicyEvaluatorFunc(func(ctx context.Context, principal Principal, policy security.Policy) (bool, error) {
+		req := &authz.Request{
+			Principal: authz.Principal{ID: principal.Subject, Roles: principal.Roles},
+			Resource:  authz.Resource{Name: policy.ServiceMethod},
+			Action:    policy.Name,
+		}
+		if i := strings.LastIndexByte(policy.Name, ':'); i > 0 && i < len(policy.Name)-1 {
+			req.Resource.Name, req.Action = policy.Name[:i], policy.Name[i+1:]
+		}
+		decision := authorizer.Authorize(ctx, req)
+		if !decision.Allowed {
+			return false, errors.New(decision.String())
+		}
+		return true, nil
+	})
+}
@@ -0,0 +1,201 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package security implements the declarative security middleware: the policies of the
+// operations, registered by the generated code, enforced on the authenticated principal
+// of their requests.
+package security
+
+import (
+	"context"
+	"strings"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+
+	commonv1 "github.com/origadmin/runtime/api/gen/go/config/common/v1"
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	runtimeerrors "github.com/origadmin/runtime/errors"
+	"github.com/origadmin/runtime/helpers/optionutil"
+	"github.com/origadmin/runtime/security"
+)
+
+// The built-in policies, enforced by the middleware itself. The other policies are
+// evaluated by the PolicyEvaluator.
+const (
+	// PolicyPublic allows every request.
+	PolicyPublic = "public"
+	// PolicyAuthenticated allows the requests of an authenticated principal.
+	PolicyAuthenticated = "authenticated"
+	// PolicyDeny denies every request.
+	PolicyDeny = "deny"
+)
+
+// Principal is the authenticated caller of a request.
+type Principal struct {
+	// Subject identifies the principal, the subject of its JWT claims.
+	Subject string
+	// Roles are the roles of the principal, those of the roles claim.
+	Roles []string
+	// Claims are the claims the principal was authenticated with, nil when it was not.
+	Claims jwt.Claims
+}
+
+// Authenticated reports whether p was authenticated.
+func (p Principal) Authenticated() bool {
+	return p.Claims != nil
+}
+
+// PrincipalFunc returns the principal of a request.
+type PrincipalFunc func(ctx context.Context) Principal
+
+// PolicyEvaluator decides whether a principal satisfies a policy other than the built-in
+// ones. A request is denied when the evaluator returns false or an error.
+type PolicyEvaluator interface {
+	Evaluate(ctx context.Context, principal Principal, policy security.Policy) (bool, error)
+}
+
+// PolicyEvaluatorFunc is a function implementing PolicyEvaluator.
+type PolicyEvaluatorFunc func(ctx context.Context, principal Principal, policy security.Policy) (bool, error)
+
+// Evaluate calls f.
+func (f PolicyEvaluatorFunc) Evaluate(ctx context.Context, principal Principal, policy security.Policy) (bool, error) {
+	return f(ctx, principal, policy)
+}
+
+type securityOptions struct {
+	defaultPolicy string
+	evaluator     PolicyEvaluator
+	principal     PrincipalFunc
+}
+
+// WithDefaultPolicy sets the policy of the operations without a registered one,
+// PolicyDeny by default.
+func WithDefaultPolicy(policy string) options.Option {
+	return optionutil.Update(func(o *securityOptions) {
+		o.defaultPolicy = policy
+	})
+}
+
+// WithEvaluator sets the evaluator of the policies other than the built-in ones. Without
+// one, the requests of these policies are denied.
+func WithEvaluator(evaluator PolicyEvaluator) options.Option {
+	return optionutil.Update(func(o *securityOptions) {
+		o.evaluator = evaluator
+	})
+}
+
+// WithPrincipal sets the function returning the principal of a request, JWTPrincipal by
+// default.
+func WithPrincipal(principal PrincipalFunc) options.Option {
+	return optionutil.Update(func(o *securityOptions) {
+		o.principal = principal
+	})
+}
+
+// FromConfig returns the options of the middleware configured by cfg.
+func FromConfig(cfg *middlewarev1.Security) []options.Option {
+	if policy := cfg.GetDefaultPolicy(); policy != "" {
+		return []options.Option{WithDefaultPolicy(policy)}
+	}
+	return nil
+}
+
+// RolesClaim is the JWT claim holding the roles of the principal, a list or a space
+// separated string.
+const RolesClaim = "roles"
+
+// JWTPrincipal returns the principal of the JWT claims of a request, as set by the jwt
+// middleware, an unauthenticated one without claims.
+func JWTPrincipal(ctx context.Context) Principal {
+	claims, ok := authjwt.FromContext(ctx)
+	if !ok || claims == nil {
+		return Principal{}
+	}
+	subject, _ := claims.GetSubject()
+	principal := Principal{Subject: subject, Claims: claims}
+	if mapClaims, ok := claims.(jwt.MapClaims); ok {
+		switch roles := mapClaims[RolesClaim].(type) {
+		case string:
+			principal.Roles = strings.Fields(roles)
+		case []any:
+			for _, role := range roles {
+				if role, ok := role.(string); ok {
+					principal.Roles = append(principal.Roles, role)
+				}
+			}
+		case []string:
+			principal.Roles = roles
+		}
+	}
+	return principal
+}
+
+// Server returns a server middleware enforcing the policy registered for the operation
+// of each request, or the default policy, rejecting the denied requests with
+// ERROR_REASON_FORBIDDEN.
+func Server(opts ...options.Option) kratosmiddleware.Middleware {
+	o := optionutil.NewT[securityOptions](opts...)
+	if o.defaultPolicy == "" {
+		o.defaultPolicy = PolicyDeny
+	}
+	if o.principal == nil {
+		o.principal = JWTPrincipal
+	}
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			var operation string
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				operation = tr.Operation()
+			}
+			if err := o.authorize(ctx, operation); err != nil {
+				return nil, err
+			}
+			return handler(ctx, req)
+		}
+	}
+}
+
+// authorize returns the error denying the request of operation, nil if it is allowed.
+func (o *securityOptions) authorize(ctx context.Context, operation string) error {
+	policy, ok := security.PolicyOf(operation)
+	if !ok || policy.Name == "" {
+		policy = security.Policy{ServiceMethod: operation, Name: o.defaultPolicy}
+	}
+	switch policy.Name {
+	case PolicyPublic:
+		return nil
+	case PolicyDeny:
+		return forbidden(policy, nil)
+	}
+	principal := o.principal(ctx)
+	if policy.Name == PolicyAuthenticated {
+		if !principal.Authenticated() {
+			return forbidden(policy, nil)
+		}
+		return nil
+	}
+	if o.evaluator == nil {
+		return forbidden(policy, nil)
+	}
+	allowed, err := o.evaluator.Evaluate(ctx, principal, policy)
+	if err != nil || !allowed {
+		return forbidden(policy, err)
+	}
+	return nil
+}
+
+// forbidden returns the error denying a request by policy, caused by err if not nil.
+func forbidden(policy security.Policy, err error) *kerrors.Error {
+	e := runtimeerrors.FromReason(commonv1.ErrorReason_ERROR_REASON_FORBIDDEN).
+		WithMetadata(map[string]string{"policy": policy.Name})
+	if err != nil {
+		e = e.WithCause(err)
+	}
+	return e
+}
@@ -0,0 +1,172 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	authjwt "github.com/go-kratos/kratos/v2/middleware/auth/jwt"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commonv1 "github.com/origadmin/runtime/api/gen/go/config/common/v1"
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/security"
+	"github.com/origadmin/runtime/security/authz"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	operation string
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return transport.KindGRPC }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return headerCarrier{} }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+func init() {
+	security.RegisterPolicies([]security.Policy{
+		{ServiceMethod: "/test.v1.Users/List", Name: PolicyPublic},
+		{ServiceMethod: "/test.v1.Users/Get", Name: PolicyAuthenticated},
+		{ServiceMethod: "/test.v1.Users/Purge", Name: PolicyDeny},
+		{ServiceMethod: "/test.v1.Users/Delete", Name: "admin-only"},
+	})
+}
+
+// call runs the middleware on a request of operation, authenticated as subject unless
+// it is empty.
+func call(t *testing.T, mw func(ctx context.Context) error, operation, subject string) error {
+	t.Helper()
+	ctx := transport.NewServerContext(context.Background(), &testTransport{operation: operation})
+	if subject != "" {
+		ctx = authjwt.NewContext(ctx, &jwt.RegisteredClaims{Subject: subject})
+	}
+	return mw(ctx)
+}
+
+// run returns the middleware configured by cfg and evaluator, on a handler succeeding.
+func run(t *testing.T, cfg *middlewarev1.Security, evaluator PolicyEvaluator) func(ctx context.Context) error {
+	t.Helper()
+	opts := FromConfig(cfg)
+	if evaluator != nil {
+		opts = append(opts, WithEvaluator(evaluator))
+	}
+	handler := Server(opts...)(func(context.Context, any) (any, error) { return "ok", nil })
+	return func(ctx context.Context) error {
+		_, err := handler(ctx, nil)
+		return err
+	}
+}
+
+func assertForbidden(t *testing.T, err error, policy string) {
+	t.Helper()
+	require.Error(t, err)
+	e := kerrors.FromError(err)
+	assert.Equal(t, int32(http.StatusForbidden), e.Code)
+	assert.Equal(t, commonv1.ErrorReason_ERROR_REASON_FORBIDDEN.String(), e.Reason)
+	assert.Equal(t, policy, e.Metadata["policy"])
+}
+
+func TestServer_BuiltinPolicies(t *testing.T) {
+	mw := run(t, nil, nil)
+
+	assert.NoError(t, call(t, mw, "/test.v1.Users/List", ""))
+	assert.NoError(t, call(t, mw, "/test.v1.Users/Get", "alice"))
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Get", ""), PolicyAuthenticated)
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Purge", "alice"), PolicyDeny)
+}
+
+func TestServer_DefaultPolicy(t *testing.T) {
+	assertForbidden(t, call(t, run(t, nil, nil), "/test.v1.Users/Unknown", "alice"), PolicyDeny)
+
+	mw := run(t, &middlewarev1.Security{DefaultPolicy: PolicyAuthenticated}, nil)
+	assert.NoError(t, call(t, mw, "/test.v1.Users/Unknown", "alice"))
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Unknown", ""), PolicyAuthenticated)
+
+	mw = run(t, &middlewarev1.Security{DefaultPolicy: PolicyPublic}, nil)
+	assert.NoError(t, call(t, mw, "/test.v1.Users/Unknown", ""))
+	// The registered policies take precedence over the default one.
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Purge", ""), PolicyDeny)
+}
+
+func TestServer_Evaluator(t *testing.T) {
+	var evaluated []security.Policy
+	evaluator := PolicyEvaluatorFunc(func(_ context.Context, principal Principal, policy security.Policy) (bool, error) {
+		evaluated = append(evaluated, policy)
+		switch principal.Subject {
+		case "root":
+			return true, nil
+		case "broken":
+			return false, errors.New("policy store unavailable")
+		}
+		return false, nil
+	})
+
+	// Without an evaluator, the custom policies are denied.
+	assertForbidden(t, call(t, run(t, nil, nil), "/test.v1.Users/Delete", "root"), "admin-only")
+
+	mw := run(t, nil, evaluator)
+	assert.NoError(t, call(t, mw, "/test.v1.Users/Delete", "root"))
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Delete", "alice"), "admin-only")
+	err := call(t, mw, "/test.v1.Users/Delete", "broken")
+	assertForbidden(t, err, "admin-only")
+	assert.EqualError(t, errors.Unwrap(err), "policy store unavailable")
+
+	require.Len(t, evaluated, 3)
+	assert.Equal(t, "/test.v1.Users/Delete", evaluated[0].ServiceMethod)
+	assert.Equal(t, "admin-only", evaluated[0].Name)
+
+	// The built-in policies are not evaluated.
+	assert.NoError(t, call(t, mw, "/test.v1.Users/List", ""))
+	assert.Len(t, evaluated, 3)
+}
+
+func TestServer_Principal(t *testing.T) {
+	var got Principal
+	mw := run(t, &middlewarev1.Security{DefaultPolicy: "custom"}, PolicyEvaluatorFunc(
+		func(_ context.Context, principal Principal, _ security.Policy) (bool, error) {
+			got = principal
+			return principal.Authenticated(), nil
+		}))
+
+	require.NoError(t, call(t, mw, "/test.v1.Users/Unknown", "alice"))
+	assert.Equal(t, "alice", got.Subject)
+	assert.True(t, got.Authenticated())
+
+	assertForbidden(t, call(t, mw, "/test.v1.Users/Unknown", ""), "custom")
+	assert.False(t, got.Authenticated())
+}
+
+func TestAuthzEvaluator(t *testing.T) {
+	authorizer, err := authz.New(context.Background(), authz.StaticLoader(&authz.Policy{
+		Roles: []authz.Role{{Name: "admin", Permissions: []string{"users:delete", "/test.v1.Users/*:admin-only"}}},
+	}))
+	require.NoError(t, err)
+	security.RegisterPolicies([]security.Policy{{ServiceMethod: "/test.v1.Users/Remove", Name: "users:delete"}})
+	mw := run(t, nil, AuthzEvaluator(authorizer))
+
+	admin := func(operation string) error {
+		ctx := transport.NewServerContext(context.Background(), &testTransport{operation: operation})
+		ctx = authjwt.NewContext(ctx, jwt.MapClaims{"sub": "root", RolesClaim: []any{"admin"}})
+		return mw(ctx)
+	}
+	assert.NoError(t, admin("/test.v1.Users/Delete"))
+	assert.NoError(t, admin("/test.v1.Users/Remove"))
+
+	err = call(t, mw, "/test.v1.Users/Remove", "alice")
+	assertForbidden(t, err, "users:delete")
+	assert.Contains(t, errors.Unwrap(err).Error(), `no role or rule allows delete on "users"`)
+}
@@ -0,0 +1,42 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package middleware implements the functions, types, and contracts for the module.
+package middleware
+
+import (
+	middlewarev1 "github.com/origadmin/runtime/api/gen/go/config/middleware/v1"
+	"github.com/origadmin/runtime/middleware/timeout"
+)
+
+type timeoutFactory struct {
+}
+
+// NewMiddlewareClient creates a new client-side timeout middleware.
+func (t timeoutFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.timeout")
+	logger.Debug("enabling timeout client middleware")
+
+	timeoutOpts, err := timeout.FromConfig(cfg.GetTimeout())
+	if err != nil {
+		logger.Errorf("failed to create timeout client middleware: %v", err)
+		return nil, false
+	}
+	return timeout.Client(timeoutOpts...), true
+}
+
+// NewMiddlewareServer creates a new server-side timeout middleware.
+func (t timeoutFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
+	mwOpts := FromOptions(opts...)
+	logger := mwOpts.GetLogger("middleware.timeout")
+	logger.Debug("enabling timeout server middleware")
+
+	timeoutOpts, err := timeout.FromConfig(cfg.GetTimeout())
+	if err != nil {
+		logger.Errorf("failed to create timeout server middleware: %v", err)
+		return nil, false
+	}
+	return timeout.Server(timeoutOpts...), true
+}
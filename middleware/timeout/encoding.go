@@ -0,0 +1,62 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package timeout
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+)
+
+// maxTimeoutValue is the largest value of a timeout, 8 digits.
+const maxTimeoutValue = 100_000_000 - 1
+
+// timeoutUnits are the units of the timeouts, from the finest.
+var timeoutUnits = []struct {
+	unit byte
+	d    time.Duration
+}{
+	{'n', time.Nanosecond},
+	{'u', time.Microsecond},
+	{'m', time.Millisecond},
+	{'S', time.Second},
+	{'M', time.Minute},
+	{'H', time.Hour},
+}
+
+// EncodeTimeout encodes d as the value of a grpc-timeout header: an integer of up to 8
+// digits and a unit, in the finest unit that fits, rounded up.
+func EncodeTimeout(d time.Duration) string {
+	if d <= 0 {
+		return "0n"
+	}
+	for _, u := range timeoutUnits {
+		if v := (d + u.d - 1) / u.d; v <= maxTimeoutValue {
+			return strconv.FormatInt(int64(v), 10) + string(u.unit)
+		}
+	}
+	return strconv.Itoa(maxTimeoutValue) + "H"
+}
+
+// DecodeTimeout decodes the value of a grpc-timeout header.
+func DecodeTimeout(s string) (time.Duration, error) {
+	if len(s) < 2 || len(s) > 9 {
+		return 0, fmt.Errorf("timeout: invalid timeout %q", s)
+	}
+	v, err := strconv.ParseInt(s[:len(s)-1], 10, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("timeout: invalid timeout %q", s)
+	}
+	for _, u := range timeoutUnits {
+		if u.unit == s[len(s)-1] {
+			if v > math.MaxInt64/int64(u.d) {
+				return math.MaxInt64, nil
+			}
+			return time.Duration(v) * u.d, nil
+		}
+	}
+	return 0, fmt.Errorf("timeout: invalid timeout unit %q", s)
+}
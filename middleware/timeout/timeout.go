@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package timeout implements the timeout middleware: the deadlines of the operations, by
+// default and per operation, propagated from the clients to the servers.
+package timeout
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	kratosmiddleware "github.com/go-kratos/kratos/v2/middleware"
+	"github.com/go-kratos/kratos/v2/transport"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/origadmin/runtime/api/gen/go/config/common/v1"
+	timeoutv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/timeout/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	runtimeerrors "github.com/origadmin/runtime/errors"
+	"github.com/origadmin/runtime/helpers/optionutil"
+	"github.com/origadmin/runtime/middleware/matcher"
+)
+
+// DefaultHeader is the HTTP header propagating the remaining time of the requests, in
+// the format of the grpc-timeout header.
+const DefaultHeader = "X-Request-Timeout"
+
+// Rule is the timeout of the operations it matches.
+type Rule struct {
+	Match   matcher.Matcher
+	Timeout time.Duration
+}
+
+type timeoutOptions struct {
+	timeout time.Duration
+	rules   []Rule
+	header  string
+}
+
+// WithTimeout sets the timeout of the operations matched by no rule, none by default.
+func WithTimeout(d time.Duration) options.Option {
+	return optionutil.Update(func(o *timeoutOptions) {
+		o.timeout = d
+	})
+}
+
+// WithRules sets the rules overriding the timeout of their operations, the first
+// matching rule applying.
+func WithRules(rules ...Rule) options.Option {
+	return optionutil.Update(func(o *timeoutOptions) {
+		o.rules = rules
+	})
+}
+
+// WithHeader sets the HTTP header propagating the remaining time of the requests,
+// DefaultHeader by default.
+func WithHeader(header string) options.Option {
+	return optionutil.Update(func(o *timeoutOptions) {
+		o.header = header
+	})
+}
+
+// FromConfig returns the options of the middleware configured by cfg.
+func FromConfig(cfg *timeoutv1.Timeout) ([]options.Option, error) {
+	rules := make([]Rule, 0, len(cfg.GetRules()))
+	for i, rule := range cfg.GetRules() {
+		if matcher.Empty(rule.GetSelector()) {
+			return nil, fmt.Errorf("timeout: rule %d selects no operation", i)
+		}
+		match, err := matcher.New(rule.GetSelector())
+		if err != nil {
+			return nil, fmt.Errorf("timeout: rule %d: %w", i, err)
+		}
+		rules = append(rules, Rule{Match: match, Timeout: time.Duration(rule.GetTimeout()) * time.Millisecond})
+	}
+	opts := []options.Option{
+		WithTimeout(time.Duration(cfg.GetDefaultTimeout()) * time.Millisecond),
+		WithRules(rules...),
+	}
+	if header := cfg.GetHeader(); header != "" {
+		opts = append(opts, WithHeader(header))
+	}
+	return opts, nil
+}
+
+// timeoutOf returns the timeout of operation, 0 for none.
+func (o *timeoutOptions) timeoutOf(operation string) time.Duration {
+	for _, rule := range o.rules {
+		if rule.Match(operation) {
+			return rule.Timeout
+		}
+	}
+	return o.timeout
+}
+
+func newOptions(opts []options.Option) *timeoutOptions {
+	o := optionutil.NewT[timeoutOptions](opts...)
+	if o.header == "" {
+		o.header = DefaultHeader
+	}
+	return o
+}
+
+// Server returns a server middleware setting the deadline of the requests, the earliest
+// of the timeout of their operation and of the remaining time propagated by an HTTP
+// client. The gRPC deadlines are propagated by gRPC itself. Deadline exceeded errors
+// are converted to ERROR_REASON_REQUEST_TIMEOUT errors.
+func Server(opts ...options.Option) kratosmiddleware.Middleware {
+	o := newOptions(opts)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			var timeout time.Duration
+			if tr, ok := transport.FromServerContext(ctx); ok {
+				timeout = o.timeoutOf(tr.Operation())
+				if tr.Kind() == transport.KindHTTP {
+					if remaining, err := DecodeTimeout(tr.RequestHeader().Get(o.header)); err == nil && (timeout == 0 || remaining < timeout) {
+						// A request without time left fails at once.
+						timeout = max(remaining, time.Nanosecond)
+					}
+				}
+			}
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				defer cancel()
+			}
+			reply, err := handler(ctx, req)
+			return reply, convert(err)
+		}
+	}
+}
+
+// Client returns a client middleware setting the deadline of the calls to the timeout
+// of their operation, and propagating the remaining time of the HTTP requests in the
+// header. Deadline exceeded errors are converted to ERROR_REASON_REQUEST_TIMEOUT
+// errors.
+func Client(opts ...options.Option) kratosmiddleware.Middleware {
+	o := newOptions(opts)
+	return func(handler kratosmiddleware.Handler) kratosmiddleware.Handler {
+		return func(ctx context.Context, req any) (any, error) {
+			tr, ok := transport.FromClientContext(ctx)
+			if ok {
+				if timeout := o.timeoutOf(tr.Operation()); timeout > 0 {
+					var cancel context.CancelFunc
+					ctx, cancel = context.WithTimeout(ctx, timeout)
+					defer cancel()
+				}
+				if deadline, has := ctx.Deadline(); has && tr.Kind() == transport.KindHTTP {
+					tr.RequestHeader().Set(o.header, EncodeTimeout(time.Until(deadline)))
+				}
+			}
+			reply, err := handler(ctx, req)
+			return reply, convert(err)
+		}
+	}
+}
+
+// convert returns an ERROR_REASON_REQUEST_TIMEOUT error for the deadline exceeded
+// errors, those of the contexts and the gRPC ones, and err otherwise. Kratos errors are
+// kept, with their reason.
+func convert(err error) error {
+	if err == nil {
+		return nil
+	}
+	if kerrors.Is(err, context.DeadlineExceeded) {
+		return runtimeerrors.FromReason(commonv1.ErrorReason_ERROR_REASON_REQUEST_TIMEOUT).WithCause(err)
+	}
+	var ke *kerrors.Error
+	if kerrors.As(err, &ke) {
+		return err
+	}
+	if status.Code(err) == codes.DeadlineExceeded {
+		return runtimeerrors.FromReason(commonv1.ErrorReason_ERROR_REASON_REQUEST_TIMEOUT).WithCause(err)
+	}
+	return err
+}
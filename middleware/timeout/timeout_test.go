@@ -0,0 +1,137 @@
+package timeout
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	kerrors "github.com/go-kratos/kratos/v2/errors"
+	"github.com/go-kratos/kratos/v2/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	commonv1 "github.com/origadmin/runtime/api/gen/go/config/common/v1"
+	selectorv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/selector/v1"
+	timeoutv1 "github.com/origadmin/runtime/api/gen/go/config/middleware/timeout/v1"
+)
+
+type headerCarrier http.Header
+
+func (hc headerCarrier) Get(key string) string      { return http.Header(hc).Get(key) }
+func (hc headerCarrier) Set(key, value string)      { http.Header(hc).Set(key, value) }
+func (hc headerCarrier) Add(key, value string)      { http.Header(hc).Add(key, value) }
+func (hc headerCarrier) Keys() []string             { return nil }
+func (hc headerCarrier) Values(key string) []string { return http.Header(hc).Values(key) }
+
+type testTransport struct {
+	kind      transport.Kind
+	operation string
+	request   headerCarrier
+}
+
+func (tr *testTransport) Kind() transport.Kind            { return tr.kind }
+func (tr *testTransport) Endpoint() string                { return "" }
+func (tr *testTransport) Operation() string               { return tr.operation }
+func (tr *testTransport) RequestHeader() transport.Header { return tr.request }
+func (tr *testTransport) ReplyHeader() transport.Header   { return headerCarrier{} }
+
+// remaining returns a handler recording the time left to its context.
+func remaining(left *time.Duration) func(context.Context, any) (any, error) {
+	return func(ctx context.Context, _ any) (any, error) {
+		*left = -1
+		if deadline, ok := ctx.Deadline(); ok {
+			*left = time.Until(deadline)
+		}
+		return nil, nil
+	}
+}
+
+func TestServer(t *testing.T) {
+	opts, err := FromConfig(&timeoutv1.Timeout{
+		DefaultTimeout: 1000,
+		Rules: []*timeoutv1.Timeout_Rule{
+			{Selector: &selectorv1.Selector{Prefixes: []string{"/api.Reports/"}}, Timeout: 30000},
+			{Selector: &selectorv1.Selector{Paths: []string{"/api.Reports/Stream"}}, Timeout: 1},
+			{Selector: &selectorv1.Selector{Regex: "^/api.Jobs/"}},
+		},
+	})
+	require.NoError(t, err)
+	var left time.Duration
+	handler := Server(opts...)(remaining(&left))
+	call := func(operation string, header headerCarrier) time.Duration {
+		tr := &testTransport{kind: transport.KindHTTP, operation: operation, request: header}
+		_, err := handler(transport.NewServerContext(context.Background(), tr), nil)
+		require.NoError(t, err)
+		return left
+	}
+
+	assert.InDelta(t, time.Second, call("/api.Users/Get", headerCarrier{}), float64(100*time.Millisecond))
+	assert.InDelta(t, 30*time.Second, call("/api.Reports/Stream", headerCarrier{}), float64(100*time.Millisecond),
+		"the first matching rule applies")
+	assert.Equal(t, time.Duration(-1), call("/api.Jobs/Run", headerCarrier{}), "no timeout")
+	assert.InDelta(t, 200*time.Millisecond, call("/api.Users/Get", headerCarrier{DefaultHeader: {"200m"}}), float64(100*time.Millisecond),
+		"the propagated time is shorter")
+	assert.InDelta(t, time.Second, call("/api.Users/Get", headerCarrier{DefaultHeader: {"5S"}}), float64(100*time.Millisecond))
+	assert.InDelta(t, 5*time.Second, call("/api.Jobs/Run", headerCarrier{DefaultHeader: {"5S"}}), float64(100*time.Millisecond))
+	assert.LessOrEqual(t, call("/api.Jobs/Run", headerCarrier{DefaultHeader: {"0n"}}), time.Duration(0), "no time left")
+}
+
+func TestClient(t *testing.T) {
+	var header headerCarrier
+	handler := Client(WithTimeout(time.Second), WithHeader("X-Deadline"))(func(ctx context.Context, _ any) (any, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	for _, kind := range []transport.Kind{transport.KindHTTP, transport.KindGRPC} {
+		header = headerCarrier{}
+		tr := &testTransport{kind: kind, operation: "/api.Users/Get", request: header}
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		_, err := handler(transport.NewClientContext(ctx, tr), nil)
+		cancel()
+		e := kerrors.FromError(err)
+		assert.EqualValues(t, http.StatusRequestTimeout, e.Code)
+		assert.Equal(t, commonv1.ErrorReason_ERROR_REASON_REQUEST_TIMEOUT.String(), e.Reason)
+		if kind == transport.KindHTTP {
+			d, err := DecodeTimeout(header.Get("X-Deadline"))
+			require.NoError(t, err)
+			assert.InDelta(t, 50*time.Millisecond, d, float64(20*time.Millisecond), "the remaining time of the caller")
+		} else {
+			assert.Empty(t, header.Get("X-Deadline"), "gRPC propagates deadlines itself")
+		}
+	}
+}
+
+func TestConvert(t *testing.T) {
+	assert.Nil(t, convert(nil))
+	assert.Equal(t, commonv1.ErrorReason_ERROR_REASON_REQUEST_TIMEOUT.String(),
+		kerrors.Reason(convert(status.Error(codes.DeadlineExceeded, "slow"))))
+	kept := kerrors.GatewayTimeout("UPSTREAM", "")
+	assert.Equal(t, kept, convert(kept), "kratos errors keep their reason")
+	other := status.Error(codes.Unavailable, "")
+	assert.Equal(t, other, convert(other))
+}
+
+func TestTimeoutEncoding(t *testing.T) {
+	for d, s := range map[time.Duration]string{
+		0:                       "0n",
+		50 * time.Millisecond:   "50000000n",
+		150 * time.Millisecond:  "150000u",
+		90 * time.Second:        "90000000u",
+		2 * time.Hour:           "7200000m",
+		1500 * time.Microsecond: "1500000n",
+	} {
+		assert.Equal(t, s, EncodeTimeout(d), "%v", d)
+		decoded, err := DecodeTimeout(s)
+		require.NoError(t, err)
+		assert.Equal(t, d, decoded)
+	}
+	for _, s := range []string{"", "5", "5x", "-5S", "123456789S"} {
+		_, err := DecodeTimeout(s)
+		assert.Error(t, err, s)
+	}
+	_, err := FromConfig(&timeoutv1.Timeout{Rules: []*timeoutv1.Timeout_Rule{{Timeout: 1}}})
+	assert.Error(t, err, "rules need a selector")
+}
@@ -18,12 +18,21 @@ func (t tracingFactory) NewMiddlewareClient(cfg *middlewarev1.Middleware, opts .
 	// Resolve common options once at the factory level.
 	mwOpts := FromOptions(opts...)
 	mwOpts.GetLogger("middleware.tracing").Debug("enabling tracing client middleware")
-	return tracing.Client(), true
+	return tracing.Client(tracingOptions(mwOpts)...), true
 }
 
 func (t tracingFactory) NewMiddlewareServer(cfg *middlewarev1.Middleware, opts ...Option) (KMiddleware, bool) {
 	// Resolve common options once at the factory level.
 	mwOpts := FromOptions(opts...)
 	mwOpts.GetLogger("middleware.tracing").Debug("enabling tracing server middleware")
-	return tracing.Server(), true
+	return tracing.Server(tracingOptions(mwOpts)...), true
+}
+
+// tracingOptions returns the options of the tracing middleware, with the tracer
+// provider of mwOpts when it has one, the global one otherwise.
+func tracingOptions(mwOpts *Options) []tracing.Option {
+	if mwOpts.TracerProvider == nil {
+		return nil
+	}
+	return []tracing.Option{tracing.WithTracerProvider(mwOpts.TracerProvider)}
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/origadmin/runtime/log"
 	"github.com/origadmin/runtime/middleware"
 	"github.com/origadmin/runtime/registry"
+	"github.com/origadmin/runtime/trace"
 )
 
 func init() {
@@ -33,6 +34,7 @@ func init() {
 
 var DefaultResolvers = map[component.Category]component.ConfigResolver{
 	CategoryLogger:      log.Resolve,
+	CategoryTracer:      trace.Resolve,
 	CategoryRegistrar:   registry.Resolve,
 	CategoryDiscovery:   registry.Resolve,
 	CategoryMiddleware:  middleware.Resolve,
@@ -21,6 +21,7 @@ import (
 	"github.com/origadmin/runtime/helpers/comp"
 	"github.com/origadmin/runtime/log"
 	"github.com/origadmin/runtime/registry"
+	"github.com/origadmin/runtime/trace"
 )
 
 // App defines the application's runtime environment powered by engine.
@@ -84,6 +85,14 @@ func (r *App) registerDefaultFactories() {
 		log.DefaultProvider,
 		engine.WithPriority(component.PriorityFramework))
 
+	// Tracer Default, describing the application in the resource of the spans
+	r.engine.Register(CategoryTracer,
+		trace.DefaultProvider,
+		engine.WithPriority(component.PriorityFramework))
+	r.engine.Requirement(CategoryTracer, trace.RequirementAppInfo, func(context.Context, component.Handle, string) (any, error) {
+		return r.appInfo, nil
+	})
+
 	// Registry components are self-registered by the registry package init()
 }
 
@@ -121,6 +130,13 @@ func (r *App) Load(path string, bootOpts ...bootstrap.Option) error {
 		return errors.New("runtime: application metadata missing after load")
 	}
 
+	// Reload the module levels of the loggers whenever the configuration changes
+	if c := res.Decoder(); c != nil {
+		if err := log.WatchModuleLevels(c); err != nil {
+			return fmt.Errorf("runtime: watch module levels: %w", err)
+		}
+	}
+
 	// Auto warm-up the engine if business configuration is available
 	if r.Config() != nil {
 		if err := r.WarmUp(); err != nil {
@@ -136,7 +152,14 @@ func (r *App) WarmUp() error {
 	if r.result == nil || r.result.Config() == nil {
 		return errors.New("runtime: cannot warm-up without loaded configuration")
 	}
-	return r.engine.Load(r.ctx, r.result.Config())
+	if err := r.engine.Load(r.ctx, r.result.Config()); err != nil {
+		return err
+	}
+	// Install the tracer provider before the tracing middlewares are created
+	if _, err := r.Tracer(); err != nil {
+		return fmt.Errorf("runtime: create tracer: %w", err)
+	}
+	return nil
 }
 
 // Getters
@@ -156,6 +179,20 @@ func (r *App) In(cat Category, opts ...InOption) component.Registry {
 	return r.engine.In(cat, opts...)
 }
 
+// Tracer returns the tracer provider of the trace configuration, installed as the
+// global one, or nil without trace configuration.
+func (r *App) Tracer() (*trace.Provider, error) {
+	it := r.engine.In(CategoryTracer).Iter(r.ctx)
+	for it.Next() {
+		if _, inst := it.Value(); inst != nil {
+			if tracer, ok := inst.(*trace.Provider); ok {
+				return tracer, nil
+			}
+		}
+	}
+	return nil, it.Err()
+}
+
 // Context returns the app context.
 func (r *App) Context() context.Context { return r.ctx }
 
@@ -170,6 +207,12 @@ func NewTrace(ctx context.Context, traceID string) context.Context {
 }
 
 func (r *App) Stop() {
+	// Flush the spans left, the tracer stops at most once
+	if tracer, _ := r.Tracer(); tracer != nil {
+		_ = tracer.Shutdown(context.Background())
+	}
+	// Deliver the entries queued by the hooks of the logger, it closes at most once
+	_ = log.Close(r.Logger())
 	if r.cancel != nil {
 		r.cancel()
 	}
@@ -196,6 +239,15 @@ func (r *App) NewApp(servers []transport.Server, options ...kratos.Option) *krat
 	if registrar, _ := r.DefaultRegistrar(); registrar != nil {
 		opts = append(opts, kratos.Registrar(registrar))
 	}
+	// Flush the spans left when the application stops
+	if tracer, _ := r.Tracer(); tracer != nil {
+		opts = append(opts, kratos.AfterStop(tracer.Shutdown))
+	}
+	// Deliver the entries queued by the hooks of the logger when the application stops
+	logger := r.Logger()
+	opts = append(opts, kratos.AfterStop(func(context.Context) error {
+		return log.Close(logger)
+	}))
 	opts = append(opts, options...)
 	return kratos.New(opts...)
 }
@@ -0,0 +1,235 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package authz implements the authorizer of the runtime: the permissions of the roles
+// of the principals on the resources, and the rules conditioned on the attributes of the
+// principal, the resource and the environment, loaded from the configuration or a
+// database table. Its decisions explain which role or rule made them, for audit.
+package authz
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/contracts/storage"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// DefaultPrefix is the prefix of the keys of the decisions in the cache.
+const DefaultPrefix = "authz:"
+
+// DefaultCacheTTL is the time the decisions are cached.
+const DefaultCacheTTL = time.Minute
+
+// Principal is the subject of a request, with its roles and attributes.
+type Principal struct {
+	ID         string         `json:"id"`
+	Roles      []string       `json:"roles,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// Resource is the object of a request, with its attributes.
+type Resource struct {
+	Name       string         `json:"name"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+// Request asks whether a principal may perform an action on a resource, in an
+// environment such as the time or the address of the request.
+type Request struct {
+	Principal   Principal      `json:"principal"`
+	Resource    Resource       `json:"resource"`
+	Action      string         `json:"action"`
+	Environment map[string]any `json:"environment,omitempty"`
+}
+
+// activation returns the variables of the conditions of the rules: the attributes of the
+// principal with its id and roles, those of the resource with its name, the action and
+// the environment.
+func (r *Request) activation() map[string]any {
+	principal := make(map[string]any, len(r.Principal.Attributes)+2)
+	for k, v := range r.Principal.Attributes {
+		principal[k] = v
+	}
+	principal["id"] = r.Principal.ID
+	principal["roles"] = r.Principal.Roles
+	if r.Principal.Roles == nil {
+		principal["roles"] = []string{}
+	}
+	resource := make(map[string]any, len(r.Resource.Attributes)+1)
+	for k, v := range r.Resource.Attributes {
+		resource[k] = v
+	}
+	resource["name"] = r.Resource.Name
+	env := r.Environment
+	if env == nil {
+		env = map[string]any{}
+	}
+	return map[string]any{"principal": principal, "resource": resource, "action": r.Action, "env": env}
+}
+
+// Decision is the answer to a Request, explaining which role or rule made it.
+type Decision struct {
+	Allowed bool `json:"allowed"`
+	// Policy is the role or rule that made the decision, empty when none applied.
+	Policy string `json:"policy,omitempty"`
+	// Reason explains the decision, e.g. role "editor" grants orders/*:write.
+	Reason string `json:"reason"`
+	// Version identifies the policy the decision was made with.
+	Version string `json:"version"`
+	// Cached reports whether the decision was read from the cache.
+	Cached bool `json:"-"`
+}
+
+// String returns the explanation of d.
+func (d *Decision) String() string {
+	effect := Deny
+	if d.Allowed {
+		effect = Allow
+	}
+	return fmt.Sprintf("%s: %s (policy version %s)", effect, d.Reason, d.Version)
+}
+
+// AuditFunc receives the decisions of an Authorizer.
+type AuditFunc func(ctx context.Context, req *Request, decision *Decision)
+
+type authzOptions struct {
+	cache  storage.Cache
+	ttl    time.Duration
+	prefix string
+	audit  AuditFunc
+}
+
+// WithCache sets the cache of the decisions, shared by the instances of the application.
+// Its keys are prefixed by DefaultPrefix unless WithPrefix is given.
+func WithCache(cache storage.Cache) options.Option {
+	return optionutil.Update(func(o *authzOptions) {
+		o.cache = cache
+	})
+}
+
+// WithCacheTTL sets the time the decisions are cached, DefaultCacheTTL by default.
+func WithCacheTTL(ttl time.Duration) options.Option {
+	return optionutil.Update(func(o *authzOptions) {
+		o.ttl = ttl
+	})
+}
+
+// WithPrefix sets the prefix of the keys of the decisions in the cache.
+func WithPrefix(prefix string) options.Option {
+	return optionutil.Update(func(o *authzOptions) {
+		o.prefix = prefix
+	})
+}
+
+// WithAudit sets the function receiving every decision, cached ones included.
+func WithAudit(audit AuditFunc) options.Option {
+	return optionutil.Update(func(o *authzOptions) {
+		o.audit = audit
+	})
+}
+
+// Authorizer decides on the requests with the policy of its Loader, until it is
+// reloaded. It is safe for concurrent use.
+type Authorizer struct {
+	loader Loader
+	opts   *authzOptions
+	policy atomic.Pointer[compiledPolicy]
+}
+
+// New creates an Authorizer with the policy loaded by loader.
+func New(ctx context.Context, loader Loader, opts ...options.Option) (*Authorizer, error) {
+	o := optionutil.NewT[authzOptions](opts...)
+	if o.ttl == 0 {
+		o.ttl = DefaultCacheTTL
+	}
+	if o.prefix == "" {
+		o.prefix = DefaultPrefix
+	}
+	a := &Authorizer{loader: loader, opts: o}
+	if err := a.Reload(ctx); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// Reload loads the policy again, the requests are decided with it once it is valid. The
+// decisions of the previous policy are no longer read from the cache.
+func (a *Authorizer) Reload(ctx context.Context) error {
+	policy, err := a.loader.Load(ctx)
+	if err != nil {
+		return fmt.Errorf("authz: load policy: %w", err)
+	}
+	compiled, err := compile(policy)
+	if err != nil {
+		return err
+	}
+	a.policy.Store(compiled)
+	return nil
+}
+
+// Version returns the version of the policy, a hash of its roles and rules.
+func (a *Authorizer) Version() string {
+	return a.policy.Load().version
+}
+
+// Authorize decides whether req is allowed. The decisions are cached when a cache is
+// set; the failures of the cache are ignored, the decision is then made again.
+func (a *Authorizer) Authorize(ctx context.Context, req *Request) *Decision {
+	policy := a.policy.Load()
+	key := a.cacheKey(policy, req)
+	decision := a.cached(ctx, key)
+	if decision == nil {
+		decision = policy.decide(req)
+		if key != "" {
+			if data, err := json.Marshal(decision); err == nil {
+				_ = a.opts.cache.Set(ctx, key, string(data), a.opts.ttl)
+			}
+		}
+	}
+	if a.opts.audit != nil {
+		a.opts.audit(ctx, req, decision)
+	}
+	return decision
+}
+
+// cacheKey returns the key of the decision of policy on req, empty when the decisions
+// are not cached.
+func (a *Authorizer) cacheKey(policy *compiledPolicy, req *Request) string {
+	if a.opts.cache == nil || a.opts.ttl < 0 {
+		return ""
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(data)
+	return a.opts.prefix + policy.version + ":" + hex.EncodeToString(sum[:])
+}
+
+// cached returns the decision cached with key, nil when there is none.
+func (a *Authorizer) cached(ctx context.Context, key string) *Decision {
+	if key == "" {
+		return nil
+	}
+	if ok, err := a.opts.cache.Exists(ctx, key); err != nil || !ok {
+		return nil
+	}
+	data, err := a.opts.cache.Get(ctx, key)
+	if err != nil {
+		return nil
+	}
+	var decision Decision
+	if err := json.Unmarshal([]byte(data), &decision); err != nil {
+		return nil
+	}
+	decision.Cached = true
+	return &decision
+}
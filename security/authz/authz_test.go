@@ -0,0 +1,250 @@
+package authz
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	cachev1 "github.com/origadmin/runtime/api/gen/go/config/data/cache/v1"
+	authzv1 "github.com/origadmin/runtime/api/gen/go/config/security/authz/v1"
+	"github.com/origadmin/runtime/data/storage/cache/memory"
+)
+
+var testConfig = &authzv1.Authz{
+	Roles: []*authzv1.Authz_Role{
+		{Name: "viewer", Permissions: []string{"orders/*:read", "reports:read"}},
+		{Name: "editor", Permissions: []string{"orders/*:write"}, Inherits: []string{"viewer"}},
+		{Name: "admin", Permissions: []string{"*:*"}},
+	},
+	Rules: []*authzv1.Authz_Rule{
+		{
+			Name:      "owner-edits",
+			Effect:    "allow",
+			Resources: []string{"orders/*"},
+			Actions:   []string{"write"},
+			Condition: `resource.owner == principal.id`,
+		},
+		{
+			Name:      "frozen-orders",
+			Effect:    "deny",
+			Resources: []string{"orders/*"},
+			Actions:   []string{"write", "delete"},
+			Condition: `has(resource.frozen) && resource.frozen`,
+		},
+		{
+			Name:      "office-hours",
+			Effect:    "deny",
+			Roles:     []string{"viewer"},
+			Resources: []string{"reports"},
+			Condition: `env.hour < 8 || env.hour >= 18`,
+		},
+	},
+}
+
+type fakeDatabase struct {
+	db *sql.DB
+}
+
+func (d *fakeDatabase) Name() string    { return "fake" }
+func (d *fakeDatabase) Dialect() string { return "sqlmock" }
+func (d *fakeDatabase) DB() *sql.DB     { return d.db }
+func (d *fakeDatabase) Close() error    { return d.db.Close() }
+
+func newAuthorizer(t *testing.T) *Authorizer {
+	t.Helper()
+	a, err := NewFromConfig(context.Background(), testConfig, nil)
+	require.NoError(t, err)
+	return a
+}
+
+func TestAuthorize(t *testing.T) {
+	a := newAuthorizer(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		req     *Request
+		allowed bool
+		policy  string
+		reason  string
+	}{
+		{
+			name:    "role permission",
+			req:     &Request{Principal: Principal{ID: "u1", Roles: []string{"viewer"}}, Resource: Resource{Name: "orders/42"}, Action: "read"},
+			allowed: true,
+			policy:  "viewer",
+			reason:  `role "viewer" grants orders/*:read`,
+		},
+		{
+			name:    "inherited permission",
+			req:     &Request{Principal: Principal{ID: "u1", Roles: []string{"editor"}}, Resource: Resource{Name: "orders/42"}, Action: "read"},
+			allowed: true,
+			policy:  "editor",
+			reason:  `role "editor" grants orders/*:read inherited from "viewer"`,
+		},
+		{
+			name:    "wildcard permission",
+			req:     &Request{Principal: Principal{ID: "root", Roles: []string{"admin"}}, Resource: Resource{Name: "users/7"}, Action: "delete"},
+			allowed: true,
+			policy:  "admin",
+		},
+		{
+			name:    "no permission",
+			req:     &Request{Principal: Principal{ID: "u1", Roles: []string{"viewer"}}, Resource: Resource{Name: "orders/42"}, Action: "write"},
+			allowed: false,
+			reason:  `no role or rule allows write on "orders/42"`,
+		},
+		{
+			name: "attribute rule",
+			req: &Request{
+				Principal: Principal{ID: "u1", Roles: []string{"viewer"}},
+				Resource:  Resource{Name: "orders/42", Attributes: map[string]any{"owner": "u1"}},
+				Action:    "write",
+			},
+			allowed: true,
+			policy:  "owner-edits",
+			reason:  `rule "owner-edits" allows write on "orders/42"`,
+		},
+		{
+			name: "deny overrides",
+			req: &Request{
+				Principal: Principal{ID: "root", Roles: []string{"admin"}},
+				Resource:  Resource{Name: "orders/42", Attributes: map[string]any{"frozen": true}},
+				Action:    "delete",
+			},
+			allowed: false,
+			policy:  "frozen-orders",
+			reason:  `rule "frozen-orders" denies delete on "orders/42"`,
+		},
+		{
+			name: "environment",
+			req: &Request{
+				Principal:   Principal{ID: "u1", Roles: []string{"viewer"}},
+				Resource:    Resource{Name: "reports"},
+				Action:      "read",
+				Environment: map[string]any{"hour": 22},
+			},
+			allowed: false,
+			policy:  "office-hours",
+		},
+		{
+			name: "failed condition denies",
+			req: &Request{
+				Principal: Principal{ID: "u1", Roles: []string{"viewer"}},
+				Resource:  Resource{Name: "reports"},
+				Action:    "read",
+			},
+			allowed: false,
+			policy:  "office-hours",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := a.Authorize(ctx, tt.req)
+			assert.Equal(t, tt.allowed, d.Allowed, d.String())
+			assert.Equal(t, tt.policy, d.Policy)
+			if tt.reason != "" {
+				assert.Equal(t, tt.reason, d.Reason)
+			}
+			assert.Equal(t, a.Version(), d.Version)
+		})
+	}
+}
+
+func TestCompileErrors(t *testing.T) {
+	for name, policy := range map[string]*Policy{
+		"permission":     {Roles: []Role{{Name: "r", Permissions: []string{"orders"}}}},
+		"unknown parent": {Roles: []Role{{Name: "r", Inherits: []string{"missing"}}}},
+		"duplicate role": {Roles: []Role{{Name: "r"}, {Name: "r"}}},
+		"effect":         {Rules: []Rule{{Name: "x", Effect: "maybe"}}},
+		"syntax":         {Rules: []Rule{{Name: "x", Effect: Allow, Condition: "principal.id =="}}},
+		"not bool":       {Rules: []Rule{{Name: "x", Effect: Allow, Condition: `action + "!"`}}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := New(context.Background(), StaticLoader(policy))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	assert.True(t, match("*", ""))
+	assert.True(t, match("orders/*", "orders/42"))
+	assert.True(t, match("orders/*/items", "orders/42/items"))
+	assert.True(t, match("*:read", "a:b:read"))
+	assert.False(t, match("orders/*", "order/42"))
+	assert.False(t, match("orders", "orders/42"))
+	assert.False(t, match("orders/*/items", "orders/42/item"))
+}
+
+func TestReloadAndCache(t *testing.T) {
+	ctx := context.Background()
+	cache, err := memory.New(&cachev1.CacheConfig{Driver: memory.DriverName})
+	require.NoError(t, err)
+
+	policy := &Policy{Roles: []Role{{Name: "viewer", Permissions: []string{"orders:read"}}}}
+	var audited []*Decision
+	a, err := New(ctx, LoaderFunc(func(context.Context) (*Policy, error) { return policy, nil }),
+		WithCache(cache),
+		WithAudit(func(_ context.Context, _ *Request, d *Decision) { audited = append(audited, d) }))
+	require.NoError(t, err)
+
+	req := &Request{Principal: Principal{ID: "u1", Roles: []string{"viewer"}}, Resource: Resource{Name: "orders"}, Action: "read"}
+	first := a.Authorize(ctx, req)
+	assert.True(t, first.Allowed)
+	assert.False(t, first.Cached)
+	second := a.Authorize(ctx, req)
+	assert.True(t, second.Allowed)
+	assert.True(t, second.Cached)
+	assert.Equal(t, first.Reason, second.Reason)
+	assert.Len(t, audited, 2)
+
+	version := a.Version()
+	policy = &Policy{Roles: []Role{{Name: "viewer"}}}
+	require.NoError(t, a.Reload(ctx))
+	assert.NotEqual(t, version, a.Version())
+	third := a.Authorize(ctx, req)
+	assert.False(t, third.Allowed)
+	assert.False(t, third.Cached)
+
+	// An invalid policy keeps the current one.
+	policy = &Policy{Roles: []Role{{Name: "viewer", Inherits: []string{"missing"}}}}
+	assert.Error(t, a.Reload(ctx))
+	assert.False(t, a.Authorize(ctx, req).Allowed)
+}
+
+func TestDatabaseLoader(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	columns := []string{"kind", "name", "effect", "roles", "permissions", "resources", "actions", "expression"}
+	mock.ExpectQuery("SELECT kind, name, effect, roles, permissions, resources, actions, expression FROM authz_policies ORDER BY kind, name").
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow("role", "editor", nil, "viewer", "orders/*:write", nil, nil, nil).
+			AddRow("role", "viewer", nil, nil, "orders/*:read, reports:read", nil, nil, nil).
+			AddRow("role", "viewer", nil, nil, "invoices:read", nil, nil, nil).
+			AddRow("rule", "owner-deletes", "allow", nil, nil, "orders/*", "delete", "resource.owner == principal.id"))
+
+	a, err := NewFromConfig(context.Background(), &authzv1.Authz{Table: "authz_policies"}, &fakeDatabase{db: db})
+	require.NoError(t, err)
+	require.NoError(t, mock.ExpectationsWereMet())
+
+	ctx := context.Background()
+	editor := Principal{ID: "u1", Roles: []string{"editor"}}
+	assert.True(t, a.Authorize(ctx, &Request{Principal: editor, Resource: Resource{Name: "invoices"}, Action: "read"}).Allowed)
+	assert.True(t, a.Authorize(ctx, &Request{Principal: editor, Resource: Resource{Name: "orders/1"}, Action: "write"}).Allowed)
+	assert.True(t, a.Authorize(ctx, &Request{
+		Principal: editor,
+		Resource:  Resource{Name: "orders/1", Attributes: map[string]any{"owner": "u1"}},
+		Action:    "delete",
+	}).Allowed)
+	assert.False(t, a.Authorize(ctx, &Request{Principal: editor, Resource: Resource{Name: "orders/1"}, Action: "delete"}).Allowed)
+
+	_, err = NewDatabaseLoader(&fakeDatabase{db: db}, "policies; DROP TABLE users")
+	assert.Error(t, err)
+}
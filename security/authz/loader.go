@@ -0,0 +1,151 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package authz
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	authzv1 "github.com/origadmin/runtime/api/gen/go/config/security/authz/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/contracts/storage"
+)
+
+// Loader loads the policy of an Authorizer, when it is created and reloaded.
+type Loader interface {
+	Load(ctx context.Context) (*Policy, error)
+}
+
+// LoaderFunc is a function implementing Loader.
+type LoaderFunc func(ctx context.Context) (*Policy, error)
+
+// Load calls f.
+func (f LoaderFunc) Load(ctx context.Context) (*Policy, error) {
+	return f(ctx)
+}
+
+// StaticLoader returns a Loader of policy.
+func StaticLoader(policy *Policy) Loader {
+	return LoaderFunc(func(context.Context) (*Policy, error) {
+		return policy, nil
+	})
+}
+
+// DatabaseLoader loads the policy from a table with the columns:
+//
+//	kind        role or rule
+//	name        the name of the role or rule
+//	effect      the effect of a rule, allow or deny
+//	roles       the roles a role inherits, or the roles of a rule
+//	permissions the permissions of a role
+//	resources   the resources of a rule
+//	actions     the actions of a rule
+//	expression  the condition of a rule
+//
+// The list columns are separated by commas, and may be NULL like the others but kind
+// and name. The rows of a role with the same name are merged.
+type DatabaseLoader struct {
+	db    storage.Database
+	table string
+}
+
+// tablePattern matches the table names, possibly qualified by a schema.
+var tablePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// NewDatabaseLoader creates a DatabaseLoader of table in db.
+func NewDatabaseLoader(db storage.Database, table string) (*DatabaseLoader, error) {
+	if db == nil {
+		return nil, errors.New("authz: database loader without database")
+	}
+	if !tablePattern.MatchString(table) {
+		return nil, fmt.Errorf("authz: invalid table name %q", table)
+	}
+	return &DatabaseLoader{db: db, table: table}, nil
+}
+
+// Load implements Loader.
+func (l *DatabaseLoader) Load(ctx context.Context) (*Policy, error) {
+	rows, err := l.db.DB().QueryContext(ctx,
+		"SELECT kind, name, effect, roles, permissions, resources, actions, expression FROM "+l.table+" ORDER BY kind, name")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	policy := &Policy{}
+	roles := make(map[string]int)
+	for rows.Next() {
+		var (
+			kind, name                                                   string
+			effect, inherits, permissions, resources, actions, condition sql.NullString
+		)
+		if err := rows.Scan(&kind, &name, &effect, &inherits, &permissions, &resources, &actions, &condition); err != nil {
+			return nil, err
+		}
+		switch strings.ToLower(kind) {
+		case "role":
+			i, ok := roles[name]
+			if !ok {
+				i = len(policy.Roles)
+				roles[name] = i
+				policy.Roles = append(policy.Roles, Role{Name: name})
+			}
+			role := &policy.Roles[i]
+			role.Permissions = append(role.Permissions, splitList(permissions)...)
+			role.Inherits = append(role.Inherits, splitList(inherits)...)
+		case "rule":
+			policy.Rules = append(policy.Rules, Rule{
+				Name:      name,
+				Effect:    Effect(strings.ToLower(effect.String)),
+				Roles:     splitList(inherits),
+				Resources: splitList(resources),
+				Actions:   splitList(actions),
+				Condition: condition.String,
+			})
+		default:
+			return nil, fmt.Errorf("authz: %s: unknown kind %q of %q", l.table, kind, name)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// splitList returns the items of a comma separated list column.
+func splitList(s sql.NullString) []string {
+	var items []string
+	for _, item := range strings.Split(s.String, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// NewFromConfig creates the Authorizer configured by cfg: its policy is loaded from the
+// table of cfg in db, or from cfg itself without a table.
+func NewFromConfig(ctx context.Context, cfg *authzv1.Authz, db storage.Database, opts ...options.Option) (*Authorizer, error) {
+	var loader Loader = StaticLoader(PolicyFromConfig(cfg))
+	if table := cfg.GetTable(); table != "" {
+		dbLoader, err := NewDatabaseLoader(db, table)
+		if err != nil {
+			return nil, err
+		}
+		loader = dbLoader
+	}
+	switch ttl := cfg.GetCacheTtl(); {
+	case ttl > 0:
+		opts = append([]options.Option{WithCacheTTL(time.Duration(ttl) * time.Millisecond)}, opts...)
+	case ttl < 0:
+		opts = append([]options.Option{WithCacheTTL(-1)}, opts...)
+	}
+	return New(ctx, loader, opts...)
+}
@@ -0,0 +1,305 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package authz
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/google/cel-go/cel"
+
+	authzv1 "github.com/origadmin/runtime/api/gen/go/config/security/authz/v1"
+)
+
+// Effect is the effect of a rule.
+type Effect string
+
+// The effects of the rules.
+const (
+	Allow Effect = "allow"
+	Deny  Effect = "deny"
+)
+
+// Role grants permissions to the principals having it. A permission is written
+// resource:action, where * matches any characters, e.g. orders/*:read.
+type Role struct {
+	Name        string   `json:"name"`
+	Permissions []string `json:"permissions,omitempty"`
+	Inherits    []string `json:"inherits,omitempty"`
+}
+
+// Rule allows or denies the actions on the resources it matches to the principals having
+// one of its roles, any principal without roles, when its condition holds. The condition
+// is a CEL expression over principal, resource, action and env, e.g.
+// principal.department == resource.department && env.hour < 18.
+type Rule struct {
+	Name      string   `json:"name"`
+	Effect    Effect   `json:"effect"`
+	Roles     []string `json:"roles,omitempty"`
+	Resources []string `json:"resources,omitempty"`
+	Actions   []string `json:"actions,omitempty"`
+	Condition string   `json:"condition,omitempty"`
+}
+
+// Policy is the set of the roles and rules of an Authorizer.
+type Policy struct {
+	Roles []Role `json:"roles,omitempty"`
+	Rules []Rule `json:"rules,omitempty"`
+}
+
+// PolicyFromConfig returns the Policy of the roles and rules of cfg.
+func PolicyFromConfig(cfg *authzv1.Authz) *Policy {
+	policy := &Policy{}
+	for _, r := range cfg.GetRoles() {
+		policy.Roles = append(policy.Roles, Role{
+			Name:        r.GetName(),
+			Permissions: r.GetPermissions(),
+			Inherits:    r.GetInherits(),
+		})
+	}
+	for _, r := range cfg.GetRules() {
+		policy.Rules = append(policy.Rules, Rule{
+			Name:      r.GetName(),
+			Effect:    Effect(strings.ToLower(r.GetEffect())),
+			Roles:     r.GetRoles(),
+			Resources: r.GetResources(),
+			Actions:   r.GetActions(),
+			Condition: r.GetCondition(),
+		})
+	}
+	return policy
+}
+
+// permission is a permission of a role, its resource and action patterns.
+type permission struct {
+	role     string
+	resource string
+	action   string
+}
+
+// compiledRule is a rule with its condition compiled, nil without one.
+type compiledRule struct {
+	Rule
+	program cel.Program
+}
+
+// compiledPolicy is a Policy ready to decide.
+type compiledPolicy struct {
+	version string
+	// permissions holds the permissions of each role, inherited ones included.
+	permissions map[string][]permission
+	deny, allow []*compiledRule
+}
+
+// conditionEnv declares the variables of the conditions of the rules.
+var conditionEnv, conditionEnvErr = cel.NewEnv(
+	cel.Variable("principal", cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)),
+	cel.Variable("action", cel.StringType),
+	cel.Variable("env", cel.MapType(cel.StringType, cel.DynType)),
+)
+
+// compile validates policy and compiles the conditions of its rules.
+func compile(policy *Policy) (*compiledPolicy, error) {
+	if conditionEnvErr != nil {
+		return nil, fmt.Errorf("authz: create condition environment: %w", conditionEnvErr)
+	}
+	if policy == nil {
+		policy = &Policy{}
+	}
+	data, err := json.Marshal(policy)
+	if err != nil {
+		return nil, fmt.Errorf("authz: encode policy: %w", err)
+	}
+	sum := sha256.Sum256(data)
+	p := &compiledPolicy{
+		version:     hex.EncodeToString(sum[:8]),
+		permissions: make(map[string][]permission),
+	}
+
+	roles := make(map[string]*Role, len(policy.Roles))
+	for i := range policy.Roles {
+		role := &policy.Roles[i]
+		if role.Name == "" {
+			return nil, fmt.Errorf("authz: role %d has no name", i)
+		}
+		if _, ok := roles[role.Name]; ok {
+			return nil, fmt.Errorf("authz: role %q declared twice", role.Name)
+		}
+		roles[role.Name] = role
+	}
+	for name := range roles {
+		seen := make(map[string]bool)
+		var visit func(role *Role) error
+		visit = func(role *Role) error {
+			if seen[role.Name] {
+				return nil
+			}
+			seen[role.Name] = true
+			for _, perm := range role.Permissions {
+				i := strings.LastIndexByte(perm, ':')
+				if i <= 0 || i == len(perm)-1 {
+					return fmt.Errorf("authz: role %q: permission %q is not resource:action", role.Name, perm)
+				}
+				p.permissions[name] = append(p.permissions[name], permission{role: role.Name, resource: perm[:i], action: perm[i+1:]})
+			}
+			for _, parent := range role.Inherits {
+				inherited, ok := roles[parent]
+				if !ok {
+					return fmt.Errorf("authz: role %q inherits unknown role %q", role.Name, parent)
+				}
+				if err := visit(inherited); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		if err := visit(roles[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, rule := range policy.Rules {
+		if rule.Name == "" {
+			return nil, fmt.Errorf("authz: rule %d has no name", i)
+		}
+		r := &compiledRule{Rule: rule}
+		if rule.Condition != "" {
+			ast, issues := conditionEnv.Compile(rule.Condition)
+			if issues != nil && issues.Err() != nil {
+				return nil, fmt.Errorf("authz: rule %q: %w", rule.Name, issues.Err())
+			}
+			if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+				return nil, fmt.Errorf("authz: rule %q: condition is %s, not bool", rule.Name, ast.OutputType())
+			}
+			if r.program, err = conditionEnv.Program(ast); err != nil {
+				return nil, fmt.Errorf("authz: rule %q: %w", rule.Name, err)
+			}
+		}
+		switch rule.Effect {
+		case Allow:
+			p.allow = append(p.allow, r)
+		case Deny:
+			p.deny = append(p.deny, r)
+		default:
+			return nil, fmt.Errorf("authz: rule %q: unknown effect %q", rule.Name, rule.Effect)
+		}
+	}
+	return p, nil
+}
+
+// decide returns the decision of p on req: a denying rule overrides every grant, then
+// the permissions of the roles of the principal and the allowing rules grant the
+// request. A condition failing to evaluate applies its denying rule, not its allowing
+// one.
+func (p *compiledPolicy) decide(req *Request) *Decision {
+	vars := req.activation()
+	for _, r := range p.deny {
+		applies, err := r.applies(req, vars)
+		if err != nil {
+			return p.decision(false, r.Name, "rule %q denies %s on %q, its condition failed: %v", r.Name, req.Action, req.Resource.Name, err)
+		}
+		if applies {
+			return p.decision(false, r.Name, "rule %q denies %s on %q", r.Name, req.Action, req.Resource.Name)
+		}
+	}
+	for _, role := range req.Principal.Roles {
+		for _, perm := range p.permissions[role] {
+			if match(perm.resource, req.Resource.Name) && match(perm.action, req.Action) {
+				via := ""
+				if perm.role != role {
+					via = fmt.Sprintf(" inherited from %q", perm.role)
+				}
+				return p.decision(true, role, "role %q grants %s:%s%s", role, perm.resource, perm.action, via)
+			}
+		}
+	}
+	for _, r := range p.allow {
+		if applies, err := r.applies(req, vars); err == nil && applies {
+			return p.decision(true, r.Name, "rule %q allows %s on %q", r.Name, req.Action, req.Resource.Name)
+		}
+	}
+	return p.decision(false, "", "no role or rule allows %s on %q", req.Action, req.Resource.Name)
+}
+
+// decision returns a decision of p.
+func (p *compiledPolicy) decision(allowed bool, policy, format string, args ...any) *Decision {
+	return &Decision{Allowed: allowed, Policy: policy, Reason: fmt.Sprintf(format, args...), Version: p.version}
+}
+
+// applies reports whether r applies to req, whose condition variables are vars.
+func (r *compiledRule) applies(req *Request, vars map[string]any) (bool, error) {
+	if len(r.Roles) > 0 && !hasAny(req.Principal.Roles, r.Roles) {
+		return false, nil
+	}
+	if !matchAny(r.Resources, req.Resource.Name) || !matchAny(r.Actions, req.Action) {
+		return false, nil
+	}
+	if r.program == nil {
+		return true, nil
+	}
+	out, _, err := r.program.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition is %s, not bool", out.Type().TypeName())
+	}
+	return result, nil
+}
+
+// hasAny reports whether roles has one of wanted.
+func hasAny(roles, wanted []string) bool {
+	for _, role := range roles {
+		for _, w := range wanted {
+			if role == w {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAny reports whether s matches one of patterns, or patterns is empty.
+func matchAny(patterns []string, s string) bool {
+	if len(patterns) == 0 {
+		return true
+	}
+	for _, pattern := range patterns {
+		if match(pattern, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// match reports whether s matches pattern, where * matches any characters.
+func match(pattern, s string) bool {
+	// Backtrack to the last star when a literal part fails to match.
+	var p, i, star, next = 0, 0, -1, 0
+	for i < len(s) {
+		switch {
+		case p < len(pattern) && pattern[p] == '*':
+			star, next = p, i
+			p++
+		case p < len(pattern) && pattern[p] == s[i]:
+			p++
+			i++
+		case star >= 0:
+			next++
+			p, i = star+1, next
+		default:
+			return false
+		}
+	}
+	for p < len(pattern) && pattern[p] == '*' {
+		p++
+	}
+	return p == len(pattern)
+}
@@ -7,7 +7,7 @@ package security
 import "sync"
 
 // Policy holds all information for a single resource's policy.
-// This struct is created by generated code and registered via init().
+// This struct is created by the code generated by protoc-gen-go-security and registered via init().
 type Policy struct {
 	ServiceMethod string // gRPC full method name, e.g., "/user.v1.UserService/GetUser"
 	GatewayPath   string // HTTP path and method, e.g., "GET:/api/v1/users/{id}"
@@ -23,6 +23,10 @@ var (
 	unifiedPolicies []Policy
 	mu              sync.RWMutex
 
+	// policyByMethod indexes the registered policies by service method, the last
+	// registration of a method winning.
+	policyByMethod = make(map[string]Policy)
+
 	// policyNameCache stores pre-filtered service methods by policy name for quick lookup.
 	// This cache is built once after all init() functions have run.
 	policyNameCache     map[string]map[string]struct{}
@@ -47,6 +51,18 @@ func RegisterPolicies(policies []Policy) {
 	mu.Lock()
 	defer mu.Unlock()
 	unifiedPolicies = append(unifiedPolicies, policies...)
+	for _, p := range policies {
+		policyByMethod[p.ServiceMethod] = p
+	}
+}
+
+// PolicyOf returns the policy registered for the given service method, such as the
+// operation of a request, and whether one is.
+func PolicyOf(serviceMethod string) (Policy, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	p, ok := policyByMethod[serviceMethod]
+	return p, ok
 }
 
 // RegisteredPolicies returns a copy of all policy registrations.
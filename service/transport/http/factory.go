@@ -7,6 +7,9 @@ import (
 	transportv1 "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
 	"github.com/origadmin/runtime/contracts"
 	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/log"
+	"github.com/origadmin/runtime/middleware"
+	"github.com/origadmin/runtime/middleware/metrics"
 	"github.com/origadmin/runtime/service"
 )
 
@@ -40,6 +43,28 @@ func (f *httpProtocolFactory) NewServer(cfg *transportv1.Server, opts ...options
 		RegisterPprof(srv)
 	}
 
+	// Register the query endpoint of the recent log entries if enabled and the logger keeps them
+	if buffer := log.Buffer(); buffer != nil && httpConfig.GetEnableLogBuffer() {
+		RegisterLogBuffer(srv, buffer)
+	}
+
+	// Register the admin endpoint of the module log levels if enabled
+	if httpConfig.GetEnableLogLevels() {
+		RegisterLogLevels(srv, log.ModuleLevels())
+	}
+
+	// Register the exposition endpoint of the metrics if enabled, those of the provider of
+	// the metrics middlewares, the default one unless set by middleware.WithMetricsProvider
+	if httpConfig.GetEnableMetrics() {
+		provider := middleware.FromOptions(opts...).Metrics
+		if provider == nil {
+			if provider, err = metrics.DefaultProvider(); err != nil {
+				return nil, err
+			}
+		}
+		RegisterMetrics(srv, provider.Handler())
+	}
+
 	if serverOpts.Registrar != nil {
 		if err := serverOpts.Registrar.RegisterHTTP(serverOpts.Context, srv); err != nil {
 			return nil, err
@@ -0,0 +1,62 @@
+package http_test
+
+import (
+	nethttp "net/http"
+	"net/http/httptest"
+	"testing"
+
+	transhttp "github.com/go-kratos/kratos/v2/transport/http"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	httpv1 "github.com/origadmin/runtime/api/gen/go/config/transport/http/v1"
+	transportv1 "github.com/origadmin/runtime/api/gen/go/config/transport/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/log"
+	"github.com/origadmin/runtime/middleware"
+	"github.com/origadmin/runtime/middleware/metrics"
+	"github.com/origadmin/runtime/service"
+	_ "github.com/origadmin/runtime/service/transport/http"
+)
+
+// serve serves a GET request of path by an HTTP server of cfg and opts.
+func serve(t *testing.T, cfg *httpv1.Server, path string, opts ...options.Option) *httptest.ResponseRecorder {
+	t.Helper()
+	srv, err := service.NewServer(&transportv1.Server{Protocol: service.ProtocolHTTP, Http: cfg}, opts...)
+	require.NoError(t, err)
+	rec := httptest.NewRecorder()
+	srv.(*transhttp.Server).ServeHTTP(rec, httptest.NewRequest(nethttp.MethodGet, path, nil))
+	return rec
+}
+
+func TestNewServer_LogBuffer(t *testing.T) {
+	defer log.SetBuffer(nil)
+	log.SetBuffer(log.NewRingBuffer(nil, nil))
+
+	// The endpoint of the log buffer is served by the servers enabling it only.
+	assert.Equal(t, nethttp.StatusNotFound, serve(t, &httpv1.Server{}, log.DefaultBufferPath).Code)
+	assert.Equal(t, nethttp.StatusOK, serve(t, &httpv1.Server{EnableLogBuffer: true}, log.DefaultBufferPath).Code)
+}
+
+func TestNewServer_LogLevels(t *testing.T) {
+	// The endpoint of the module levels is served by the servers enabling it only.
+	assert.Equal(t, nethttp.StatusNotFound, serve(t, &httpv1.Server{}, log.DefaultLevelPath).Code)
+	rec := serve(t, &httpv1.Server{EnableLogLevels: true}, log.DefaultLevelPath)
+	assert.Equal(t, nethttp.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+}
+
+func TestNewServer_Metrics(t *testing.T) {
+	assert.Equal(t, nethttp.StatusNotFound, serve(t, &httpv1.Server{}, metrics.DefaultPath).Code)
+	assert.Equal(t, nethttp.StatusOK, serve(t, &httpv1.Server{EnableMetrics: true}, metrics.DefaultPath).Code)
+
+	// The metrics are those of the provider of the middlewares.
+	registry := prometheus.NewRegistry()
+	registry.MustRegister(prometheus.NewCounter(prometheus.CounterOpts{Name: "factory_test_total"}))
+	provider, err := metrics.NewProvider(metrics.WithRegistry(registry))
+	require.NoError(t, err)
+	rec := serve(t, &httpv1.Server{EnableMetrics: true}, metrics.DefaultPath, middleware.WithMetricsProvider(provider))
+	assert.Equal(t, nethttp.StatusOK, rec.Code)
+	assert.Contains(t, rec.Body.String(), "factory_test_total")
+}
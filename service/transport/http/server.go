@@ -1,11 +1,14 @@
 package http
 
 import (
+	"net/http"
 	"net/http/pprof"
 
 	transhttp "github.com/go-kratos/kratos/v2/transport/http"
 
 	httpv1 "github.com/origadmin/runtime/api/gen/go/config/transport/http/v1"
+	"github.com/origadmin/runtime/log"
+	"github.com/origadmin/runtime/middleware/metrics"
 )
 
 // NewServer creates a new concrete HTTP server instance based on the provided configuration.
@@ -35,3 +38,21 @@ func RegisterPprof(srv *transhttp.Server) {
 	srv.HandleFunc("/debug/mutex", pprof.Handler("mutex").ServeHTTP)
 	srv.HandleFunc("/debug/threadcreate", pprof.Handler("threadcreate").ServeHTTP)
 }
+
+// RegisterLogBuffer registers the query endpoint of the in-memory log buffer with the
+// HTTP server, at the path of the buffer.
+func RegisterLogBuffer(srv *transhttp.Server, buffer *log.RingBuffer) {
+	srv.Handle(buffer.Path(), log.BufferHandler(buffer))
+}
+
+// RegisterLogLevels registers the admin endpoint of the module levels of registry with
+// the HTTP server, at log.DefaultLevelPath.
+func RegisterLogLevels(srv *transhttp.Server, registry *log.LevelRegistry) {
+	srv.Handle(log.DefaultLevelPath, log.LevelHandler(registry))
+}
+
+// RegisterMetrics registers the Prometheus exposition handler of the metrics with the
+// HTTP server, at metrics.DefaultPath.
+func RegisterMetrics(srv *transhttp.Server, handler http.Handler) {
+	srv.Handle(metrics.DefaultPath, handler)
+}
@@ -688,3 +688,96 @@ func TestJWTClientServerFlow(t *testing.T) {
 		})
 	})
 }
+
+// TestJWTKeyRotation tests the verification with the secondary signing key and the
+// enforcement of the issuer and audience of the tokens
+func TestJWTKeyRotation(t *testing.T) {
+	jwtConfig := &jwtv1.JWT{
+		Config: &jwtv1.AuthConfig{
+			SigningMethod:       "HS256",
+			SigningKey:          "new-secret-key",
+			KeyId:               "v2",
+			SecondarySigningKey: "old-secret-key",
+			SecondaryKeyId:      "v1",
+			Issuer:              "test-issuer",
+			Audience:            []string{"test-audience"},
+			AccessTokenLifetime: 3600,
+			ClockSkew:           30,
+		},
+		ClaimType: "registered",
+	}
+	serverMW, created := middleware.JwtServer(jwtConfig, nil)
+	require.True(t, created, "Server middleware should be created")
+
+	sign := func(key, kid string, mutate func(c *jwt.RegisteredClaims)) string {
+		claims := &jwt.RegisteredClaims{
+			Subject:   "test-user",
+			Issuer:    "test-issuer",
+			Audience:  []string{"test-audience"},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		}
+		if mutate != nil {
+			mutate(claims)
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+		token.Header["kid"] = kid
+		signed, err := token.SignedString([]byte(key))
+		require.NoError(t, err, "Failed to sign token")
+		return signed
+	}
+	validate := func(token string) error {
+		header := make(headerCarrier)
+		header.Set("Authorization", "Bearer "+token)
+		serverCtx := transport.NewServerContext(context.Background(), &mockTransport{
+			kind:        "http",
+			endpoint:    "test",
+			operation:   "test",
+			reqHeader:   header,
+			replyHeader: make(headerCarrier),
+		})
+		_, err := serverMW(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "validated", nil
+		})(serverCtx, nil)
+		return err
+	}
+
+	assert.NoError(t, validate(sign("new-secret-key", "v2", nil)), "Token of the signing key should be validated")
+	assert.NoError(t, validate(sign("old-secret-key", "v1", nil)), "Token of the secondary key should be validated")
+	assert.Error(t, validate(sign("old-secret-key", "v2", nil)), "Token of another kid should be rejected")
+	assert.Error(t, validate(sign("new-secret-key", "v2", func(c *jwt.RegisteredClaims) {
+		c.Issuer = "other-issuer"
+	})), "Token of another issuer should be rejected")
+	assert.Error(t, validate(sign("new-secret-key", "v2", func(c *jwt.RegisteredClaims) {
+		c.Audience = []string{"other-audience"}
+	})), "Token of another audience should be rejected")
+	assert.NoError(t, validate(sign("new-secret-key", "v2", func(c *jwt.RegisteredClaims) {
+		c.ExpiresAt = jwt.NewNumericDate(time.Now().Add(-10 * time.Second))
+	})), "Token expired within the clock skew should be validated")
+
+	t.Run("ClientKeyID", func(t *testing.T) {
+		clientMW, created := middleware.JwtClient(jwtConfig, &middleware.Options{
+			SubjectFactory: func() string { return "test-user" },
+		})
+		require.True(t, created, "Client middleware should be created")
+
+		header := make(headerCarrier)
+		clientCtx := transport.NewClientContext(context.Background(), &mockTransport{
+			kind:        "http",
+			endpoint:    "test",
+			operation:   "test",
+			reqHeader:   header,
+			replyHeader: make(headerCarrier),
+		})
+		_, err := clientMW(func(ctx context.Context, req interface{}) (interface{}, error) {
+			return nil, nil
+		})(clientCtx, nil)
+		require.NoError(t, err, "Token generation should succeed")
+
+		token := strings.TrimPrefix(header.Get("Authorization"), "Bearer ")
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, &jwt.RegisteredClaims{})
+		require.NoError(t, err, "Generated token should be parsed")
+		assert.Equal(t, "v2", parsed.Header["kid"], "Generated token should have the kid of the signing key")
+		assert.NoError(t, validate(token), "Generated token should be validated")
+	})
+}
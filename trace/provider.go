@@ -0,0 +1,209 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+// Package trace builds the OpenTelemetry tracer provider of the application from its
+// Trace configuration: the exporter of the spans, the sampler and the resource
+// describing the application.
+package trace
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+
+	appv1 "github.com/origadmin/runtime/api/gen/go/config/app/v1"
+	tracev1 "github.com/origadmin/runtime/api/gen/go/config/trace/v1"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/optionutil"
+)
+
+// The exporters of the spans, as named by the configuration.
+const (
+	ExporterOTLP     = "otlp"
+	ExporterOTLPGRPC = "otlp-grpc"
+	ExporterOTLPHTTP = "otlp-http"
+	ExporterStdout   = "stdout"
+	ExporterMemory   = "memory"
+	ExporterJaeger   = "jaeger"
+)
+
+type providerOptions struct {
+	app      *appv1.App
+	exporter sdktrace.SpanExporter
+}
+
+// WithAppInfo sets the application described by the resource of the spans.
+func WithAppInfo(app *appv1.App) options.Option {
+	return optionutil.Update(func(o *providerOptions) {
+		o.app = app
+	})
+}
+
+// WithExporter sets the exporter of the spans, instead of the one named by the
+// configuration.
+func WithExporter(exporter sdktrace.SpanExporter) options.Option {
+	return optionutil.Update(func(o *providerOptions) {
+		o.exporter = exporter
+	})
+}
+
+// Provider is the OpenTelemetry tracer provider built from a Trace configuration.
+type Provider struct {
+	*sdktrace.TracerProvider
+	exporter sdktrace.SpanExporter
+}
+
+// NewProvider creates the Provider configured by cfg. Its spans are batched to the
+// exporter, except those of the in-memory exporter, which are exported as they end.
+func NewProvider(ctx context.Context, cfg *tracev1.Trace, opts ...options.Option) (*Provider, error) {
+	o := optionutil.NewT[providerOptions](opts...)
+	exporter := o.exporter
+	if exporter == nil {
+		var err error
+		if exporter, err = NewExporter(ctx, cfg); err != nil {
+			return nil, err
+		}
+	}
+	res, err := NewResource(cfg, o.app)
+	if err != nil {
+		return nil, err
+	}
+	tpOpts := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(NewSampler(cfg)),
+	}
+	switch exporter.(type) {
+	case nil:
+	case *tracetest.InMemoryExporter:
+		tpOpts = append(tpOpts, sdktrace.WithSyncer(exporter))
+	default:
+		var batchOpts []sdktrace.BatchSpanProcessorOption
+		if timeout := cfg.GetTimeout().AsDuration(); timeout > 0 {
+			batchOpts = append(batchOpts, sdktrace.WithExportTimeout(timeout))
+		}
+		tpOpts = append(tpOpts, sdktrace.WithBatcher(exporter, batchOpts...))
+	}
+	return &Provider{TracerProvider: sdktrace.NewTracerProvider(tpOpts...), exporter: exporter}, nil
+}
+
+// Exporter returns the exporter of the spans of p, nil if they are not exported.
+func (p *Provider) Exporter() sdktrace.SpanExporter {
+	return p.exporter
+}
+
+// Install makes p the global tracer provider, and the W3C trace context and baggage the
+// global propagators. The tracing middlewares created afterward use them.
+func (p *Provider) Install() {
+	otel.SetTracerProvider(p)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+}
+
+// NewExporter creates the exporter of the spans named by cfg, nil when none is. Jaeger
+// receives OTLP, over HTTP at a URL endpoint and over gRPC otherwise.
+func NewExporter(ctx context.Context, cfg *tracev1.Trace) (sdktrace.SpanExporter, error) {
+	endpoint := cfg.GetEndpoint()
+	timeout := cfg.GetTimeout().AsDuration()
+	name := strings.ToLower(cfg.GetName())
+	if name == ExporterJaeger {
+		name = ExporterOTLPGRPC
+		if strings.Contains(endpoint, "://") {
+			name = ExporterOTLPHTTP
+		}
+	}
+	var (
+		exporter sdktrace.SpanExporter
+		err      error
+	)
+	switch name {
+	case "":
+		return nil, nil
+	case ExporterOTLP, ExporterOTLPGRPC:
+		var grpcOpts []otlptracegrpc.Option
+		if strings.Contains(endpoint, "://") {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpointURL(endpoint))
+		} else if endpoint != "" {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+		}
+		if timeout > 0 {
+			grpcOpts = append(grpcOpts, otlptracegrpc.WithTimeout(timeout))
+		}
+		exporter, err = otlptracegrpc.New(ctx, grpcOpts...)
+	case ExporterOTLPHTTP:
+		var httpOpts []otlptracehttp.Option
+		if strings.Contains(endpoint, "://") {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpointURL(endpoint))
+		} else if endpoint != "" {
+			httpOpts = append(httpOpts, otlptracehttp.WithEndpoint(endpoint), otlptracehttp.WithInsecure())
+		}
+		if timeout > 0 {
+			httpOpts = append(httpOpts, otlptracehttp.WithTimeout(timeout))
+		}
+		exporter, err = otlptracehttp.New(ctx, httpOpts...)
+	case ExporterStdout:
+		exporter, err = stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+	case ExporterMemory:
+		exporter = tracetest.NewInMemoryExporter()
+	default:
+		return nil, fmt.Errorf("trace: unknown exporter %q", cfg.GetName())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("trace: create %s exporter: %w", cfg.GetName(), err)
+	}
+	return exporter, nil
+}
+
+// NewSampler returns the sampler of cfg: the spans are sampled with the probability of
+// its ratio, all of them without one, unless their parent span is not sampled.
+func NewSampler(cfg *tracev1.Trace) sdktrace.Sampler {
+	if cfg == nil || cfg.Ratio == nil {
+		return sdktrace.ParentBased(sdktrace.AlwaysSample())
+	}
+	return sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.GetRatio()))
+}
+
+// NewResource returns the resource of the spans, the default one with the attributes of
+// app: its name, unless cfg names the service, version, project, instance, environment,
+// host and metadata.
+func NewResource(cfg *tracev1.Trace, app *appv1.App) (*resource.Resource, error) {
+	var attrs []attribute.KeyValue
+	add := func(key attribute.Key, value string) {
+		if value != "" {
+			attrs = append(attrs, key.String(value))
+		}
+	}
+	for k, v := range app.GetMetadata() {
+		add(attribute.Key(k), v)
+	}
+	serviceName := cfg.GetServiceName()
+	if serviceName == "" {
+		serviceName = app.GetName()
+	}
+	instanceID := app.GetInstanceId()
+	if instanceID == "" {
+		instanceID = app.GetId()
+	}
+	add(semconv.ServiceNameKey, serviceName)
+	add(semconv.ServiceVersionKey, app.GetVersion())
+	add(semconv.ServiceNamespaceKey, app.GetProject())
+	add(semconv.ServiceInstanceIDKey, instanceID)
+	add(semconv.DeploymentEnvironmentNameKey, app.GetEnv())
+	add(semconv.HostNameKey, app.GetHostname())
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL, attrs...))
+	if err != nil {
+		return nil, fmt.Errorf("trace: create resource: %w", err)
+	}
+	return res, nil
+}
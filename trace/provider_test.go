@@ -0,0 +1,150 @@
+package trace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	appv1 "github.com/origadmin/runtime/api/gen/go/config/app/v1"
+	tracev1 "github.com/origadmin/runtime/api/gen/go/config/trace/v1"
+	"github.com/origadmin/runtime/contracts/component"
+	"github.com/origadmin/runtime/engine/container"
+	"github.com/origadmin/runtime/helpers/comp"
+)
+
+var testApp = &appv1.App{
+	Project:    "origadmin",
+	Id:         "app-1",
+	Name:       "orders",
+	Version:    "v1.2.3",
+	Env:        "prod",
+	Hostname:   "node-1",
+	InstanceId: "orders-1",
+	Metadata:   map[string]string{"region": "eu"},
+}
+
+func newMemoryProvider(t *testing.T, cfg *tracev1.Trace) (*Provider, *tracetest.InMemoryExporter) {
+	t.Helper()
+	provider, err := NewProvider(context.Background(), cfg, WithAppInfo(testApp))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = provider.Shutdown(context.Background()) })
+	exporter, ok := provider.Exporter().(*tracetest.InMemoryExporter)
+	require.True(t, ok)
+	return provider, exporter
+}
+
+func TestNewProvider(t *testing.T) {
+	provider, exporter := newMemoryProvider(t, &tracev1.Trace{Name: ExporterMemory})
+	_, span := provider.Tracer("test").Start(context.Background(), "operation")
+	span.End()
+
+	spans := exporter.GetSpans()
+	require.Len(t, spans, 1)
+	assert.Equal(t, "operation", spans[0].Name)
+	attrs := spans[0].Resource.Set()
+	for key, want := range map[attribute.Key]string{
+		semconv.ServiceNameKey:               "orders",
+		semconv.ServiceVersionKey:            "v1.2.3",
+		semconv.ServiceNamespaceKey:          "origadmin",
+		semconv.ServiceInstanceIDKey:         "orders-1",
+		semconv.DeploymentEnvironmentNameKey: "prod",
+		semconv.HostNameKey:                  "node-1",
+		"region":                             "eu",
+	} {
+		value, ok := attrs.Value(key)
+		assert.True(t, ok, key)
+		assert.Equal(t, want, value.AsString(), key)
+	}
+	_, ok := attrs.Value(semconv.TelemetrySDKNameKey)
+	assert.True(t, ok, "the default resource is kept")
+
+	res, err := NewResource(&tracev1.Trace{ServiceName: "orders-api"}, testApp)
+	require.NoError(t, err)
+	value, _ := res.Set().Value(semconv.ServiceNameKey)
+	assert.Equal(t, "orders-api", value.AsString(), "the configuration names the service")
+}
+
+func TestSampler(t *testing.T) {
+	provider, exporter := newMemoryProvider(t, &tracev1.Trace{Name: ExporterMemory, Ratio: proto.Float64(0)})
+	ctx, span := provider.Tracer("test").Start(context.Background(), "dropped")
+	assert.False(t, span.SpanContext().IsSampled())
+	span.End()
+	assert.Empty(t, exporter.GetSpans())
+
+	sampled, _ := newMemoryProvider(t, &tracev1.Trace{Name: ExporterMemory})
+	_, child := sampled.Tracer("test").Start(ctx, "child")
+	assert.False(t, child.SpanContext().IsSampled(), "the decision of the parent is kept")
+	child.End()
+
+	sampler := NewSampler(&tracev1.Trace{Ratio: proto.Float64(0.5)})
+	assert.Contains(t, sampler.Description(), "TraceIDRatioBased{0.5}")
+	assert.Contains(t, NewSampler(nil).Description(), "AlwaysOnSampler")
+}
+
+func TestNewExporter(t *testing.T) {
+	ctx := context.Background()
+	for _, cfg := range []*tracev1.Trace{
+		{Name: ExporterOTLP, Endpoint: "localhost:4317", Timeout: durationpb.New(1)},
+		{Name: ExporterOTLPHTTP, Endpoint: "https://collector:4318"},
+		{Name: "Jaeger", Endpoint: "http://jaeger:14268/api/traces"},
+		{Name: ExporterStdout},
+	} {
+		exporter, err := NewExporter(ctx, cfg)
+		require.NoError(t, err, cfg.GetName())
+		require.NotNil(t, exporter, cfg.GetName())
+		assert.NoError(t, exporter.Shutdown(ctx))
+	}
+	exporter, err := NewExporter(ctx, &tracev1.Trace{})
+	assert.NoError(t, err)
+	assert.Nil(t, exporter, "the spans are not exported")
+	_, err = NewExporter(ctx, &tracev1.Trace{Name: "zipkin"})
+	assert.Error(t, err)
+
+	provider, err := NewProvider(ctx, &tracev1.Trace{}, WithExporter(tracetest.NewNoopExporter()))
+	require.NoError(t, err)
+	assert.IsType(t, tracetest.NewNoopExporter(), provider.Exporter())
+	assert.NoError(t, provider.Shutdown(ctx))
+}
+
+type traceConfig struct {
+	trace *tracev1.Trace
+}
+
+func (c *traceConfig) GetTrace() *tracev1.Trace { return c.trace }
+
+func TestDefaultProvider(t *testing.T) {
+	ctx := context.Background()
+	defer otel.SetTracerProvider(otel.GetTracerProvider())
+
+	reg := container.NewContainer(container.WithCategoryResolvers(map[component.Category]component.ConfigResolver{
+		"tracer": Resolve,
+	}))
+	reg.Register("tracer", DefaultProvider)
+	reg.Requirement("tracer", RequirementAppInfo, func(context.Context, component.Handle, string) (any, error) {
+		return testApp, nil
+	})
+	require.NoError(t, reg.Load(ctx, &traceConfig{trace: &tracev1.Trace{Name: ExporterMemory}}))
+
+	provider, err := comp.Get[*Provider](ctx, reg.In("tracer"))
+	require.NoError(t, err)
+	defer provider.Shutdown(ctx)
+	assert.Same(t, provider, otel.GetTracerProvider(), "the provider is installed")
+	_, span := otel.Tracer("test").Start(ctx, "operation")
+	span.End()
+	spans := provider.Exporter().(*tracetest.InMemoryExporter).GetSpans()
+	require.Len(t, spans, 1)
+	value, _ := spans[0].Resource.Set().Value(semconv.ServiceNameKey)
+	assert.Equal(t, "orders", value.AsString())
+
+	res, err := Resolve(ctx, &traceConfig{}, nil)
+	require.NoError(t, err)
+	assert.Empty(t, res.Entries, "no trace configuration, no tracer")
+}
@@ -0,0 +1,51 @@
+/*
+ * Copyright (c) 2024 OrigAdmin. All rights reserved.
+ */
+
+package trace
+
+import (
+	"context"
+
+	appv1 "github.com/origadmin/runtime/api/gen/go/config/app/v1"
+	tracev1 "github.com/origadmin/runtime/api/gen/go/config/trace/v1"
+	"github.com/origadmin/runtime/contracts"
+	"github.com/origadmin/runtime/contracts/component"
+	"github.com/origadmin/runtime/contracts/options"
+	"github.com/origadmin/runtime/helpers/comp"
+)
+
+// RequirementAppInfo is the purpose for gathering the application described by the
+// resource of the spans.
+const RequirementAppInfo = "app_info"
+
+// Resolve resolves the trace configuration. A configuration without one has no tracer.
+func Resolve(ctx context.Context, source any, opts *component.LoadOptions) (*component.ModuleConfig, error) {
+	c, ok := source.(contracts.TraceConfig)
+	if !ok || c.GetTrace() == nil {
+		return &component.ModuleConfig{}, nil
+	}
+	return &component.ModuleConfig{
+		Entries: []component.ConfigEntry{{Name: "tracer", Value: c.GetTrace()}},
+		Active:  "tracer",
+	}, nil
+}
+
+// DefaultProvider is the engine-compatible provider for tracer components. The
+// Provider is installed as the global tracer provider as it is created.
+var DefaultProvider component.Provider = func(ctx context.Context, h component.Handle) (any, error) {
+	cfg, err := comp.AsConfig[tracev1.Trace](h)
+	if err != nil {
+		return nil, err
+	}
+	var opts []options.Option
+	if app, err := comp.RequireTyped[*appv1.App](h, RequirementAppInfo); err == nil {
+		opts = append(opts, WithAppInfo(app))
+	}
+	provider, err := NewProvider(ctx, cfg, opts...)
+	if err != nil {
+		return nil, err
+	}
+	provider.Install()
+	return provider, nil
+}
@@ -27,6 +27,7 @@ type Container = component.Container
 const (
 	CategoryInfrastructure Category = "infrastructure"
 	CategoryLogger         Category = "logger"
+	CategoryTracer         Category = "tracer"
 	CategoryRegistrar      Category = "registrar"
 	CategoryDiscovery      Category = "discovery"
 	CategoryClient         Category = "client"